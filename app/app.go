@@ -0,0 +1,208 @@
+package app
+
+import (
+	"assignment-4/config"
+	"assignment-4/db"
+	"assignment-4/domain/list_domain"
+	"assignment-4/domain/tag_domain"
+	"assignment-4/domain/todo_domain"
+	"assignment-4/domain/user_domain"
+	"assignment-4/metrics"
+	"assignment-4/router"
+	"assignment-4/service/health_service"
+	"assignment-4/service/todo_service"
+	"assignment-4/tracing"
+	"assignment-4/utils/etag_utils"
+	"assignment-4/utils/token_utils"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const tracingFlushTimeout = 5 * time.Second
+
+// App owns the HTTP server and the resources it depends on. The lifecycle is
+// New, Start, then Shutdown (or Run, which does all three).
+type App struct {
+	cfg      *config.Config
+	server   *http.Server
+	listener net.Listener
+	serveErr chan error
+	closers  []func() error
+}
+
+// New wires the repositories and builds the server. With the postgres
+// repository it connects to the database and runs pending migrations.
+func New(cfg *config.Config) (*App, error) {
+	log.Info().
+		Str("repository", cfg.Repository).
+		Str("http_addr", cfg.HTTP.Addr).
+		Str("log_level", cfg.Log.Level).
+		Msg("starting, see the config command for the full configuration")
+
+	token_utils.SetSecret(cfg.Auth.JWTSecret)
+	etag_utils.SetRequireIfMatch(cfg.HTTP.RequireIfMatch)
+	todo_service.SetChecklistSync(cfg.Checklist.SyncCompletion)
+	health_service.HealthService = health_service.NewHealthService(cfg.DB.PingTimeout)
+
+	var closers []func() error
+
+	shutdownTracing, err := tracing.Setup(cfg.Tracing, os.Stdout)
+	if err != nil {
+		return nil, err
+	}
+	closers = append(closers, func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), tracingFlushTimeout)
+		defer cancel()
+
+		return shutdownTracing(ctx)
+	})
+
+	if cfg.Repository == config.RepositoryMemory {
+		todo_domain.TodoDomain = todo_domain.NewTodoMemoryRepo()
+		user_domain.UserDomain = user_domain.NewUserMemoryRepo()
+		list_domain.ListDomain = list_domain.NewListMemoryRepo()
+		tag_domain.TagDomain = tag_domain.NewTagMemoryRepo()
+	} else {
+		if err := db.InitializeDB(cfg.DB); err != nil {
+			closeAll(closers)
+			return nil, err
+		}
+		closers = append(closers, db.Close)
+
+		if err := metrics.RegisterDBStats(db.GetDB()); err != nil {
+			closeAll(closers)
+			return nil, fmt.Errorf("registering database metrics: %w", err)
+		}
+	}
+
+	todo_domain.TodoDomain = todo_domain.WithMetrics(todo_domain.TodoDomain)
+	todo_service.TodoService = todo_service.WithTracing(todo_service.TodoService)
+	closers = append(closers, todo_service.StartTrashPurger(cfg.Trash.Retention, cfg.Trash.PurgeInterval))
+
+	return newApp(cfg, router.New(cfg), closers...), nil
+}
+
+// closeAll releases what New acquired before failing, newest first like
+// Shutdown does.
+func closeAll(closers []func() error) {
+	for i := len(closers) - 1; i >= 0; i-- {
+		closers[i]()
+	}
+}
+
+func newApp(cfg *config.Config, handler http.Handler, closers ...func() error) *App {
+	return &App{
+		cfg: cfg,
+		server: &http.Server{
+			Addr:         cfg.HTTP.Addr,
+			Handler:      handler,
+			ReadTimeout:  cfg.HTTP.ReadTimeout,
+			WriteTimeout: cfg.HTTP.WriteTimeout,
+			IdleTimeout:  cfg.HTTP.IdleTimeout,
+		},
+		serveErr: make(chan error, 1),
+		closers:  closers,
+	}
+}
+
+// Start binds the listen address and serves in the background. Binding
+// errors are returned here rather than surfacing later.
+func (a *App) Start() error {
+	listener, err := net.Listen("tcp", a.cfg.HTTP.Addr)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", a.cfg.HTTP.Addr, err)
+	}
+	a.listener = listener
+
+	go func() {
+		if err := a.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			a.serveErr <- err
+		}
+		close(a.serveErr)
+	}()
+
+	log.Info().Str("addr", listener.Addr().String()).Msg("listening")
+
+	return nil
+}
+
+// Addr is the address the server listens on, available after Start.
+func (a *App) Addr() string {
+	if a.listener == nil {
+		return a.cfg.HTTP.Addr
+	}
+
+	return a.listener.Addr().String()
+}
+
+// Shutdown stops accepting connections, waits for in-flight requests until
+// ctx is done and then closes the database pool.
+func (a *App) Shutdown(ctx context.Context) error {
+	var errs []error
+
+	if err := a.server.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("draining http server: %w", err))
+		a.server.Close()
+	}
+
+	for i := len(a.closers) - 1; i >= 0; i-- {
+		if err := a.closers[i](); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return joinErrors(errs)
+}
+
+// Run starts the app and blocks until ctx is cancelled, e.g. by SIGTERM, or
+// the server fails. It then shuts down within the configured timeout.
+func (a *App) Run(ctx context.Context) error {
+	if err := a.Start(); err != nil {
+		a.Shutdown(context.Background())
+		return err
+	}
+
+	var runErr error
+
+	select {
+	case <-ctx.Done():
+		log.Info().Dur("shutdown_timeout", a.cfg.HTTP.ShutdownTimeout).Msg("shutting down, waiting for in-flight requests")
+	case runErr = <-a.serveErr:
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), a.cfg.HTTP.ShutdownTimeout)
+	defer cancel()
+
+	if err := a.Shutdown(shutdownCtx); err != nil {
+		if runErr != nil {
+			return joinErrors([]error{runErr, err})
+		}
+		return err
+	}
+
+	return runErr
+}
+
+func joinErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+
+	return errors.New(strings.Join(messages, "; "))
+}
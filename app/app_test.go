@@ -0,0 +1,118 @@
+package app
+
+import (
+	"assignment-4/config"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConfig() *config.Config {
+	cfg := config.Default()
+	cfg.HTTP.Addr = "127.0.0.1:0"
+	cfg.HTTP.ShutdownTimeout = time.Second
+
+	return cfg
+}
+
+func TestApp_ShutdownDrainsInFlightRequests(t *testing.T) {
+	started := make(chan struct{})
+	var closedAt, finishedAt time.Time
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		finishedAt = time.Now()
+		io.WriteString(w, "done")
+	})
+
+	a := newApp(newTestConfig(), handler, func() error {
+		closedAt = time.Now()
+		return nil
+	})
+	require.Nil(t, a.Start())
+
+	type result struct {
+		body string
+		err  error
+	}
+	results := make(chan result, 1)
+
+	go func() {
+		res, err := http.Get("http://" + a.Addr())
+		if err != nil {
+			results <- result{err: err}
+			return
+		}
+		defer res.Body.Close()
+
+		body, _ := io.ReadAll(res.Body)
+		results <- result{body: string(body)}
+	}()
+
+	<-started
+	require.Nil(t, a.Shutdown(context.Background()))
+
+	res := <-results
+	require.Nil(t, res.err)
+	assert.EqualValues(t, "done", res.body)
+	assert.False(t, closedAt.Before(finishedAt), "pool closed before the request finished")
+
+	_, err := http.Get("http://" + a.Addr())
+	assert.NotNil(t, err)
+}
+
+func TestApp_RunStopsWhenContextIsCancelled(t *testing.T) {
+	closed := false
+
+	a := newApp(newTestConfig(), http.NotFoundHandler(), func() error {
+		closed = true
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() {
+		done <- a.Run(ctx)
+	}()
+
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.Nil(t, err)
+		assert.True(t, closed)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not return after the context was cancelled")
+	}
+}
+
+func TestApp_StartFailsOnBusyAddress(t *testing.T) {
+	first := newApp(newTestConfig(), http.NotFoundHandler())
+	require.Nil(t, first.Start())
+	defer first.Shutdown(context.Background())
+
+	cfg := newTestConfig()
+	cfg.HTTP.Addr = first.Addr()
+
+	err := newApp(cfg, http.NotFoundHandler()).Start()
+
+	assert.NotNil(t, err)
+}
+
+func TestCloseAll_NewestFirst(t *testing.T) {
+	var closed []string
+
+	closeAll([]func() error{
+		func() error { closed = append(closed, "tracing"); return nil },
+		func() error { closed = append(closed, "db"); return nil },
+	})
+
+	assert.EqualValues(t, []string{"db", "tracing"}, closed)
+}
@@ -0,0 +1,318 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	RepositoryPostgres = "postgres"
+	RepositoryMemory   = "memory"
+
+	redacted = "******"
+
+	// MinJWTSecretLength is the HS256 key size; shorter secrets can be
+	// brute forced from a single token.
+	MinJWTSecretLength = 32
+	// jwtSecretPlaceholder is the example secret from .env and
+	// config.example.yaml, which anyone can forge tokens with.
+	jwtSecretPlaceholder = "change-me-in-production"
+)
+
+type Config struct {
+	Repository string          `yaml:"repository" toml:"repository"`
+	HTTP       HTTPConfig      `yaml:"http" toml:"http"`
+	DB         DBConfig        `yaml:"db" toml:"db"`
+	Auth       AuthConfig      `yaml:"auth" toml:"auth"`
+	Log        LogConfig       `yaml:"log" toml:"log"`
+	Tracing    TracingConfig   `yaml:"tracing" toml:"tracing"`
+	Trash      TrashConfig     `yaml:"trash" toml:"trash"`
+	Checklist  ChecklistConfig `yaml:"checklist" toml:"checklist"`
+}
+
+type HTTPConfig struct {
+	Addr            string        `yaml:"addr" toml:"addr"`
+	RequestTimeout  time.Duration `yaml:"request_timeout" toml:"request_timeout"`
+	ReadTimeout     time.Duration `yaml:"read_timeout" toml:"read_timeout"`
+	WriteTimeout    time.Duration `yaml:"write_timeout" toml:"write_timeout"`
+	IdleTimeout     time.Duration `yaml:"idle_timeout" toml:"idle_timeout"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+	RequireIfMatch  bool          `yaml:"require_if_match" toml:"require_if_match"`
+}
+
+// DBConfig either holds a complete DSN or the parts to build one from.
+type DBConfig struct {
+	Driver          string        `yaml:"driver" toml:"driver"`
+	DSN             string        `yaml:"dsn" toml:"dsn"`
+	Host            string        `yaml:"host" toml:"host"`
+	Port            int           `yaml:"port" toml:"port"`
+	User            string        `yaml:"user" toml:"user"`
+	Password        string        `yaml:"password" toml:"password"`
+	Name            string        `yaml:"name" toml:"name"`
+	SSLMode         string        `yaml:"sslmode" toml:"sslmode"`
+	ConnectTimeout  time.Duration `yaml:"connect_timeout" toml:"connect_timeout"`
+	ConnectAttempts int           `yaml:"connect_attempts" toml:"connect_attempts"`
+	PingTimeout     time.Duration `yaml:"ping_timeout" toml:"ping_timeout"`
+	MaxOpenConns    int           `yaml:"max_open_conns" toml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" toml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" toml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" toml:"conn_max_idle_time"`
+}
+
+type AuthConfig struct {
+	JWTSecret string `yaml:"jwt_secret" toml:"jwt_secret"`
+}
+
+type LogConfig struct {
+	Level string `yaml:"level" toml:"level"`
+}
+
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter" toml:"exporter"`
+	ServiceName string  `yaml:"service_name" toml:"service_name"`
+	SampleRatio float64 `yaml:"sample_ratio" toml:"sample_ratio"`
+}
+
+// TrashConfig controls how long deleted todos can still be restored.
+type TrashConfig struct {
+	Retention     time.Duration `yaml:"retention" toml:"retention"`
+	PurgeInterval time.Duration `yaml:"purge_interval" toml:"purge_interval"`
+}
+
+// ChecklistConfig controls how checklist items follow the completion of
+// their todo.
+type ChecklistConfig struct {
+	SyncCompletion bool `yaml:"sync_completion" toml:"sync_completion"`
+}
+
+func Default() *Config {
+	return &Config{
+		Repository: RepositoryPostgres,
+		HTTP: HTTPConfig{
+			Addr:            ":8080",
+			RequestTimeout:  10 * time.Second,
+			ReadTimeout:     15 * time.Second,
+			WriteTimeout:    30 * time.Second,
+			IdleTimeout:     60 * time.Second,
+			ShutdownTimeout: 20 * time.Second,
+		},
+		DB: DBConfig{
+			Driver:          "postgres",
+			Host:            "127.0.0.1",
+			Port:            5432,
+			SSLMode:         "disable",
+			ConnectTimeout:  5 * time.Second,
+			ConnectAttempts: 5,
+			PingTimeout:     2 * time.Second,
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			ServiceName: "todo-api",
+			SampleRatio: 1,
+		},
+		Trash: TrashConfig{
+			Retention:     30 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+		},
+	}
+}
+
+// Validate reports every invalid setting at once so a misconfigured
+// deployment can be fixed in one go.
+func (c *Config) Validate() error {
+	var problems []string
+
+	if c.Repository != RepositoryPostgres && c.Repository != RepositoryMemory {
+		problems = append(problems, fmt.Sprintf("repository must be %s or %s, got %q", RepositoryPostgres, RepositoryMemory, c.Repository))
+	}
+
+	if _, _, err := net.SplitHostPort(c.HTTP.Addr); err != nil {
+		problems = append(problems, fmt.Sprintf("http.addr %q is not a valid listen address such as :8080", c.HTTP.Addr))
+	}
+
+	for name, timeout := range map[string]time.Duration{
+		"http.request_timeout":  c.HTTP.RequestTimeout,
+		"http.read_timeout":     c.HTTP.ReadTimeout,
+		"http.write_timeout":    c.HTTP.WriteTimeout,
+		"http.idle_timeout":     c.HTTP.IdleTimeout,
+		"http.shutdown_timeout": c.HTTP.ShutdownTimeout,
+	} {
+		if timeout <= 0 {
+			problems = append(problems, name+" must be a positive duration such as 10s")
+		}
+	}
+
+	if c.Repository == RepositoryPostgres {
+		problems = append(problems, c.DB.validate()...)
+	}
+
+	switch {
+	case c.Auth.JWTSecret == "":
+		problems = append(problems, "auth.jwt_secret is required")
+	case c.Auth.JWTSecret == jwtSecretPlaceholder:
+		problems = append(problems, "auth.jwt_secret must be changed from the example value")
+	case len(c.Auth.JWTSecret) < MinJWTSecretLength:
+		problems = append(problems, fmt.Sprintf("auth.jwt_secret must be at least %d bytes, got %d", MinJWTSecretLength, len(c.Auth.JWTSecret)))
+	}
+
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		problems = append(problems, fmt.Sprintf("log.level must be one of debug, info, warn, error, got %q", c.Log.Level))
+	}
+
+	switch c.Tracing.Exporter {
+	case "none", "stdout", "otlp":
+	default:
+		problems = append(problems, fmt.Sprintf("tracing.exporter must be one of none, stdout, otlp, got %q", c.Tracing.Exporter))
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		problems = append(problems, fmt.Sprintf("tracing.sample_ratio must be between 0 and 1, got %g", c.Tracing.SampleRatio))
+	}
+
+	if c.Trash.Retention <= 0 || c.Trash.PurgeInterval <= 0 {
+		problems = append(problems, "trash.retention and trash.purge_interval must be positive durations such as 720h")
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	sort.Strings(problems)
+
+	return fmt.Errorf("invalid configuration:\n  - %s", strings.Join(problems, "\n  - "))
+}
+
+func (d *DBConfig) validate() []string {
+	var problems []string
+
+	if d.Driver == "" {
+		problems = append(problems, "db.driver is required")
+	}
+
+	if d.DSN == "" {
+		if d.Host == "" {
+			problems = append(problems, "db.host is required when db.dsn is not set")
+		}
+		if d.Name == "" {
+			problems = append(problems, "db.name is required when db.dsn is not set")
+		}
+		if d.User == "" {
+			problems = append(problems, "db.user is required when db.dsn is not set")
+		}
+		if d.Port < 1 || d.Port > 65535 {
+			problems = append(problems, fmt.Sprintf("db.port must be between 1 and 65535, got %d", d.Port))
+		}
+	}
+
+	switch d.SSLMode {
+	case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+	default:
+		problems = append(problems, fmt.Sprintf("db.sslmode must be one of disable, allow, prefer, require, verify-ca, verify-full, got %q", d.SSLMode))
+	}
+
+	if d.ConnectTimeout < 0 {
+		problems = append(problems, "db.connect_timeout must not be negative")
+	}
+
+	if d.ConnectAttempts < 1 {
+		problems = append(problems, "db.connect_attempts must be at least 1")
+	}
+
+	if d.PingTimeout <= 0 {
+		problems = append(problems, "db.ping_timeout must be a positive duration such as 2s")
+	}
+
+	if d.MaxOpenConns < 0 {
+		problems = append(problems, "db.max_open_conns must not be negative")
+	}
+
+	if d.MaxIdleConns < 0 {
+		problems = append(problems, "db.max_idle_conns must not be negative")
+	} else if d.MaxOpenConns > 0 && d.MaxIdleConns > d.MaxOpenConns {
+		problems = append(problems, "db.max_idle_conns must not exceed db.max_open_conns")
+	}
+
+	if d.ConnMaxLifetime < 0 || d.ConnMaxIdleTime < 0 {
+		problems = append(problems, "db.conn_max_lifetime and db.conn_max_idle_time must not be negative")
+	}
+
+	return problems
+}
+
+// ConnectionString returns DSN when set, otherwise a postgres URL built
+// from the individual parts.
+func (d *DBConfig) ConnectionString() string {
+	if d.DSN != "" {
+		return d.DSN
+	}
+
+	query := url.Values{}
+	query.Set("sslmode", d.SSLMode)
+	if d.ConnectTimeout > 0 {
+		// libpq only takes whole seconds and waits forever on 0, so round up
+		query.Set("connect_timeout", strconv.Itoa(int(math.Ceil(d.ConnectTimeout.Seconds()))))
+	}
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(d.User, d.Password),
+		Host:     net.JoinHostPort(d.Host, strconv.Itoa(d.Port)),
+		Path:     "/" + d.Name,
+		RawQuery: query.Encode(),
+	}
+
+	return dsn.String()
+}
+
+// Redacted returns a copy of c that is safe to print.
+func (c Config) Redacted() Config {
+	if c.DB.Password != "" {
+		c.DB.Password = redacted
+	}
+
+	if c.Auth.JWTSecret != "" {
+		c.Auth.JWTSecret = redacted
+	}
+
+	c.DB.DSN = redactDSN(c.DB.DSN)
+
+	return c
+}
+
+func redactDSN(dsn string) string {
+	if dsn == "" {
+		return dsn
+	}
+
+	if parsed, err := url.Parse(dsn); err == nil && parsed.Scheme != "" {
+		if _, ok := parsed.User.Password(); ok {
+			parsed.User = url.UserPassword(parsed.User.Username(), redacted)
+		}
+		return parsed.String()
+	}
+
+	// key=value form, e.g. "host=db user=app password=secret"
+	fields := strings.Fields(dsn)
+	for i, field := range fields {
+		if strings.HasPrefix(field, "password=") {
+			fields[i] = "password=" + redacted
+		}
+	}
+
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,184 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.Nil(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+http:
+  addr: ":9000"
+  request_timeout: 3s
+db:
+  host: db.internal
+  user: app
+  name: todos
+  max_open_conns: 10
+auth:
+  jwt_secret: from-file-0123456789abcdefghijklmnop
+`)
+
+	t.Setenv("DB_HOST", "db.env")
+	t.Setenv("REQUEST_TIMEOUT", "4s")
+
+	cfg, args, err := Load([]string{"-config", path, "-request-timeout", "5s", "migrate", "up"})
+
+	require.Nil(t, err)
+	assert.EqualValues(t, []string{"migrate", "up"}, args)
+	assert.EqualValues(t, ":9000", cfg.HTTP.Addr)
+	assert.EqualValues(t, 5*time.Second, cfg.HTTP.RequestTimeout)
+	assert.EqualValues(t, "db.env", cfg.DB.Host)
+	assert.EqualValues(t, 10, cfg.DB.MaxOpenConns)
+	assert.EqualValues(t, 5432, cfg.DB.Port)
+	assert.EqualValues(t, "from-file-0123456789abcdefghijklmnop", cfg.Auth.JWTSecret)
+	assert.EqualValues(t, "postgres://app:@db.env:5432/todos?connect_timeout=5&sslmode=disable", cfg.DB.ConnectionString())
+}
+
+func TestLoad_TOMLFile(t *testing.T) {
+	path := writeFile(t, "config.toml", `
+repository = "memory"
+
+[http]
+addr = "127.0.0.1:8081"
+idle_timeout = "2m"
+
+[auth]
+jwt_secret = "from-toml-0123456789abcdefghijklmnop"
+`)
+
+	cfg, _, err := Load([]string{"-config", path})
+
+	require.Nil(t, err)
+	assert.EqualValues(t, RepositoryMemory, cfg.Repository)
+	assert.EqualValues(t, "127.0.0.1:8081", cfg.HTTP.Addr)
+	assert.EqualValues(t, 2*time.Minute, cfg.HTTP.IdleTimeout)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   string
+		args   []string
+		errMsg string
+	}{
+		{
+			name:   "unknown yaml key",
+			file:   "http:\n  port: 8080\n",
+			errMsg: "field port not found",
+		},
+		{
+			name:   "invalid flag value",
+			args:   []string{"-db-port", "abc"},
+			errMsg: `invalid -db-port flag: "abc" is not an integer`,
+		},
+		{
+			name:   "validation problems",
+			args:   []string{"-db-sslmode", "sometimes", "-log-level", "loud", "-http-addr", "8080"},
+			errMsg: "invalid configuration:\n  - auth.jwt_secret is required\n  - db.name is required when db.dsn is not set\n  - db.sslmode must be one of disable, allow, prefer, require, verify-ca, verify-full, got \"sometimes\"\n  - db.user is required when db.dsn is not set\n  - http.addr \"8080\" is not a valid listen address such as :8080\n  - log.level must be one of debug, info, warn, error, got \"loud\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.args
+			if tt.file != "" {
+				args = append([]string{"-config", writeFile(t, "config.yaml", tt.file)}, args...)
+			}
+
+			cfg, _, err := Load(args)
+
+			assert.Nil(t, cfg)
+			require.NotNil(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestConfig_Validate_JWTSecret(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret string
+		errMsg string
+	}{
+		{
+			name:   "example placeholder",
+			secret: "change-me-in-production",
+			errMsg: "auth.jwt_secret must be changed from the example value",
+		},
+		{
+			name:   "too short",
+			secret: "0123456789abcdef0123456789abcde",
+			errMsg: "auth.jwt_secret must be at least 32 bytes, got 31",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Repository = RepositoryMemory
+			cfg.Auth.JWTSecret = tt.secret
+
+			err := cfg.Validate()
+
+			require.NotNil(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+
+	cfg := Default()
+	cfg.Repository = RepositoryMemory
+	cfg.Auth.JWTSecret = "0123456789abcdef0123456789abcdef"
+
+	assert.Nil(t, cfg.Validate())
+}
+
+func TestDBConfig_ConnectionString_RoundsUpConnectTimeout(t *testing.T) {
+	db := Default().DB
+	db.Host = "db"
+	db.User = "app"
+	db.Name = "todos"
+
+	for timeout, want := range map[time.Duration]string{
+		500 * time.Millisecond:  "connect_timeout=1",
+		1500 * time.Millisecond: "connect_timeout=2",
+		3 * time.Second:         "connect_timeout=3",
+	} {
+		db.ConnectTimeout = timeout
+
+		assert.Contains(t, db.ConnectionString(), want, timeout.String())
+	}
+}
+
+func TestConfig_PrintRedactsSecrets(t *testing.T) {
+	cfg := Default()
+	cfg.DB.Password = "db-password"
+	cfg.DB.DSN = "postgres://app:dsn-password@db:5432/todos"
+	cfg.Auth.JWTSecret = "jwt-secret"
+
+	var out bytes.Buffer
+	require.Nil(t, cfg.Print(&out))
+
+	assert.NotContains(t, out.String(), "db-password")
+	assert.NotContains(t, out.String(), "dsn-password")
+	assert.NotContains(t, out.String(), "jwt-secret")
+	assert.Contains(t, out.String(), "request_timeout: 10s")
+	assert.EqualValues(t, "db-password", cfg.DB.Password)
+}
+
+func TestRedactDSN_KeyValue(t *testing.T) {
+	assert.EqualValues(t, "host=db user=app password=******", redactDSN("host=db user=app password=secret"))
+}
@@ -0,0 +1,224 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// setting binds one Config field to its environment variable and, unless it
+// is a secret, to a command line flag.
+type setting struct {
+	env    string
+	flag   string
+	usage  string
+	target func(*Config) interface{}
+}
+
+var settings = []setting{
+	{"REPOSITORY", "repository", "todo storage, postgres or memory", func(c *Config) interface{} { return &c.Repository }},
+	{"HTTP_ADDR", "http-addr", "address the HTTP server listens on", func(c *Config) interface{} { return &c.HTTP.Addr }},
+	{"REQUEST_TIMEOUT", "request-timeout", "deadline for a single request", func(c *Config) interface{} { return &c.HTTP.RequestTimeout }},
+	{"HTTP_READ_TIMEOUT", "http-read-timeout", "maximum duration for reading a request", func(c *Config) interface{} { return &c.HTTP.ReadTimeout }},
+	{"HTTP_WRITE_TIMEOUT", "http-write-timeout", "maximum duration for writing a response", func(c *Config) interface{} { return &c.HTTP.WriteTimeout }},
+	{"HTTP_IDLE_TIMEOUT", "http-idle-timeout", "how long keep-alive connections stay open", func(c *Config) interface{} { return &c.HTTP.IdleTimeout }},
+	{"SHUTDOWN_TIMEOUT", "shutdown-timeout", "how long in-flight requests may take to finish on shutdown", func(c *Config) interface{} { return &c.HTTP.ShutdownTimeout }},
+	{"REQUIRE_IF_MATCH", "require-if-match", "reject todo writes without an If-Match header with 428", func(c *Config) interface{} { return &c.HTTP.RequireIfMatch }},
+	{"DB_DRIVER", "db-driver", "database/sql driver name", func(c *Config) interface{} { return &c.DB.Driver }},
+	{"DB_DSN", "", "", func(c *Config) interface{} { return &c.DB.DSN }},
+	{"DB_HOST", "db-host", "database host", func(c *Config) interface{} { return &c.DB.Host }},
+	{"DB_PORT", "db-port", "database port", func(c *Config) interface{} { return &c.DB.Port }},
+	{"DB_USER", "db-user", "database user", func(c *Config) interface{} { return &c.DB.User }},
+	{"DB_PASSWORD", "", "", func(c *Config) interface{} { return &c.DB.Password }},
+	{"DB_NAME", "db-name", "database name", func(c *Config) interface{} { return &c.DB.Name }},
+	{"DB_SSLMODE", "db-sslmode", "postgres sslmode", func(c *Config) interface{} { return &c.DB.SSLMode }},
+	{"DB_CONNECT_TIMEOUT", "db-connect-timeout", "timeout for opening a database connection", func(c *Config) interface{} { return &c.DB.ConnectTimeout }},
+	{"DB_CONNECT_ATTEMPTS", "db-connect-attempts", "how often to try the initial database connection", func(c *Config) interface{} { return &c.DB.ConnectAttempts }},
+	{"DB_PING_TIMEOUT", "db-ping-timeout", "timeout for the readiness database ping", func(c *Config) interface{} { return &c.DB.PingTimeout }},
+	{"DB_MAX_OPEN_CONNS", "db-max-open-conns", "maximum open database connections, 0 for unlimited", func(c *Config) interface{} { return &c.DB.MaxOpenConns }},
+	{"DB_MAX_IDLE_CONNS", "db-max-idle-conns", "maximum idle database connections", func(c *Config) interface{} { return &c.DB.MaxIdleConns }},
+	{"DB_CONN_MAX_LIFETIME", "db-conn-max-lifetime", "maximum lifetime of a database connection", func(c *Config) interface{} { return &c.DB.ConnMaxLifetime }},
+	{"DB_CONN_MAX_IDLE_TIME", "db-conn-max-idle-time", "maximum idle time of a database connection", func(c *Config) interface{} { return &c.DB.ConnMaxIdleTime }},
+	{"JWT_SECRET", "", "", func(c *Config) interface{} { return &c.Auth.JWTSecret }},
+	{"LOG_LEVEL", "log-level", "debug, info, warn or error", func(c *Config) interface{} { return &c.Log.Level }},
+	{"TRACING_EXPORTER", "tracing-exporter", "none, stdout or otlp (configured with the OTEL_EXPORTER_OTLP_* variables)", func(c *Config) interface{} { return &c.Tracing.Exporter }},
+	{"TRACING_SERVICE_NAME", "tracing-service-name", "service.name of exported spans", func(c *Config) interface{} { return &c.Tracing.ServiceName }},
+	{"TRACING_SAMPLE_RATIO", "tracing-sample-ratio", "fraction of new traces to sample, between 0 and 1", func(c *Config) interface{} { return &c.Tracing.SampleRatio }},
+	{"TRASH_RETENTION", "trash-retention", "how long deleted todos can be restored before they are purged", func(c *Config) interface{} { return &c.Trash.Retention }},
+	{"TRASH_PURGE_INTERVAL", "trash-purge-interval", "how often expired todos are purged from the trash", func(c *Config) interface{} { return &c.Trash.PurgeInterval }},
+	{"CHECKLIST_SYNC_COMPLETION", "checklist-sync-completion", "complete checklist items with their todo, and reopen a todo when an item is reopened", func(c *Config) interface{} { return &c.Checklist.SyncCompletion }},
+}
+
+// Load builds the configuration from, in increasing precedence, the
+// defaults, an optional YAML or TOML file, environment variables (a .env file
+// is read into the environment first) and command line flags. It returns the
+// arguments left after the flags, e.g. a subcommand.
+func Load(args []string) (*Config, []string, error) {
+	fs := flag.NewFlagSet("assignment-4", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "path to a .yaml, .yml or .toml config file")
+
+	flagValues := map[string]string{}
+	for _, s := range settings {
+		if s.flag == "" {
+			continue
+		}
+
+		name := s.flag
+		fs.Func(name, s.usage, func(value string) error {
+			flagValues[name] = value
+			return nil
+		})
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, fmt.Errorf("%w\n%s", err, Usage())
+	}
+
+	cfg := Default()
+
+	if *configFile != "" {
+		if err := loadFile(cfg, *configFile); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	godotenv.Load()
+
+	for _, s := range settings {
+		value, ok := os.LookupEnv(s.env)
+		if !ok || value == "" {
+			continue
+		}
+
+		if err := setValue(s.target(cfg), value); err != nil {
+			return nil, nil, fmt.Errorf("invalid %s environment variable: %w", s.env, err)
+		}
+	}
+
+	for _, s := range settings {
+		value, ok := flagValues[s.flag]
+		if !ok {
+			continue
+		}
+
+		if err := setValue(s.target(cfg), value); err != nil {
+			return nil, nil, fmt.Errorf("invalid -%s flag: %w", s.flag, err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	return cfg, fs.Args(), nil
+}
+
+// Usage lists the supported flags and environment variables.
+func Usage() string {
+	var buf bytes.Buffer
+
+	fmt.Fprintln(&buf, "flags:")
+	fmt.Fprintln(&buf, "  -config\tpath to a .yaml, .yml or .toml config file (env CONFIG_FILE)")
+
+	for _, s := range settings {
+		if s.flag == "" {
+			continue
+		}
+		fmt.Fprintf(&buf, "  -%s\t%s (env %s)\n", s.flag, s.usage, s.env)
+	}
+
+	fmt.Fprint(&buf, "secrets are only read from the config file or env: DB_DSN, DB_PASSWORD, JWT_SECRET")
+
+	return buf.String()
+}
+
+func loadFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+
+		if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("parsing config file %s: %w", path, err)
+		}
+	case ".toml":
+		meta, err := toml.Decode(string(data), cfg)
+		if err != nil {
+			return fmt.Errorf("parsing config file %s: %w", path, err)
+		}
+
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("parsing config file %s: unknown key %s", path, undecoded[0])
+		}
+	default:
+		return fmt.Errorf("config file %s must end in .yaml, .yml or .toml", path)
+	}
+
+	return nil
+}
+
+func setValue(target interface{}, value string) error {
+	switch target := target.(type) {
+	case *string:
+		*target = value
+	case *int:
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+		*target = parsed
+	case *bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+		*target = parsed
+	case *float64:
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+		*target = parsed
+	case *time.Duration:
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration such as 10s", value)
+		}
+		*target = parsed
+	default:
+		return fmt.Errorf("unsupported setting type %T", target)
+	}
+
+	return nil
+}
+
+// Print writes the effective configuration as YAML with secrets redacted.
+func (c *Config) Print(w io.Writer) error {
+	safe := c.Redacted()
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(&safe); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
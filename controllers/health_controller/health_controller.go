@@ -0,0 +1,40 @@
+package health_controller
+
+import (
+	"assignment-4/service/health_service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Healthz godoc
+// @Summary Liveness probe
+// @Tags health
+// @Description Reporting that the process is up, it does not touch the database
+// @ID healthz
+// @Produce json
+// @Success 200 {object} health_service.Check
+// @Router /healthz [get]
+func Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, health_service.Check{Status: health_service.StatusOk})
+}
+
+// Readyz godoc
+// @Summary Readiness probe
+// @Tags health
+// @Description Pinging the database, checking the migration version and reporting connection pool stats
+// @ID readyz
+// @Produce json
+// @Success 200 {object} health_service.Readiness
+// @Failure 503 {object} health_service.Readiness
+// @Router /readyz [get]
+func Readyz(c *gin.Context) {
+	readiness := health_service.HealthService.Readiness(c.Request.Context())
+
+	if readiness.Status != health_service.StatusOk {
+		c.JSON(http.StatusServiceUnavailable, readiness)
+		return
+	}
+
+	c.JSON(http.StatusOK, readiness)
+}
@@ -0,0 +1,76 @@
+package health_controller
+
+import (
+	"assignment-4/service/health_service"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var readiness func() *health_service.Readiness
+
+type healthServiceMock struct{}
+
+func (h *healthServiceMock) Readiness(ctx context.Context) *health_service.Readiness {
+	return readiness()
+}
+
+func newRouter() *gin.Engine {
+	r := gin.Default()
+	r.GET("/healthz", Healthz)
+	r.GET("/readyz", Readyz)
+
+	return r
+}
+
+func TestHealthz(t *testing.T) {
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/healthz", nil)
+
+	newRouter().ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rr.Body.String())
+}
+
+func TestReadyz(t *testing.T) {
+	health_service.HealthService = &healthServiceMock{}
+
+	tests := []struct {
+		name   string
+		status string
+		code   int
+	}{
+		{"ready", health_service.StatusOk, http.StatusOK},
+		{"not ready", health_service.StatusFail, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readiness = func() *health_service.Readiness {
+				return &health_service.Readiness{
+					Status: tt.status,
+					Checks: map[string]health_service.Check{"database": {Status: tt.status}},
+				}
+			}
+
+			rr := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/readyz", nil)
+
+			newRouter().ServeHTTP(rr, req)
+
+			var body health_service.Readiness
+			require.Nil(t, json.Unmarshal(rr.Body.Bytes(), &body))
+
+			assert.EqualValues(t, tt.code, rr.Code)
+			assert.EqualValues(t, tt.status, body.Status)
+			assert.EqualValues(t, tt.status, body.Checks["database"].Status)
+		})
+	}
+}
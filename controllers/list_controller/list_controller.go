@@ -0,0 +1,450 @@
+package list_controller
+
+import (
+	"assignment-4/domain/list_domain"
+	"assignment-4/domain/todo_domain"
+	"assignment-4/middlewares"
+	"assignment-4/service/list_service"
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/response_utils"
+	"assignment-4/utils/validation_utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateList godoc
+// @Summary Create a list
+// @Tags lists
+// @Description creating a new list to group todos
+// @ID create-list
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param RequestBody body doc_datas.CreateListRequest true "request body json"
+// @Success 201 {object} doc_datas.ListResponse
+// @Failure 400 {object} error_utils.ValidationErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 409 {object} error_utils.MessageErrData "a list with this name already exists"
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /lists [post]
+func CreateList(c *gin.Context) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	var list list_domain.List
+
+	if err := validation_utils.BindJSON(c, &list); err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	list.OwnerId = ownerId
+
+	res, err := list_service.ListService.CreateList(c.Request.Context(), &list)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// UpdateList godoc
+// @Summary Update list
+// @Tags lists
+// @Description Renaming a list or changing its description
+// @ID update-list
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param RequestBody body doc_datas.UpdateListRequest true "request body json"
+// @Param listId path int true "list id"
+// @Success 200 {object} doc_datas.ListResponse
+// @Failure 400 {object} error_utils.ValidationErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 404 {object} error_utils.MessageErrData
+// @Failure 409 {object} error_utils.MessageErrData "a list with this name already exists"
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /lists/{listId} [put]
+func UpdateList(c *gin.Context) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	var list list_domain.List
+
+	listId, err := list.GetListIdParam(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	if err := validation_utils.BindJSON(c, &list); err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	list.Id = listId
+	list.OwnerId = ownerId
+
+	res, err := list_service.ListService.UpdateList(c.Request.Context(), &list)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetListById godoc
+// @Summary Get list by ID
+// @Tags lists
+// @Description Getting a list by ID with the number of its todos and of its completed todos
+// @ID get-list
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param listId path int true "list id"
+// @Success 200 {object} doc_datas.ListResponse
+// @Failure 400 {object} error_utils.MessageErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 404 {object} error_utils.MessageErrData
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /lists/{listId} [get]
+func GetListById(c *gin.Context) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	var list list_domain.List
+
+	listId, err := list.GetListIdParam(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	res, err := list_service.ListService.GetListById(c.Request.Context(), listId, ownerId)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetAllLists godoc
+// @Summary Get all lists
+// @Tags lists
+// @Description Getting the active or the archived lists, with the number of their todos and of their completed todos
+// @ID get-all-lists
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param archived query bool false "archived lists instead of active ones" default(false)
+// @Success 200 {object} doc_datas.GetAllListsResponse
+// @Failure 400 {object} error_utils.MessageErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /lists [get]
+func GetAllLists(c *gin.Context) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	query := list_domain.ListQuery{OwnerId: ownerId}
+
+	if value, ok := c.GetQuery("archived"); ok {
+		archived, parseErr := strconv.ParseBool(value)
+
+		if parseErr != nil {
+			response_utils.Error(c, error_utils.NewBadRequest("invalid archived query param"))
+			return
+		}
+
+		query.Archived = archived
+	}
+
+	res, err := list_service.ListService.GetAllLists(c.Request.Context(), &query)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DeleteListById godoc
+// @Summary Delete list by ID
+// @Tags lists
+// @Description Deleting a list by ID. Its todos are kept and no longer belong to a list.
+// @ID delete-list
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param listId path int true "list id"
+// @Success 204 "list deleted"
+// @Failure 400 {object} error_utils.MessageErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 404 {object} error_utils.MessageErrData
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /lists/{listId} [delete]
+func DeleteListById(c *gin.Context) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	var list list_domain.List
+
+	listId, err := list.GetListIdParam(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	err = list_service.ListService.DeleteListById(c.Request.Context(), listId, ownerId)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ArchiveList godoc
+// @Summary Archive list
+// @Tags lists
+// @Description Archiving a list. Its todos stay in it, but no todos can be added until it is unarchived.
+// @ID archive-list
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param listId path int true "list id"
+// @Success 200 {object} doc_datas.ListResponse
+// @Failure 400 {object} error_utils.MessageErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 404 {object} error_utils.MessageErrData
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /lists/{listId}/archive [post]
+func ArchiveList(c *gin.Context) {
+	setArchived(c, true)
+}
+
+// UnarchiveList godoc
+// @Summary Unarchive list
+// @Tags lists
+// @Description Making an archived list active again
+// @ID unarchive-list
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param listId path int true "list id"
+// @Success 200 {object} doc_datas.ListResponse
+// @Failure 400 {object} error_utils.MessageErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 404 {object} error_utils.MessageErrData
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /lists/{listId}/unarchive [post]
+func UnarchiveList(c *gin.Context) {
+	setArchived(c, false)
+}
+
+func setArchived(c *gin.Context, archived bool) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	var list list_domain.List
+
+	listId, err := list.GetListIdParam(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	res, err := list_service.ListService.ArchiveList(c.Request.Context(), listId, ownerId, archived)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetListTodos godoc
+// @Summary Get todos of a list
+// @Tags lists
+// @Description Getting a page of the todos in a list, with the same filters as listing todos
+// @ID get-list-todos
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param listId path int true "list id"
+// @Param limit query int false "page size, 1 to 100" default(20)
+// @Param cursor query string false "next_cursor from the previous page"
+// @Param offset query int false "number of todos to skip, cannot be combined with cursor"
+// @Param completed query bool false "filter by completion status"
+// @Param due_before query string false "only todos due before this RFC 3339 timestamp" format(date-time)
+// @Param overdue query bool false "only todos that are (true) or are not (false) open past their due date"
+// @Param q query string false "case insensitive substring of title or description"
+// @Param tag query []string false "only todos with these tags, repeat the param for more than one" collectionFormat(multi)
+// @Param tag_mode query string false "whether todos need any or all of the tags" Enums(any, all) default(any)
+// @Param priority query string false "only todos with this priority" Enums(none, low, medium, high, urgent)
+// @Param sort query string false "sort order, position is the manual order of POST /todo/{todoId}/move and -priority puts the most urgent first" Enums(id, -id, title, position, -priority) default(id)
+// @Success 200 {object} doc_datas.GetAllTodosResponse
+// @Failure 400 {object} error_utils.MessageErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 404 {object} error_utils.MessageErrData
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /lists/{listId}/todos [get]
+func GetListTodos(c *gin.Context) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	var list list_domain.List
+
+	listId, err := list.GetListIdParam(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	query := todo_domain.TodoQuery{OwnerId: ownerId}
+
+	if err := query.ParseQueryParams(c); err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	res, err := list_service.ListService.GetListTodos(c.Request.Context(), listId, &query)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// MoveTodos godoc
+// @Summary Move todos into a list
+// @Tags lists
+// @Description Moving up to 100 todos into a list, out of whatever list they were in. Either all todos are moved or none.
+// @ID move-todos
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param listId path int true "list id"
+// @Param RequestBody body doc_datas.MoveTodosRequest true "request body json"
+// @Success 200 {object} doc_datas.ListResponse
+// @Failure 400 {object} error_utils.MessageErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 404 {object} error_utils.MessageErrData "the list or one of the todos does not exist"
+// @Failure 409 {object} error_utils.MessageErrData "the list is archived"
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /lists/{listId}/todos [post]
+func MoveTodos(c *gin.Context) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	var list list_domain.List
+
+	listId, err := list.GetListIdParam(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	var moveReq list_domain.MoveTodosRequest
+
+	if err := validation_utils.BindJSON(c, &moveReq); err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	res, err := list_service.ListService.MoveTodos(c.Request.Context(), listId, ownerId, &moveReq)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,254 @@
+package list_controller
+
+import (
+	"assignment-4/domain/list_domain"
+	"assignment-4/domain/todo_domain"
+	"assignment-4/middlewares"
+	"assignment-4/service/list_service"
+	"assignment-4/utils/error_utils"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	createList     func(list *list_domain.List) (*list_domain.List, error_utils.MessageErr)
+	updateList     func(list *list_domain.List) (*list_domain.List, error_utils.MessageErr)
+	archiveList    func(listId int64, ownerId int64, archived bool) (*list_domain.List, error_utils.MessageErr)
+	getListById    func(listId int64, ownerId int64) (*list_domain.List, error_utils.MessageErr)
+	getAllLists    func(query *list_domain.ListQuery) (*list_domain.ListCollection, error_utils.MessageErr)
+	deleteListById func(listId int64, ownerId int64) error_utils.MessageErr
+	getListTodos   func(listId int64, query *todo_domain.TodoQuery) (*todo_domain.TodoPage, error_utils.MessageErr)
+	moveTodos      func(listId int64, ownerId int64, moveReq *list_domain.MoveTodosRequest) (*list_domain.List, error_utils.MessageErr)
+)
+
+type listServiceMock struct{}
+
+func (l *listServiceMock) CreateList(ctx context.Context, list *list_domain.List) (*list_domain.List, error_utils.MessageErr) {
+	return createList(list)
+}
+
+func (l *listServiceMock) UpdateList(ctx context.Context, list *list_domain.List) (*list_domain.List, error_utils.MessageErr) {
+	return updateList(list)
+}
+
+func (l *listServiceMock) ArchiveList(ctx context.Context, listId int64, ownerId int64, archived bool) (*list_domain.List, error_utils.MessageErr) {
+	return archiveList(listId, ownerId, archived)
+}
+
+func (l *listServiceMock) GetListById(ctx context.Context, listId int64, ownerId int64) (*list_domain.List, error_utils.MessageErr) {
+	return getListById(listId, ownerId)
+}
+
+func (l *listServiceMock) GetAllLists(ctx context.Context, query *list_domain.ListQuery) (*list_domain.ListCollection, error_utils.MessageErr) {
+	return getAllLists(query)
+}
+
+func (l *listServiceMock) DeleteListById(ctx context.Context, listId int64, ownerId int64) error_utils.MessageErr {
+	return deleteListById(listId, ownerId)
+}
+
+func (l *listServiceMock) GetListTodos(ctx context.Context, listId int64, query *todo_domain.TodoQuery) (*todo_domain.TodoPage, error_utils.MessageErr) {
+	return getListTodos(listId, query)
+}
+
+func (l *listServiceMock) MoveTodos(ctx context.Context, listId int64, ownerId int64, moveReq *list_domain.MoveTodosRequest) (*list_domain.List, error_utils.MessageErr) {
+	return moveTodos(listId, ownerId, moveReq)
+}
+
+func newAuthenticatedRouter() *gin.Engine {
+	r := gin.Default()
+
+	r.Use(func(c *gin.Context) {
+		c.Set(middlewares.UserIdKey, int64(1))
+	})
+
+	return r
+}
+
+func TestListController_CreateList_Success(t *testing.T) {
+	list_service.ListService = &listServiceMock{}
+
+	createList = func(list *list_domain.List) (*list_domain.List, error_utils.MessageErr) {
+		assert.EqualValues(t, 1, list.OwnerId)
+		created := *list
+		created.Id = 2
+		return &created, nil
+	}
+
+	r := newAuthenticatedRouter()
+	r.POST("/lists", CreateList)
+
+	req, _ := http.NewRequest(http.MethodPost, "/lists", bytes.NewBufferString(`{"name":"School"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusCreated, rr.Code)
+
+	var list list_domain.List
+	require.Nil(t, json.Unmarshal(rr.Body.Bytes(), &list))
+	assert.EqualValues(t, 2, list.Id)
+	assert.EqualValues(t, "School", list.Name)
+}
+
+func TestListController_CreateList_Conflict(t *testing.T) {
+	list_service.ListService = &listServiceMock{}
+
+	createList = func(list *list_domain.List) (*list_domain.List, error_utils.MessageErr) {
+		return nil, error_utils.NewConflictError("a list with this name already exists")
+	}
+
+	r := newAuthenticatedRouter()
+	r.POST("/lists", CreateList)
+
+	req, _ := http.NewRequest(http.MethodPost, "/lists", bytes.NewBufferString(`{"name":"School"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusConflict, rr.Code)
+}
+
+func TestListController_UpdateList_InvalidId(t *testing.T) {
+	list_service.ListService = &listServiceMock{}
+
+	r := newAuthenticatedRouter()
+	r.PUT("/lists/:listId", UpdateList)
+
+	req, _ := http.NewRequest(http.MethodPut, "/lists/abc", bytes.NewBufferString(`{"name":"School"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "invalid list id params")
+}
+
+func TestListController_GetAllLists_Archived(t *testing.T) {
+	list_service.ListService = &listServiceMock{}
+
+	getAllLists = func(query *list_domain.ListQuery) (*list_domain.ListCollection, error_utils.MessageErr) {
+		assert.True(t, query.Archived)
+		return &list_domain.ListCollection{Lists: []list_domain.List{{Id: 2, Name: "Old", TodoCount: 3, CompletedCount: 3}}}, nil
+	}
+
+	r := newAuthenticatedRouter()
+	r.GET("/lists", GetAllLists)
+
+	req, _ := http.NewRequest(http.MethodGet, "/lists?archived=true", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"data":[{"id":2,"name":"Old","description":"","archived_at":null,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","todo_count":3,"completed_count":3}]}`, rr.Body.String())
+
+	req, _ = http.NewRequest(http.MethodGet, "/lists?archived=maybe", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestListController_DeleteListById_Success(t *testing.T) {
+	list_service.ListService = &listServiceMock{}
+
+	deleteListById = func(listId int64, ownerId int64) error_utils.MessageErr {
+		assert.EqualValues(t, 2, listId)
+		return nil
+	}
+
+	r := newAuthenticatedRouter()
+	r.DELETE("/lists/:listId", DeleteListById)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/lists/2", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusNoContent, rr.Code)
+	assert.Empty(t, rr.Body.String())
+}
+
+func TestListController_ArchiveList(t *testing.T) {
+	list_service.ListService = &listServiceMock{}
+
+	var calls []bool
+	archiveList = func(listId int64, ownerId int64, archived bool) (*list_domain.List, error_utils.MessageErr) {
+		calls = append(calls, archived)
+		return &list_domain.List{Id: listId, Name: "School"}, nil
+	}
+
+	r := newAuthenticatedRouter()
+	r.POST("/lists/:listId/archive", ArchiveList)
+	r.POST("/lists/:listId/unarchive", UnarchiveList)
+
+	for _, path := range []string{"/lists/2/archive", "/lists/2/unarchive"} {
+		req, _ := http.NewRequest(http.MethodPost, path, nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.EqualValues(t, http.StatusOK, rr.Code)
+	}
+
+	assert.EqualValues(t, []bool{true, false}, calls)
+}
+
+func TestListController_GetListTodos_Success(t *testing.T) {
+	list_service.ListService = &listServiceMock{}
+
+	getListTodos = func(listId int64, query *todo_domain.TodoQuery) (*todo_domain.TodoPage, error_utils.MessageErr) {
+		assert.EqualValues(t, 2, listId)
+		assert.EqualValues(t, 1, query.OwnerId)
+		require.NotNil(t, query.Completed)
+		assert.True(t, *query.Completed)
+		return &todo_domain.TodoPage{Todos: []todo_domain.Todo{}}, nil
+	}
+
+	r := newAuthenticatedRouter()
+	r.GET("/lists/:listId/todos", GetListTodos)
+
+	req, _ := http.NewRequest(http.MethodGet, "/lists/2/todos?completed=true", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusOK, rr.Code)
+}
+
+func TestListController_MoveTodos(t *testing.T) {
+	list_service.ListService = &listServiceMock{}
+
+	tests := []struct {
+		name   string
+		err    error_utils.MessageErr
+		status int
+	}{
+		{name: "moved", status: http.StatusOK},
+		{name: "archived list", err: list_domain.NewListArchivedError(), status: http.StatusConflict},
+		{name: "unknown todo", err: error_utils.NewNotFoundError("one or more todos were not found"), status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			moveTodos = func(listId int64, ownerId int64, moveReq *list_domain.MoveTodosRequest) (*list_domain.List, error_utils.MessageErr) {
+				assert.EqualValues(t, []int64{4, 7}, moveReq.TodoIds)
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				return &list_domain.List{Id: listId, Name: "School", TodoCount: 2}, nil
+			}
+
+			r := newAuthenticatedRouter()
+			r.POST("/lists/:listId/todos", MoveTodos)
+
+			req, _ := http.NewRequest(http.MethodPost, "/lists/2/todos", bytes.NewBufferString(`{"todo_ids":[4,7]}`))
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.EqualValues(t, tt.status, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package problem_controller
+
+import (
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/response_utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetProblemTypes godoc
+// @Summary List error codes
+// @Tags problems
+// @Description Listing every stable error code and the problem type URI it maps to
+// @ID get-problem-types
+// @Produce json
+// @Success 200 {array} error_utils.ErrorCode
+// @Router /problems [get]
+func GetProblemTypes(c *gin.Context) {
+	c.JSON(http.StatusOK, error_utils.Codes())
+}
+
+// GetProblemType godoc
+// @Summary Get error code
+// @Tags problems
+// @Description Describing a single error code, this is where problem type URIs resolve to
+// @ID get-problem-type
+// @Produce json
+// @Produce application/problem+json
+// @Param code path string true "error code, e.g. not_found"
+// @Success 200 {object} error_utils.ErrorCode
+// @Failure 404 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /problems/{code} [get]
+func GetProblemType(c *gin.Context) {
+	errorCode, ok := error_utils.LookupCode(c.Param("code"))
+
+	if !ok {
+		response_utils.Error(c, error_utils.NewNotFoundError("unknown error code"))
+		return
+	}
+
+	c.JSON(http.StatusOK, errorCode)
+}
@@ -0,0 +1,240 @@
+package tag_controller
+
+import (
+	"assignment-4/domain/tag_domain"
+	"assignment-4/middlewares"
+	"assignment-4/service/tag_service"
+	"assignment-4/utils/response_utils"
+	"assignment-4/utils/validation_utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateTag godoc
+// @Summary Create a tag
+// @Tags tags
+// @Description creating a new tag. Tags named on a todo that do not exist yet are created with the default color.
+// @ID create-tag
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param RequestBody body doc_datas.CreateTagRequest true "request body json"
+// @Success 201 {object} doc_datas.TagResponse
+// @Failure 400 {object} error_utils.ValidationErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 409 {object} error_utils.MessageErrData "a tag with this name already exists"
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /tags [post]
+func CreateTag(c *gin.Context) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	var tag tag_domain.Tag
+
+	if err := validation_utils.BindJSON(c, &tag); err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	tag.OwnerId = ownerId
+
+	res, err := tag_service.TagService.CreateTag(c.Request.Context(), &tag)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// UpdateTag godoc
+// @Summary Update tag
+// @Tags tags
+// @Description Renaming a tag or changing its color. Todos carrying the tag show the new name.
+// @ID update-tag
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param RequestBody body doc_datas.UpdateTagRequest true "request body json"
+// @Param tagId path int true "tag id"
+// @Success 200 {object} doc_datas.TagResponse
+// @Failure 400 {object} error_utils.ValidationErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 404 {object} error_utils.MessageErrData
+// @Failure 409 {object} error_utils.MessageErrData "a tag with this name already exists"
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /tags/{tagId} [put]
+func UpdateTag(c *gin.Context) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	var tag tag_domain.Tag
+
+	tagId, err := tag.GetTagIdParam(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	if err := validation_utils.BindJSON(c, &tag); err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	tag.Id = tagId
+	tag.OwnerId = ownerId
+
+	res, err := tag_service.TagService.UpdateTag(c.Request.Context(), &tag)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetTagById godoc
+// @Summary Get tag by ID
+// @Tags tags
+// @Description Getting a tag by ID with the number of todos carrying it
+// @ID get-tag
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param tagId path int true "tag id"
+// @Success 200 {object} doc_datas.TagResponse
+// @Failure 400 {object} error_utils.MessageErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 404 {object} error_utils.MessageErrData
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /tags/{tagId} [get]
+func GetTagById(c *gin.Context) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	var tag tag_domain.Tag
+
+	tagId, err := tag.GetTagIdParam(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	res, err := tag_service.TagService.GetTagById(c.Request.Context(), tagId, ownerId)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetAllTags godoc
+// @Summary Get all tags
+// @Tags tags
+// @Description Getting all tags by name, each with the number of todos carrying it, trashed todos left out
+// @ID get-all-tags
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Success 200 {object} doc_datas.GetAllTagsResponse
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /tags [get]
+func GetAllTags(c *gin.Context) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	res, err := tag_service.TagService.GetAllTags(c.Request.Context(), ownerId)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DeleteTagById godoc
+// @Summary Delete tag by ID
+// @Tags tags
+// @Description Deleting a tag by ID and taking it off every todo
+// @ID delete-tag
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param tagId path int true "tag id"
+// @Success 204 "tag deleted"
+// @Failure 400 {object} error_utils.MessageErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 404 {object} error_utils.MessageErrData
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /tags/{tagId} [delete]
+func DeleteTagById(c *gin.Context) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	var tag tag_domain.Tag
+
+	tagId, err := tag.GetTagIdParam(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	err = tag_service.TagService.DeleteTagById(c.Request.Context(), tagId, ownerId)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
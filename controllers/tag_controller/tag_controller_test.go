@@ -0,0 +1,164 @@
+package tag_controller
+
+import (
+	"assignment-4/domain/tag_domain"
+	"assignment-4/middlewares"
+	"assignment-4/service/tag_service"
+	"assignment-4/utils/error_utils"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	createTag     func(tag *tag_domain.Tag) (*tag_domain.Tag, error_utils.MessageErr)
+	updateTag     func(tag *tag_domain.Tag) (*tag_domain.Tag, error_utils.MessageErr)
+	getTagById    func(tagId int64, ownerId int64) (*tag_domain.Tag, error_utils.MessageErr)
+	getAllTags    func(ownerId int64) (*tag_domain.TagCollection, error_utils.MessageErr)
+	deleteTagById func(tagId int64, ownerId int64) error_utils.MessageErr
+)
+
+type tagServiceMock struct{}
+
+func (t *tagServiceMock) CreateTag(ctx context.Context, tag *tag_domain.Tag) (*tag_domain.Tag, error_utils.MessageErr) {
+	return createTag(tag)
+}
+
+func (t *tagServiceMock) UpdateTag(ctx context.Context, tag *tag_domain.Tag) (*tag_domain.Tag, error_utils.MessageErr) {
+	return updateTag(tag)
+}
+
+func (t *tagServiceMock) GetTagById(ctx context.Context, tagId int64, ownerId int64) (*tag_domain.Tag, error_utils.MessageErr) {
+	return getTagById(tagId, ownerId)
+}
+
+func (t *tagServiceMock) GetAllTags(ctx context.Context, ownerId int64) (*tag_domain.TagCollection, error_utils.MessageErr) {
+	return getAllTags(ownerId)
+}
+
+func (t *tagServiceMock) DeleteTagById(ctx context.Context, tagId int64, ownerId int64) error_utils.MessageErr {
+	return deleteTagById(tagId, ownerId)
+}
+
+func newAuthenticatedRouter() *gin.Engine {
+	r := gin.Default()
+
+	r.Use(func(c *gin.Context) {
+		c.Set(middlewares.UserIdKey, int64(1))
+	})
+
+	return r
+}
+
+func TestTagController_CreateTag_Success(t *testing.T) {
+	tag_service.TagService = &tagServiceMock{}
+
+	createTag = func(tag *tag_domain.Tag) (*tag_domain.Tag, error_utils.MessageErr) {
+		assert.EqualValues(t, 1, tag.OwnerId)
+		created := *tag
+		created.Id = 3
+		return &created, nil
+	}
+
+	r := newAuthenticatedRouter()
+	r.POST("/tags", CreateTag)
+
+	req, _ := http.NewRequest(http.MethodPost, "/tags", bytes.NewBufferString(`{"name":"urgent","color":"#e53935"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusCreated, rr.Code)
+
+	var tag tag_domain.Tag
+	require.Nil(t, json.Unmarshal(rr.Body.Bytes(), &tag))
+	assert.EqualValues(t, 3, tag.Id)
+	assert.EqualValues(t, "#e53935", tag.Color)
+}
+
+func TestTagController_CreateTag_Conflict(t *testing.T) {
+	tag_service.TagService = &tagServiceMock{}
+
+	createTag = func(tag *tag_domain.Tag) (*tag_domain.Tag, error_utils.MessageErr) {
+		return nil, error_utils.NewConflictError("a tag with this name already exists")
+	}
+
+	r := newAuthenticatedRouter()
+	r.POST("/tags", CreateTag)
+
+	req, _ := http.NewRequest(http.MethodPost, "/tags", bytes.NewBufferString(`{"name":"urgent"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusConflict, rr.Code)
+	assert.Contains(t, rr.Body.String(), "a tag with this name already exists")
+}
+
+func TestTagController_GetAllTags_Success(t *testing.T) {
+	tag_service.TagService = &tagServiceMock{}
+
+	getAllTags = func(ownerId int64) (*tag_domain.TagCollection, error_utils.MessageErr) {
+		return &tag_domain.TagCollection{Tags: []tag_domain.Tag{{Id: 3, Name: "urgent", Color: "#e53935", TodoCount: 4}}}, nil
+	}
+
+	r := newAuthenticatedRouter()
+	r.GET("/tags", GetAllTags)
+
+	req, _ := http.NewRequest(http.MethodGet, "/tags", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"data":[{"id":3,"name":"urgent","color":"#e53935","todo_count":4,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}]}`, rr.Body.String())
+}
+
+func TestTagController_UpdateTag_InvalidId(t *testing.T) {
+	tag_service.TagService = &tagServiceMock{}
+
+	r := newAuthenticatedRouter()
+	r.PUT("/tags/:tagId", UpdateTag)
+
+	req, _ := http.NewRequest(http.MethodPut, "/tags/abc", bytes.NewBufferString(`{"name":"urgent"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "invalid tag id params")
+}
+
+func TestTagController_DeleteTagById(t *testing.T) {
+	tag_service.TagService = &tagServiceMock{}
+
+	tests := []struct {
+		name   string
+		err    error_utils.MessageErr
+		status int
+	}{
+		{name: "deleted", status: http.StatusNoContent},
+		{name: "not found", err: error_utils.NewNotFoundError("no record found"), status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleteTagById = func(tagId int64, ownerId int64) error_utils.MessageErr {
+				assert.EqualValues(t, 3, tagId)
+				return tt.err
+			}
+
+			r := newAuthenticatedRouter()
+			r.DELETE("/tags/:tagId", DeleteTagById)
+
+			req, _ := http.NewRequest(http.MethodDelete, "/tags/3", nil)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.EqualValues(t, tt.status, rr.Code)
+		})
+	}
+}
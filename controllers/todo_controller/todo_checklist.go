@@ -0,0 +1,277 @@
+package todo_controller
+
+import (
+	"assignment-4/domain/todo_domain"
+	"assignment-4/service/todo_service"
+	"assignment-4/utils/response_utils"
+	"assignment-4/utils/validation_utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetChecklist godoc
+// @Summary Get the checklist of a todo
+// @Tags checklist
+// @Description Getting the checklist items of a todo in order, with its progress
+// @ID get-checklist
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param todoId path int true "todo's todo id"
+// @Success 200 {object} doc_datas.ChecklistResponse
+// @Failure 400 {object} error_utils.MessageErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 404 {object} error_utils.MessageErrData
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /todo/{todoId}/checklist [get]
+func GetChecklist(c *gin.Context) {
+	ownerId, todoId, ok := todoParams(c)
+
+	if !ok {
+		return
+	}
+
+	res, err := todo_service.TodoService.GetChecklist(c.Request.Context(), todoId, ownerId)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// AddChecklistItem godoc
+// @Summary Add a checklist item
+// @Tags checklist
+// @Description Appending an item to the checklist of a todo, which holds at most 100 items
+// @ID add-checklist-item
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param todoId path int true "todo's todo id"
+// @Param RequestBody body doc_datas.AddChecklistItemRequest true "request body json"
+// @Success 201 {object} doc_datas.ChecklistItemResponse
+// @Failure 400 {object} error_utils.ValidationErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 404 {object} error_utils.MessageErrData
+// @Failure 409 {object} error_utils.MessageErrData "the checklist is full"
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /todo/{todoId}/checklist [post]
+func AddChecklistItem(c *gin.Context) {
+	ownerId, todoId, ok := todoParams(c)
+
+	if !ok {
+		return
+	}
+
+	var item todo_domain.ChecklistItem
+
+	if err := validation_utils.BindJSON(c, &item); err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	item.TodoId = todoId
+	item.OwnerId = ownerId
+
+	res, err := todo_service.TodoService.AddChecklistItem(c.Request.Context(), &item)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// UpdateChecklistItem godoc
+// @Summary Update a checklist item
+// @Tags checklist
+// @Description Changing the text of a checklist item, marking it done or both. Fields that are left out keep their value.
+// @ID update-checklist-item
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param todoId path int true "todo's todo id"
+// @Param itemId path int true "checklist item id"
+// @Param RequestBody body doc_datas.UpdateChecklistItemRequest true "request body json"
+// @Success 200 {object} doc_datas.ChecklistItemResponse
+// @Failure 400 {object} error_utils.ValidationErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 404 {object} error_utils.MessageErrData
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /todo/{todoId}/checklist/{itemId} [patch]
+func UpdateChecklistItem(c *gin.Context) {
+	ownerId, todoId, itemId, ok := checklistItemParams(c)
+
+	if !ok {
+		return
+	}
+
+	var patch todo_domain.ChecklistItemPatch
+
+	if err := validation_utils.BindJSON(c, &patch); err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	res, err := todo_service.TodoService.UpdateChecklistItem(c.Request.Context(), todoId, ownerId, itemId, &patch)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// ToggleChecklistItem godoc
+// @Summary Toggle a checklist item
+// @Tags checklist
+// @Description Marking an open checklist item done, or a done one open again
+// @ID toggle-checklist-item
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param todoId path int true "todo's todo id"
+// @Param itemId path int true "checklist item id"
+// @Success 200 {object} doc_datas.ChecklistItemResponse
+// @Failure 400 {object} error_utils.MessageErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 404 {object} error_utils.MessageErrData
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /todo/{todoId}/checklist/{itemId}/toggle [post]
+func ToggleChecklistItem(c *gin.Context) {
+	ownerId, todoId, itemId, ok := checklistItemParams(c)
+
+	if !ok {
+		return
+	}
+
+	res, err := todo_service.TodoService.ToggleChecklistItem(c.Request.Context(), todoId, ownerId, itemId)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DeleteChecklistItem godoc
+// @Summary Delete a checklist item
+// @Tags checklist
+// @Description Removing an item from the checklist of a todo
+// @ID delete-checklist-item
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param todoId path int true "todo's todo id"
+// @Param itemId path int true "checklist item id"
+// @Success 204 "checklist item deleted"
+// @Failure 400 {object} error_utils.MessageErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 404 {object} error_utils.MessageErrData
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /todo/{todoId}/checklist/{itemId} [delete]
+func DeleteChecklistItem(c *gin.Context) {
+	ownerId, todoId, itemId, ok := checklistItemParams(c)
+
+	if !ok {
+		return
+	}
+
+	err := todo_service.TodoService.DeleteChecklistItem(c.Request.Context(), todoId, ownerId, itemId)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ReorderChecklist godoc
+// @Summary Reorder a checklist
+// @Tags checklist
+// @Description Putting the checklist items of a todo in a new order. item_ids has to list every item exactly once.
+// @ID reorder-checklist
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param todoId path int true "todo's todo id"
+// @Param RequestBody body doc_datas.ReorderChecklistRequest true "request body json"
+// @Success 200 {object} doc_datas.ChecklistResponse
+// @Failure 400 {object} error_utils.ValidationErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 404 {object} error_utils.MessageErrData
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /todo/{todoId}/checklist/order [put]
+func ReorderChecklist(c *gin.Context) {
+	ownerId, todoId, ok := todoParams(c)
+
+	if !ok {
+		return
+	}
+
+	var order todo_domain.ChecklistOrder
+
+	if err := validation_utils.BindJSON(c, &order); err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	res, err := todo_service.TodoService.ReorderChecklist(c.Request.Context(), todoId, ownerId, &order)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func checklistItemParams(c *gin.Context) (int64, int64, int64, bool) {
+	ownerId, todoId, ok := todoParams(c)
+
+	if !ok {
+		return 0, 0, 0, false
+	}
+
+	var item todo_domain.ChecklistItem
+
+	itemId, err := item.GetChecklistItemIdParam(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return 0, 0, 0, false
+	}
+
+	return ownerId, todoId, itemId, true
+}
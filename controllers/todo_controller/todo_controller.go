@@ -2,9 +2,14 @@ package todo_controller
 
 import (
 	"assignment-4/domain/todo_domain"
+	"assignment-4/middlewares"
 	"assignment-4/service/todo_service"
 	"assignment-4/utils/error_utils"
+	"assignment-4/utils/etag_utils"
+	"assignment-4/utils/response_utils"
+	"assignment-4/utils/validation_utils"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,27 +21,43 @@ import (
 // @ID create-todo
 // @Accept json
 // @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
 // @Param RequestBody body doc_datas.CreateTodoRequest true "request body json"
 // @Success 201 {object} doc_datas.CreateTodoResponse
-// @Failure 400 {object} error_utils.MessageErrData
+// @Header 201 {string} ETag "version of the new todo"
+// @Failure 400 {object} error_utils.ValidationErrData
+// @Failure 401 {object} error_utils.MessageErrData
 // @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
 // @Router /todo [post]
 func CreateTodo(c *gin.Context) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
 	var todo todo_domain.Todo
 
-	if err := c.ShouldBindJSON(&todo); err != nil {
-		theErr := error_utils.NewBadRequest("invalid json body")
-		c.JSON(theErr.Status(), theErr)
+	if err := validation_utils.BindJSON(c, &todo); err != nil {
+		response_utils.Error(c, err)
 		return
 	}
 
-	res, err := todo_service.TodoService.CreateTodo(&todo)
+	todo.OwnerId = ownerId
+
+	res, err := todo_service.TodoService.CreateTodo(c.Request.Context(), &todo)
 
 	if err != nil {
-		c.JSON(err.Status(), err)
+		response_utils.Error(c, err)
 		return
 	}
 
+	c.Header("ETag", etag_utils.ETag(res.Version))
 	c.JSON(http.StatusCreated, res)
 }
 
@@ -47,38 +68,134 @@ func CreateTodo(c *gin.Context) {
 // @ID update-todo
 // @Accept json
 // @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
 // @Param RequestBody body doc_datas.UpdateTodoRequest true "request body json"
 // @Param todoId path int true "todo's todo id"
+// @Param If-Match header string false "ETag from the last GET, the write fails with 412 if the todo changed since"
 // @Success 200 {object} doc_datas.UpdateTodoResponse
-// @Failure 400 {object} error_utils.MessageErrData
+// @Header 200 {string} ETag "current version of the todo"
+// @Failure 400 {object} error_utils.ValidationErrData
+// @Failure 401 {object} error_utils.MessageErrData
 // @Failure 404 {object} error_utils.MessageErrData
+// @Failure 412 {object} error_utils.MessageErrData
+// @Failure 428 {object} error_utils.MessageErrData "If-Match is missing and the server requires it"
 // @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
 // @Router /todo/{todoId} [put]
 func UpdateTodo(c *gin.Context) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
 	var todo todo_domain.Todo
 
 	todoId, err := todo.GetTodoIdParam(c)
 
 	if err != nil {
-		c.JSON(err.Status(), err)
+		response_utils.Error(c, err)
+		return
+	}
+
+	version, err := etag_utils.IfMatch(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
 		return
 	}
 
-	if err := c.ShouldBindJSON(&todo); err != nil {
-		theErr := error_utils.NewBadRequest("invalid json body")
-		c.JSON(theErr.Status(), theErr)
+	if err := validation_utils.BindJSON(c, &todo); err != nil {
+		response_utils.Error(c, err)
 		return
 	}
 
 	todo.Id = todoId
+	todo.OwnerId = ownerId
+	todo.Version = version
+
+	res, err := todo_service.TodoService.UpdateTodo(c.Request.Context(), &todo)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.Header("ETag", etag_utils.ETag(res.Version))
+	c.JSON(http.StatusOK, res)
+}
+
+// PatchTodo godoc
+// @Summary Partially update todo
+// @Tags todo
+// @Description Applying a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902) to a todo by ID, chosen by Content-Type
+// @ID patch-todo
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param RequestBody body doc_datas.PatchTodoRequest true "merge patch document, or for json patch an array of operations such as [{\"op\":\"replace\",\"path\":\"/completed\",\"value\":true}]"
+// @Param todoId path int true "todo's todo id"
+// @Param If-Match header string false "ETag from the last GET, the write fails with 412 if the todo changed since"
+// @Success 200 {object} doc_datas.PatchTodoResponse
+// @Header 200 {string} ETag "current version of the todo"
+// @Failure 400 {object} error_utils.ValidationErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 404 {object} error_utils.MessageErrData
+// @Failure 412 {object} error_utils.MessageErrData
+// @Failure 415 {object} error_utils.MessageErrData
+// @Failure 422 {object} error_utils.MessageErrData
+// @Failure 428 {object} error_utils.MessageErrData "If-Match is missing and the server requires it"
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /todo/{todoId} [patch]
+func PatchTodo(c *gin.Context) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	var todo todo_domain.Todo
+
+	todoId, err := todo.GetTodoIdParam(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	version, err := etag_utils.IfMatch(c)
 
-	res, err := todo_service.TodoService.UpdateTodo(&todo)
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	patch, readErr := c.GetRawData()
+
+	if readErr != nil {
+		theErr := error_utils.NewBadRequest("invalid patch body")
+		response_utils.Error(c, theErr)
+		return
+	}
+
+	res, err := todo_service.TodoService.PatchTodo(c.Request.Context(), todoId, ownerId, version, patch, c.ContentType())
 
 	if err != nil {
-		c.JSON(err.Status(), err)
+		response_utils.Error(c, err)
 		return
 	}
 
+	c.Header("ETag", etag_utils.ETag(res.Version))
 	c.JSON(http.StatusOK, res)
 }
 
@@ -89,26 +206,64 @@ func UpdateTodo(c *gin.Context) {
 // @ID get-todo
 // @Accept json
 // @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
 // @Param todoId path int true "todo's todo id"
+// @Param include query string false "also return the checklist items of the todo" Enums(checklist)
+// @Param If-None-Match header string false "ETag from an earlier GET, answered with 304 if the todo is unchanged"
 // @Success 200 {object} doc_datas.GetTodoResponse
+// @Header 200 {string} ETag "current version of the todo, with a -checklist suffix for include=checklist"
+// @Success 304 "todo is unchanged"
 // @Failure 400 {object} error_utils.MessageErrData
+// @Failure 401 {object} error_utils.MessageErrData
 // @Failure 404 {object} error_utils.MessageErrData
 // @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
 // @Router /todo/{todoId} [get]
 func GetTodoById(c *gin.Context) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
 	var todo todo_domain.Todo
 
 	todoId, err := todo.GetTodoIdParam(c)
 
 	if err != nil {
-		c.JSON(err.Status(), err)
+		response_utils.Error(c, err)
 		return
 	}
 
-	res, err := todo_service.TodoService.GetTodoById(todoId)
+	var res *todo_domain.Todo
+
+	switch c.Query("include") {
+	case "":
+		res, err = todo_service.TodoService.GetTodoById(c.Request.Context(), todoId, ownerId)
+	case "checklist":
+		res, err = todo_service.TodoService.GetTodoWithChecklist(c.Request.Context(), todoId, ownerId)
+	default:
+		err = error_utils.NewBadRequest("include must be checklist")
+	}
 
 	if err != nil {
-		c.JSON(err.Status(), err)
+		response_utils.Error(c, err)
+		return
+	}
+
+	etag := etag_utils.ETag(res.Version)
+	// the checklist is a different body, so it needs its own tag
+	if c.Query("include") == "checklist" {
+		etag = etag_utils.VariantETag(res.Version, "checklist")
+	}
+	c.Header("ETag", etag)
+
+	if etag_utils.NoneMatch(c, etag) {
+		c.Status(http.StatusNotModified)
 		return
 	}
 
@@ -118,56 +273,300 @@ func GetTodoById(c *gin.Context) {
 // GetAllTodos godoc
 // @Summary Get all todos
 // @Tags todo
-// @Description Getting all todos
+// @Description Getting a page of todos, optionally filtered and sorted
 // @ID get-all-todos
 // @Accept json
 // @Produce json
-// @Param todoId path int true "todo's todo id"
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param limit query int false "page size, 1 to 100" default(20)
+// @Param cursor query string false "next_cursor from the previous page"
+// @Param offset query int false "number of todos to skip, cannot be combined with cursor"
+// @Param completed query bool false "filter by completion status"
+// @Param due_before query string false "only todos due before this RFC 3339 timestamp" format(date-time)
+// @Param overdue query bool false "only todos that are (true) or are not (false) open past their due date"
+// @Param q query string false "case insensitive substring of title or description"
+// @Param tag query []string false "only todos with these tags, repeat the param for more than one" collectionFormat(multi)
+// @Param tag_mode query string false "whether todos need any or all of the tags" Enums(any, all) default(any)
+// @Param list_id query int false "only todos in this list"
+// @Param priority query string false "only todos with this priority" Enums(none, low, medium, high, urgent)
+// @Param sort query string false "sort order, position is the manual order of POST /todo/{todoId}/move and -priority puts the most urgent first" Enums(id, -id, title, position, -priority) default(id)
 // @Success 200 {object} doc_datas.GetAllTodosResponse
 // @Failure 400 {object} error_utils.MessageErrData
-// @Failure 404 {object} error_utils.MessageErrData
+// @Failure 401 {object} error_utils.MessageErrData
 // @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
 // @Router /todo [get]
 func GetAllTodos(c *gin.Context) {
-	res, err := todo_service.TodoService.GetAllTodos()
+	ownerId, err := middlewares.GetUserId(c)
 
 	if err != nil {
-		c.JSON(err.Status(), err)
+		response_utils.Error(c, err)
+		return
+	}
+
+	query := todo_domain.TodoQuery{OwnerId: ownerId}
+
+	if err := query.ParseQueryParams(c); err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	res, err := todo_service.TodoService.GetAllTodos(c.Request.Context(), &query)
+
+	if err != nil {
+		response_utils.Error(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, res)
 }
 
+// ApplyBatch godoc
+// @Summary Apply a batch of todo operations
+// @Tags todo
+// @Description Running up to 100 create, update, delete and complete operations in one transaction, in order. In all_or_nothing mode (the default) the first failing operation rolls back the whole batch, the response then has its status and only that operation in results. In per_item mode only failing operations are rolled back and the response is 200 with a result per operation.
+// @ID apply-batch
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param RequestBody body doc_datas.BatchRequest true "request body json"
+// @Success 200 {object} doc_datas.BatchResponse
+// @Failure 400 {object} error_utils.ValidationErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 404 {object} doc_datas.BatchResponse "an all_or_nothing batch failed, e.g. because a todo does not exist"
+// @Failure 412 {object} doc_datas.BatchResponse "an all_or_nothing batch failed on a stale version"
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /todo/batch [post]
+func ApplyBatch(c *gin.Context) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	var batch todo_domain.Batch
+
+	if err := validation_utils.BindJSON(c, &batch); err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	res, err := todo_service.TodoService.ApplyBatch(c.Request.Context(), ownerId, &batch)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	status := http.StatusOK
+
+	if !res.Committed && len(res.Results) > 0 {
+		status = res.Results[0].Status
+	}
+
+	c.JSON(status, res)
+}
+
+// GetTrash godoc
+// @Summary Get trashed todos
+// @Tags todo
+// @Description Getting a page of deleted todos that can still be restored, with the same filters as listing todos
+// @ID get-trash
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param limit query int false "page size, 1 to 100" default(20)
+// @Param cursor query string false "next_cursor from the previous page"
+// @Param offset query int false "number of todos to skip, cannot be combined with cursor"
+// @Param completed query bool false "filter by completion status"
+// @Param due_before query string false "only todos due before this RFC 3339 timestamp" format(date-time)
+// @Param overdue query bool false "only todos that are (true) or are not (false) open past their due date"
+// @Param q query string false "case insensitive substring of title or description"
+// @Param tag query []string false "only todos with these tags, repeat the param for more than one" collectionFormat(multi)
+// @Param tag_mode query string false "whether todos need any or all of the tags" Enums(any, all) default(any)
+// @Param list_id query int false "only todos in this list"
+// @Param priority query string false "only todos with this priority" Enums(none, low, medium, high, urgent)
+// @Param sort query string false "sort order, position is the manual order of POST /todo/{todoId}/move and -priority puts the most urgent first" Enums(id, -id, title, position, -priority) default(id)
+// @Success 200 {object} doc_datas.GetAllTodosResponse
+// @Failure 400 {object} error_utils.MessageErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /todo/trash [get]
+func GetTrash(c *gin.Context) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	query := todo_domain.TodoQuery{OwnerId: ownerId}
+
+	if err := query.ParseQueryParams(c); err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	query.Trashed = true
+
+	res, err := todo_service.TodoService.GetAllTodos(c.Request.Context(), &query)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// RestoreTodoById godoc
+// @Summary Restore todo by ID
+// @Tags todo
+// @Description Moving a deleted todo out of the trash
+// @ID restore-todo
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param todoId path int true "todo's todo id"
+// @Success 200 {object} doc_datas.GetTodoResponse
+// @Header 200 {string} ETag "current version of the todo"
+// @Failure 400 {object} error_utils.MessageErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 404 {object} error_utils.MessageErrData "todo is not in the trash"
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /todo/{todoId}/restore [post]
+func RestoreTodoById(c *gin.Context) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	var todo todo_domain.Todo
+
+	todoId, err := todo.GetTodoIdParam(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	res, err := todo_service.TodoService.RestoreTodoById(c.Request.Context(), todoId, ownerId)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.Header("ETag", etag_utils.ETag(res.Version))
+	c.JSON(http.StatusOK, res)
+}
+
 // DeleteTodoById godoc
 // @Summary Delete todo by ID
 // @Tags todo
-// @Description Deleting a todo by ID
+// @Description Moving a todo to the trash, from where it can be restored until it is purged. With permanent=true the todo is deleted for good, also from the trash.
 // @ID delete-todo
 // @Accept json
 // @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
 // @Param todoId path int true "todo's todo id"
+// @Param permanent query bool false "delete for good instead of moving to the trash" default(false)
+// @Param If-Match header string false "ETag from the last GET, the write fails with 412 if the todo changed since"
 // @Success 200 {object} doc_datas.DeleteTodoResponse
 // @Failure 400 {object} error_utils.MessageErrData
+// @Failure 401 {object} error_utils.MessageErrData
 // @Failure 404 {object} error_utils.MessageErrData
+// @Failure 412 {object} error_utils.MessageErrData
+// @Failure 428 {object} error_utils.MessageErrData "If-Match is missing and the server requires it"
 // @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
 // @Router /todo/{todoId} [delete]
 func DeleteTodoById(c *gin.Context) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
 	var todo todo_domain.Todo
 
 	todoId, err := todo.GetTodoIdParam(c)
 
 	if err != nil {
-		c.JSON(err.Status(), err)
+		response_utils.Error(c, err)
+		return
+	}
+
+	var permanent bool
+
+	if value := c.Query("permanent"); value != "" {
+		parsed, parseErr := strconv.ParseBool(value)
+
+		if parseErr != nil {
+			response_utils.Error(c, error_utils.NewBadRequest("invalid permanent query param"))
+			return
+		}
+
+		permanent = parsed
+	}
+
+	version, err := etag_utils.IfMatch(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
 		return
 	}
 
-	res, err := todo_service.TodoService.DeleteTodoById(todoId)
+	res, err := todo_service.TodoService.DeleteTodoById(c.Request.Context(), todoId, ownerId, version, permanent)
 
 	if err != nil {
-		c.JSON(err.Status(), err)
+		response_utils.Error(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, res)
 }
+
+// todoParams reads the owner and the todo id of a request on one todo,
+// answering it with the error when either is missing.
+func todoParams(c *gin.Context) (int64, int64, bool) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return 0, 0, false
+	}
+
+	var todo todo_domain.Todo
+
+	todoId, err := todo.GetTodoIdParam(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return 0, 0, false
+	}
+
+	return ownerId, todoId, true
+}
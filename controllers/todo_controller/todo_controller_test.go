@@ -2,14 +2,19 @@ package todo_controller
 
 import (
 	"assignment-4/domain/todo_domain"
+	"assignment-4/middlewares"
 	"assignment-4/service/todo_service"
 	"assignment-4/utils/error_utils"
+	"assignment-4/utils/etag_utils"
 	"bytes"
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -17,33 +22,114 @@ import (
 )
 
 var (
-	createTodo     func(todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr)
-	updateTodo     func(todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr)
-	getTodoById    func(todoId int64) (*todo_domain.Todo, error_utils.MessageErr)
-	getAllTodos    func() (*[]todo_domain.Todo, error_utils.MessageErr)
-	deleteTodoById func(todoId int64) (*map[string]interface{}, error_utils.MessageErr)
+	createTodo      func(todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr)
+	updateTodo      func(todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr)
+	patchTodo       func(todoId int64, ownerId int64, version int64, patch []byte, contentType string) (*todo_domain.Todo, error_utils.MessageErr)
+	getTodoById     func(todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr)
+	getAllTodos     func(query *todo_domain.TodoQuery) (*todo_domain.TodoPage, error_utils.MessageErr)
+	searchTodos     func(search *todo_domain.TodoSearch) (*todo_domain.SearchPage, error_utils.MessageErr)
+	deleteTodoById  func(todoId int64, ownerId int64, version int64, permanent bool) (*todo_domain.DeleteResult, error_utils.MessageErr)
+	restoreTodoById func(todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr)
+	purgeTrash      func(deletedBefore time.Time) (int64, error_utils.MessageErr)
+	applyBatch      func(ownerId int64, batch *todo_domain.Batch) (*todo_domain.BatchResult, error_utils.MessageErr)
+
+	getTodoWithChecklist func(todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr)
+	getChecklist         func(todoId int64, ownerId int64) (*todo_domain.Checklist, error_utils.MessageErr)
+	addChecklistItem     func(item *todo_domain.ChecklistItem) (*todo_domain.ChecklistItem, error_utils.MessageErr)
+	updateChecklistItem  func(todoId int64, ownerId int64, itemId int64, patch *todo_domain.ChecklistItemPatch) (*todo_domain.ChecklistItem, error_utils.MessageErr)
+	toggleChecklistItem  func(todoId int64, ownerId int64, itemId int64) (*todo_domain.ChecklistItem, error_utils.MessageErr)
+	deleteChecklistItem  func(todoId int64, ownerId int64, itemId int64) error_utils.MessageErr
+	reorderChecklist     func(todoId int64, ownerId int64, order *todo_domain.ChecklistOrder) (*todo_domain.Checklist, error_utils.MessageErr)
+	getOccurrences       func(todoId int64, ownerId int64, count int) (*todo_domain.Occurrences, error_utils.MessageErr)
+	moveTodo             func(move *todo_domain.TodoMove) (*todo_domain.Todo, error_utils.MessageErr)
 )
 
 type todoServiceMock struct{}
 
-func (t *todoServiceMock) CreateTodo(todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr) {
+func (t *todoServiceMock) CreateTodo(ctx context.Context, todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr) {
 	return createTodo(todo)
 }
 
-func (t *todoServiceMock) UpdateTodo(todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr) {
+func (t *todoServiceMock) UpdateTodo(ctx context.Context, todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr) {
 	return updateTodo(todo)
 }
 
-func (t *todoServiceMock) GetTodoById(todoId int64) (*todo_domain.Todo, error_utils.MessageErr) {
-	return getTodoById(todoId)
+func (t *todoServiceMock) PatchTodo(ctx context.Context, todoId int64, ownerId int64, version int64, patch []byte, contentType string) (*todo_domain.Todo, error_utils.MessageErr) {
+	return patchTodo(todoId, ownerId, version, patch, contentType)
 }
 
-func (t *todoServiceMock) GetAllTodos() (*[]todo_domain.Todo, error_utils.MessageErr) {
-	return getAllTodos()
+func (t *todoServiceMock) GetTodoById(ctx context.Context, todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+	return getTodoById(todoId, ownerId)
 }
 
-func (t *todoServiceMock) DeleteTodoById(todoId int64) (*map[string]interface{}, error_utils.MessageErr) {
-	return deleteTodoById(todoId)
+func (t *todoServiceMock) GetAllTodos(ctx context.Context, query *todo_domain.TodoQuery) (*todo_domain.TodoPage, error_utils.MessageErr) {
+	return getAllTodos(query)
+}
+
+func (t *todoServiceMock) SearchTodos(ctx context.Context, search *todo_domain.TodoSearch) (*todo_domain.SearchPage, error_utils.MessageErr) {
+	return searchTodos(search)
+}
+
+func (t *todoServiceMock) DeleteTodoById(ctx context.Context, todoId int64, ownerId int64, version int64, permanent bool) (*todo_domain.DeleteResult, error_utils.MessageErr) {
+	return deleteTodoById(todoId, ownerId, version, permanent)
+}
+
+func (t *todoServiceMock) RestoreTodoById(ctx context.Context, todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+	return restoreTodoById(todoId, ownerId)
+}
+
+func (t *todoServiceMock) PurgeTrash(ctx context.Context, deletedBefore time.Time) (int64, error_utils.MessageErr) {
+	return purgeTrash(deletedBefore)
+}
+
+func (t *todoServiceMock) ApplyBatch(ctx context.Context, ownerId int64, batch *todo_domain.Batch) (*todo_domain.BatchResult, error_utils.MessageErr) {
+	return applyBatch(ownerId, batch)
+}
+
+func (t *todoServiceMock) GetTodoWithChecklist(ctx context.Context, todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+	return getTodoWithChecklist(todoId, ownerId)
+}
+
+func (t *todoServiceMock) GetChecklist(ctx context.Context, todoId int64, ownerId int64) (*todo_domain.Checklist, error_utils.MessageErr) {
+	return getChecklist(todoId, ownerId)
+}
+
+func (t *todoServiceMock) AddChecklistItem(ctx context.Context, item *todo_domain.ChecklistItem) (*todo_domain.ChecklistItem, error_utils.MessageErr) {
+	return addChecklistItem(item)
+}
+
+func (t *todoServiceMock) UpdateChecklistItem(ctx context.Context, todoId int64, ownerId int64, itemId int64, patch *todo_domain.ChecklistItemPatch) (*todo_domain.ChecklistItem, error_utils.MessageErr) {
+	return updateChecklistItem(todoId, ownerId, itemId, patch)
+}
+
+func (t *todoServiceMock) ToggleChecklistItem(ctx context.Context, todoId int64, ownerId int64, itemId int64) (*todo_domain.ChecklistItem, error_utils.MessageErr) {
+	return toggleChecklistItem(todoId, ownerId, itemId)
+}
+
+func (t *todoServiceMock) DeleteChecklistItem(ctx context.Context, todoId int64, ownerId int64, itemId int64) error_utils.MessageErr {
+	return deleteChecklistItem(todoId, ownerId, itemId)
+}
+
+func (t *todoServiceMock) ReorderChecklist(ctx context.Context, todoId int64, ownerId int64, order *todo_domain.ChecklistOrder) (*todo_domain.Checklist, error_utils.MessageErr) {
+	return reorderChecklist(todoId, ownerId, order)
+}
+
+func (t *todoServiceMock) MoveTodo(ctx context.Context, move *todo_domain.TodoMove) (*todo_domain.Todo, error_utils.MessageErr) {
+	return moveTodo(move)
+}
+
+func (t *todoServiceMock) GetOccurrences(ctx context.Context, todoId int64, ownerId int64, count int) (*todo_domain.Occurrences, error_utils.MessageErr) {
+	return getOccurrences(todoId, ownerId, count)
+}
+
+func newAuthenticatedRouter() *gin.Engine {
+	r := gin.Default()
+
+	r.Use(func(c *gin.Context) {
+		c.Set(middlewares.UserIdKey, int64(1))
+	})
+
+	return r
 }
 
 // ----------------
@@ -71,7 +157,7 @@ func TestTodoController_CreateTodo_Success(t *testing.T) {
 		return expectedVal, nil
 	}
 
-	r := gin.Default()
+	r := newAuthenticatedRouter()
 
 	req, _ := http.NewRequest(http.MethodPost, "/todo", bytes.NewBufferString(string(requestJsonData)))
 	rr := httptest.NewRecorder()
@@ -108,7 +194,7 @@ func TestTodoService_CreateTodo_ServerError(t *testing.T) {
 		return nil, error_utils.NewInternalServerError("something went wrong")
 	}
 
-	r := gin.Default()
+	r := newAuthenticatedRouter()
 
 	req, _ := http.NewRequest(http.MethodPost, "/todo", bytes.NewBufferString(string(requestJsonData)))
 	rr := httptest.NewRecorder()
@@ -168,7 +254,7 @@ func TestTodoService_CreateTodo_BadRequest(t *testing.T) {
 
 	requestJsonData, _ := json.Marshal(requestBody)
 
-	r := gin.Default()
+	r := newAuthenticatedRouter()
 	req, _ := http.NewRequest(http.MethodPost, "/todo", bytes.NewBufferString(string(requestJsonData)))
 	rr := httptest.NewRecorder()
 	r.POST("/todo", CreateTodo)
@@ -193,6 +279,54 @@ func TestTodoService_CreateTodo_BadRequest(t *testing.T) {
 	}
 }
 
+func TestTodoController_CreateTodo_FieldErrors(t *testing.T) {
+	todo_service.TodoService = &todoServiceMock{}
+
+	tests := []struct {
+		name  string
+		body  string
+		field error_utils.FieldError
+	}{
+		{
+			name:  "type mismatch",
+			body:  `{"title": "Homework", "description": "Deadline", "completed": "yes"}`,
+			field: error_utils.FieldError{Field: "completed", Rule: "type", Message: "completed must be a boolean"},
+		},
+		{
+			name:  "unknown field",
+			body:  `{"title": "Homework", "description": "Deadline", "assignee": 1}`,
+			field: error_utils.FieldError{Field: "assignee", Rule: "unknown", Message: "assignee is not allowed"},
+		},
+		{
+			name:  "server owned field",
+			body:  `{"title": "Homework", "description": "Deadline", "version": 7}`,
+			field: error_utils.FieldError{Field: "version", Rule: "readonly", Message: "version is read only"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newAuthenticatedRouter()
+
+			req, _ := http.NewRequest(http.MethodPost, "/todo", bytes.NewBufferString(tt.body))
+			rr := httptest.NewRecorder()
+
+			r.POST("/todo", CreateTodo)
+
+			r.ServeHTTP(rr, req)
+
+			var errData error_utils.ValidationErrData
+
+			err := json.Unmarshal(rr.Body.Bytes(), &errData)
+
+			require.Nil(t, err)
+			assert.EqualValues(t, http.StatusBadRequest, rr.Code)
+			assert.EqualValues(t, "bad_request", errData.Error())
+			assert.EqualValues(t, []error_utils.FieldError{tt.field}, errData.Fields)
+		})
+	}
+}
+
 // Test Update Todo
 
 func TestTodoService_UpdateTodo_Success(t *testing.T) {
@@ -218,7 +352,7 @@ func TestTodoService_UpdateTodo_Success(t *testing.T) {
 		return expectedVal, nil
 	}
 
-	r := gin.Default()
+	r := newAuthenticatedRouter()
 
 	todoId := "1"
 
@@ -257,7 +391,7 @@ func TestTodoService_UpdateTodo_ServerError(t *testing.T) {
 		return nil, error_utils.NewInternalServerError("something went wrong")
 	}
 
-	r := gin.Default()
+	r := newAuthenticatedRouter()
 	todoId := "1"
 
 	req, _ := http.NewRequest(http.MethodPut, "/todo/"+todoId, bytes.NewBufferString(string(requestJsonData)))
@@ -318,7 +452,7 @@ func TestTodoService_UpdateTodo_BadRequest(t *testing.T) {
 
 	requestJsonData, _ := json.Marshal(requestBody)
 
-	r := gin.Default()
+	r := newAuthenticatedRouter()
 
 	todoId := "1"
 
@@ -349,6 +483,86 @@ func TestTodoService_UpdateTodo_BadRequest(t *testing.T) {
 	}
 }
 
+// ----------------
+// Test Patch Todo
+
+func TestTodoService_PatchTodo_Success(t *testing.T) {
+	todo_service.TodoService = &todoServiceMock{}
+
+	expectedVal := &todo_domain.Todo{
+		Id:          1,
+		Title:       "Homework",
+		Description: "Deadline: January 19, 2022",
+		Completed:   true,
+	}
+
+	var receivedContentType string
+	var receivedPatch []byte
+
+	patchTodo = func(todoId int64, ownerId int64, version int64, patch []byte, contentType string) (*todo_domain.Todo, error_utils.MessageErr) {
+		receivedPatch = patch
+		receivedContentType = contentType
+		return expectedVal, nil
+	}
+
+	r := newAuthenticatedRouter()
+
+	req, _ := http.NewRequest(http.MethodPatch, "/todo/1", bytes.NewBufferString(`{"completed": true}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json; charset=utf-8")
+	rr := httptest.NewRecorder()
+
+	r.PATCH("/todo/:todoId", PatchTodo)
+
+	r.ServeHTTP(rr, req)
+
+	result := rr.Result()
+
+	data, _ := ioutil.ReadAll(result.Body)
+	defer result.Body.Close()
+
+	var todo todo_domain.Todo
+
+	err := json.Unmarshal(data, &todo)
+
+	assert.Nil(t, err)
+	assert.EqualValues(t, http.StatusOK, result.StatusCode)
+	assert.EqualValues(t, *expectedVal, todo)
+	assert.EqualValues(t, todo_domain.MergePatchContentType, receivedContentType)
+	assert.EqualValues(t, `{"completed": true}`, string(receivedPatch))
+}
+
+func TestTodoService_PatchTodo_UnsupportedMediaType(t *testing.T) {
+	todo_service.TodoService = &todoServiceMock{}
+
+	patchTodo = func(todoId int64, ownerId int64, version int64, patch []byte, contentType string) (*todo_domain.Todo, error_utils.MessageErr) {
+		return nil, error_utils.NewUnsupportedMediaTypeError("content type must be application/merge-patch+json or application/json-patch+json")
+	}
+
+	r := newAuthenticatedRouter()
+
+	req, _ := http.NewRequest(http.MethodPatch, "/todo/1", bytes.NewBufferString(`completed=true`))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rr := httptest.NewRecorder()
+
+	r.PATCH("/todo/:todoId", PatchTodo)
+
+	r.ServeHTTP(rr, req)
+
+	result := rr.Result()
+
+	data, _ := ioutil.ReadAll(result.Body)
+	defer result.Body.Close()
+
+	var errData error_utils.MessageErrData
+	var errDataInterface error_utils.MessageErr = &errData
+
+	err := json.Unmarshal(data, &errData)
+
+	require.Nil(t, err)
+	assert.EqualValues(t, http.StatusUnsupportedMediaType, errDataInterface.Status())
+	assert.EqualValues(t, "unsupported_media_type", errDataInterface.Error())
+}
+
 // ----------------
 // Test Get Todo By ID
 
@@ -362,11 +576,11 @@ func TestTodoService_GetTodoById_Success(t *testing.T) {
 		Completed:   false,
 	}
 
-	getTodoById = func(todoId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+	getTodoById = func(todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
 		return expectedVal, nil
 	}
 
-	r := gin.Default()
+	r := newAuthenticatedRouter()
 
 	todoId := "1"
 
@@ -396,11 +610,11 @@ func TestTodoService_GetTodoById_Success(t *testing.T) {
 func TestTodoService_GetTodoById_NotFoundError(t *testing.T) {
 	todo_service.TodoService = &todoServiceMock{}
 
-	getTodoById = func(todoId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+	getTodoById = func(todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
 		return nil, error_utils.NewNotFoundError("data not found")
 	}
 
-	r := gin.Default()
+	r := newAuthenticatedRouter()
 
 	todoId := "999"
 
@@ -428,34 +642,69 @@ func TestTodoService_GetTodoById_NotFoundError(t *testing.T) {
 	assert.EqualValues(t, "data not found", errDataInterface.Message())
 }
 
+func TestTodoService_GetTodoById_NotAuthenticated(t *testing.T) {
+	todo_service.TodoService = &todoServiceMock{}
+
+	r := gin.Default()
+
+	req, _ := http.NewRequest(http.MethodGet, "/todo/1", nil)
+	rr := httptest.NewRecorder()
+
+	r.GET("/todo/:todoId", GetTodoById)
+
+	r.ServeHTTP(rr, req)
+
+	result := rr.Result()
+
+	data, _ := ioutil.ReadAll(result.Body)
+	defer result.Body.Close()
+
+	var errData error_utils.MessageErrData
+	var errDataInterface error_utils.MessageErr = &errData
+
+	err := json.Unmarshal(data, &errData)
+
+	require.Nil(t, err)
+	assert.EqualValues(t, http.StatusUnauthorized, errDataInterface.Status())
+	assert.EqualValues(t, "not_authenticated", errDataInterface.Error())
+}
+
 // ----------------
 // Test Get All Todos
 
 func TestTodoService_GetAllTodos_Success(t *testing.T) {
 	todo_service.TodoService = &todoServiceMock{}
 
-	expectedVal := &[]todo_domain.Todo{
-		{
-			Id:          1,
-			Title:       "Homework",
-			Description: "Deadline: January 19, 2022",
-			Completed:   false,
-		},
-		{
-			Id:          2,
-			Title:       "Hacktiv8 Course Last Session",
-			Description: "Using Gmeet at Wednesday, January 12 2022",
-			Completed:   true,
+	expectedVal := &todo_domain.TodoPage{
+		Todos: []todo_domain.Todo{
+			{
+				Id:          1,
+				Title:       "Homework",
+				Description: "Deadline: January 19, 2022",
+				Completed:   false,
+			},
+			{
+				Id:          2,
+				Title:       "Hacktiv8 Course Last Session",
+				Description: "Using Gmeet at Wednesday, January 12 2022",
+				Completed:   true,
+			},
 		},
+		Total:      5,
+		Limit:      2,
+		NextCursor: "eyJzIjoiaWQiLCJpIjoyfQ",
 	}
 
-	getAllTodos = func() (*[]todo_domain.Todo, error_utils.MessageErr) {
+	var receivedQuery *todo_domain.TodoQuery
+
+	getAllTodos = func(query *todo_domain.TodoQuery) (*todo_domain.TodoPage, error_utils.MessageErr) {
+		receivedQuery = query
 		return expectedVal, nil
 	}
 
-	r := gin.Default()
+	r := newAuthenticatedRouter()
 
-	req, _ := http.NewRequest(http.MethodGet, "/todo", nil)
+	req, _ := http.NewRequest(http.MethodGet, "/todo?limit=2&completed=false&q=home&sort=-id", nil)
 	rr := httptest.NewRecorder()
 
 	r.GET("/todo", GetAllTodos)
@@ -467,7 +716,7 @@ func TestTodoService_GetAllTodos_Success(t *testing.T) {
 	data, _ := ioutil.ReadAll(result.Body)
 	defer result.Body.Close()
 
-	var todo []todo_domain.Todo
+	var todo todo_domain.TodoPage
 
 	err := json.Unmarshal(data, &todo)
 
@@ -475,6 +724,91 @@ func TestTodoService_GetAllTodos_Success(t *testing.T) {
 	assert.Nil(t, err)
 
 	assert.EqualValues(t, *expectedVal, todo)
+
+	require.NotNil(t, receivedQuery)
+	require.NotNil(t, receivedQuery.Completed)
+	assert.EqualValues(t, 2, receivedQuery.Limit)
+	assert.False(t, *receivedQuery.Completed)
+	assert.EqualValues(t, "home", receivedQuery.Search)
+	assert.EqualValues(t, "-id", receivedQuery.Sort)
+}
+
+func TestTodoController_GetAllTodos_Tags(t *testing.T) {
+	todo_service.TodoService = &todoServiceMock{}
+
+	var receivedQuery *todo_domain.TodoQuery
+
+	getAllTodos = func(query *todo_domain.TodoQuery) (*todo_domain.TodoPage, error_utils.MessageErr) {
+		receivedQuery = query
+		return &todo_domain.TodoPage{Todos: []todo_domain.Todo{}}, nil
+	}
+
+	r := newAuthenticatedRouter()
+	r.GET("/todo", GetAllTodos)
+
+	req, _ := http.NewRequest(http.MethodGet, "/todo?tag=school&tag=+urgent&tag=&tag_mode=all", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusOK, rr.Code)
+	require.NotNil(t, receivedQuery)
+	assert.EqualValues(t, []string{"school", "urgent"}, receivedQuery.Tags)
+	assert.EqualValues(t, todo_domain.TagModeAll, receivedQuery.TagMode)
+}
+
+func TestTodoService_GetAllTodos_BadRequest(t *testing.T) {
+	todo_service.TodoService = &todoServiceMock{}
+
+	r := newAuthenticatedRouter()
+
+	req, _ := http.NewRequest(http.MethodGet, "/todo?completed=maybe&due_before=tomorrow", nil)
+	rr := httptest.NewRecorder()
+
+	r.GET("/todo", GetAllTodos)
+
+	r.ServeHTTP(rr, req)
+
+	result := rr.Result()
+
+	data, _ := ioutil.ReadAll(result.Body)
+	defer result.Body.Close()
+
+	var errData error_utils.MessageErrData
+	var errDataInterface error_utils.MessageErr = &errData
+
+	err := json.Unmarshal(data, &errData)
+
+	require.Nil(t, err)
+	assert.EqualValues(t, http.StatusBadRequest, errDataInterface.Status())
+	assert.EqualValues(t, "bad_request", errDataInterface.Error())
+	assert.EqualValues(t, "invalid completed query param", errDataInterface.Message())
+}
+
+func TestTodoService_GetAllTodos_DueFilters(t *testing.T) {
+	todo_service.TodoService = &todoServiceMock{}
+
+	var receivedQuery *todo_domain.TodoQuery
+
+	getAllTodos = func(query *todo_domain.TodoQuery) (*todo_domain.TodoPage, error_utils.MessageErr) {
+		receivedQuery = query
+		return &todo_domain.TodoPage{Todos: []todo_domain.Todo{}}, nil
+	}
+
+	r := newAuthenticatedRouter()
+
+	req, _ := http.NewRequest(http.MethodGet, "/todo?due_before=2022-01-20T00:00:00Z&overdue=true", nil)
+	rr := httptest.NewRecorder()
+
+	r.GET("/todo", GetAllTodos)
+
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusOK, rr.Code)
+	require.NotNil(t, receivedQuery)
+	require.NotNil(t, receivedQuery.DueBefore)
+	require.NotNil(t, receivedQuery.Overdue)
+	assert.True(t, receivedQuery.DueBefore.Equal(time.Date(2022, time.January, 20, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, *receivedQuery.Overdue)
 }
 
 // ----------------
@@ -483,16 +817,17 @@ func TestTodoService_GetAllTodos_Success(t *testing.T) {
 func TestTodoService_DeleteTodoById_Success(t *testing.T) {
 	todo_service.TodoService = &todoServiceMock{}
 
-	expectedVal := &map[string]interface{}{
-		"StatusDelete": "Success",
-		"AffectedRow":  1,
-	}
+	deletedAt := time.Date(2022, time.January, 20, 10, 0, 0, 0, time.UTC)
+	expectedVal := &todo_domain.DeleteResult{Id: 1, DeletedAt: &deletedAt}
 
-	deleteTodoById = func(todoId int64) (*map[string]interface{}, error_utils.MessageErr) {
+	var receivedPermanent bool
+
+	deleteTodoById = func(todoId int64, ownerId int64, version int64, permanent bool) (*todo_domain.DeleteResult, error_utils.MessageErr) {
+		receivedPermanent = permanent
 		return expectedVal, nil
 	}
 
-	r := gin.Default()
+	r := newAuthenticatedRouter()
 
 	todoId := "1"
 
@@ -508,23 +843,24 @@ func TestTodoService_DeleteTodoById_Success(t *testing.T) {
 	data, _ := ioutil.ReadAll(result.Body)
 	defer result.Body.Close()
 
-	var todo map[string]interface{}
+	var todo todo_domain.DeleteResult
 	err := json.Unmarshal(data, &todo)
-	todo["AffectedRow"] = int(todo["AffectedRow"].(float64))
 
-	assert.NotNil(t, todo)
 	assert.Nil(t, err)
-
-	assert.EqualValues(t, *expectedVal, todo)
+	assert.False(t, receivedPermanent)
+	assert.EqualValues(t, expectedVal.Id, todo.Id)
+	assert.False(t, todo.Permanent)
+	require.NotNil(t, todo.DeletedAt)
+	assert.True(t, deletedAt.Equal(*todo.DeletedAt))
 }
 
 func TestTodoService_DeleteTodoById_NotFoundError(t *testing.T) {
 
-	deleteTodoById = func(todoId int64) (*map[string]interface{}, error_utils.MessageErr) {
+	deleteTodoById = func(todoId int64, ownerId int64, version int64, permanent bool) (*todo_domain.DeleteResult, error_utils.MessageErr) {
 		return nil, error_utils.NewNotFoundError("data not found")
 	}
 
-	r := gin.Default()
+	r := newAuthenticatedRouter()
 
 	todoId := "999"
 
@@ -551,3 +887,511 @@ func TestTodoService_DeleteTodoById_NotFoundError(t *testing.T) {
 	assert.EqualValues(t, "not_found", errDataInterface.Error())
 	assert.EqualValues(t, "data not found", errDataInterface.Message())
 }
+
+// ----------------
+// Test Conditional Requests
+
+func TestTodoController_GetTodoById_NotModified(t *testing.T) {
+	todo_service.TodoService = &todoServiceMock{}
+
+	getTodoById = func(todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+		return &todo_domain.Todo{Id: todoId, Title: "Homework", Description: "Deadline", Version: 3}, nil
+	}
+
+	r := newAuthenticatedRouter()
+	r.GET("/todo/:todoId", GetTodoById)
+
+	tests := []struct {
+		ifNoneMatch string
+		code        int
+	}{
+		{"", http.StatusOK},
+		{`"2"`, http.StatusOK},
+		{`"3"`, http.StatusNotModified},
+		{`W/"3"`, http.StatusNotModified},
+		{`"1", "3"`, http.StatusNotModified},
+	}
+
+	for _, tt := range tests {
+		req, _ := http.NewRequest(http.MethodGet, "/todo/1", nil)
+		if tt.ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", tt.ifNoneMatch)
+		}
+		rr := httptest.NewRecorder()
+
+		r.ServeHTTP(rr, req)
+
+		assert.EqualValues(t, tt.code, rr.Code, tt.ifNoneMatch)
+		assert.EqualValues(t, `"3"`, rr.Header().Get("ETag"))
+
+		if tt.code == http.StatusNotModified {
+			assert.Empty(t, rr.Body.String())
+		}
+	}
+}
+
+func TestTodoController_UpdateTodo_IfMatch(t *testing.T) {
+	todo_service.TodoService = &todoServiceMock{}
+
+	var gotVersion int64
+	updateTodo = func(todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr) {
+		gotVersion = todo.Version
+		if todo.Version != 0 && todo.Version != 3 {
+			return nil, todo_domain.NewVersionMismatchError()
+		}
+		updated := *todo
+		updated.Version = 4
+		return &updated, nil
+	}
+
+	r := newAuthenticatedRouter()
+	r.PUT("/todo/:todoId", UpdateTodo)
+
+	tests := []struct {
+		name     string
+		ifMatch  string
+		required bool
+		version  int64
+		code     int
+	}{
+		{"no header", "", false, 0, http.StatusOK},
+		{"any version", "*", false, 0, http.StatusOK},
+		{"current version", `"3"`, false, 3, http.StatusOK},
+		{"tag of the checklist variant", `"3-checklist"`, false, 3, http.StatusOK},
+		{"stale version", `"2"`, false, 2, http.StatusPreconditionFailed},
+		{"weak tag never matches", `W/"3"`, false, 0, http.StatusPreconditionFailed},
+		{"missing but required", "", true, 0, http.StatusPreconditionRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			etag_utils.SetRequireIfMatch(tt.required)
+			defer etag_utils.SetRequireIfMatch(false)
+
+			gotVersion = -1
+
+			body, _ := json.Marshal(&todo_domain.Todo{Title: "Homework", Description: "Deadline"})
+			req, _ := http.NewRequest(http.MethodPut, "/todo/1", bytes.NewBuffer(body))
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.EqualValues(t, tt.code, rr.Code)
+
+			switch tt.code {
+			case http.StatusOK:
+				assert.EqualValues(t, tt.version, gotVersion)
+				assert.EqualValues(t, `"4"`, rr.Header().Get("ETag"))
+			case http.StatusPreconditionFailed:
+				assert.Contains(t, rr.Body.String(), "precondition_failed")
+			case http.StatusPreconditionRequired:
+				assert.EqualValues(t, -1, gotVersion)
+				assert.Contains(t, rr.Body.String(), "precondition_required")
+			}
+		})
+	}
+}
+
+func TestTodoController_DeleteTodoById_IfMatch(t *testing.T) {
+	todo_service.TodoService = &todoServiceMock{}
+
+	var gotVersion int64
+	deleteTodoById = func(todoId int64, ownerId int64, version int64, permanent bool) (*todo_domain.DeleteResult, error_utils.MessageErr) {
+		gotVersion = version
+		return &todo_domain.DeleteResult{Id: todoId}, nil
+	}
+
+	r := newAuthenticatedRouter()
+	r.DELETE("/todo/:todoId", DeleteTodoById)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/todo/1", nil)
+	req.Header.Set("If-Match", `"7"`)
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusOK, rr.Code)
+	assert.EqualValues(t, 7, gotVersion)
+}
+
+// ----------------
+// Test Trash
+
+func TestTodoController_GetTrash(t *testing.T) {
+	todo_service.TodoService = &todoServiceMock{}
+
+	var receivedQuery *todo_domain.TodoQuery
+
+	getAllTodos = func(query *todo_domain.TodoQuery) (*todo_domain.TodoPage, error_utils.MessageErr) {
+		receivedQuery = query
+		return &todo_domain.TodoPage{Todos: []todo_domain.Todo{}, Limit: 20}, nil
+	}
+
+	r := newAuthenticatedRouter()
+	r.GET("/todo/trash", GetTrash)
+	r.GET("/todo/:todoId", GetTodoById)
+
+	req, _ := http.NewRequest(http.MethodGet, "/todo/trash?sort=-id", nil)
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusOK, rr.Code)
+	require.NotNil(t, receivedQuery)
+	assert.True(t, receivedQuery.Trashed)
+	assert.EqualValues(t, 1, receivedQuery.OwnerId)
+	assert.EqualValues(t, todo_domain.SortByIdDesc, receivedQuery.Sort)
+}
+
+func TestTodoController_RestoreTodoById(t *testing.T) {
+	todo_service.TodoService = &todoServiceMock{}
+
+	restoreTodoById = func(todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+		return &todo_domain.Todo{Id: todoId, Title: "Homework", Description: "Deadline", Version: 5}, nil
+	}
+
+	r := newAuthenticatedRouter()
+	r.POST("/todo/:todoId/restore", RestoreTodoById)
+
+	req, _ := http.NewRequest(http.MethodPost, "/todo/1/restore", nil)
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusOK, rr.Code)
+	assert.EqualValues(t, `"5"`, rr.Header().Get("ETag"))
+	assert.NotContains(t, rr.Body.String(), "deleted_at")
+}
+
+func TestTodoController_DeleteTodoById_Permanent(t *testing.T) {
+	todo_service.TodoService = &todoServiceMock{}
+
+	var receivedPermanent bool
+
+	deleteTodoById = func(todoId int64, ownerId int64, version int64, permanent bool) (*todo_domain.DeleteResult, error_utils.MessageErr) {
+		receivedPermanent = permanent
+		return &todo_domain.DeleteResult{Id: todoId, Permanent: permanent}, nil
+	}
+
+	r := newAuthenticatedRouter()
+	r.DELETE("/todo/:todoId", DeleteTodoById)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/todo/1?permanent=true", nil)
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusOK, rr.Code)
+	assert.True(t, receivedPermanent)
+	assert.JSONEq(t, `{"id": 1, "permanent": true}`, rr.Body.String())
+
+	req, _ = http.NewRequest(http.MethodDelete, "/todo/1?permanent=maybe", nil)
+	rr = httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusBadRequest, rr.Code)
+}
+
+// ----------------
+// Test Batch
+
+func TestTodoController_ApplyBatch(t *testing.T) {
+	todo_service.TodoService = &todoServiceMock{}
+
+	r := newAuthenticatedRouter()
+	r.POST("/todo/batch", ApplyBatch)
+
+	tests := []struct {
+		name   string
+		result *todo_domain.BatchResult
+		code   int
+	}{
+		{
+			name: "committed",
+			result: &todo_domain.BatchResult{Mode: todo_domain.BatchModeAllOrNothing, Committed: true, Succeeded: 1, Results: []todo_domain.BatchItemResult{
+				{Index: 0, Op: todo_domain.BatchOpComplete, Status: http.StatusOK, Todo: &todo_domain.Todo{Id: 1, Completed: true}},
+			}},
+			code: http.StatusOK,
+		},
+		{
+			name: "rolled back",
+			result: &todo_domain.BatchResult{Mode: todo_domain.BatchModeAllOrNothing, Failed: 1, Results: []todo_domain.BatchItemResult{
+				{Index: 0, Op: todo_domain.BatchOpComplete, Status: http.StatusNotFound, Error: error_utils.NewNotFoundError("no record found")},
+			}},
+			code: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var receivedBatch *todo_domain.Batch
+
+			applyBatch = func(ownerId int64, batch *todo_domain.Batch) (*todo_domain.BatchResult, error_utils.MessageErr) {
+				receivedBatch = batch
+				return tt.result, nil
+			}
+
+			req, _ := http.NewRequest(http.MethodPost, "/todo/batch", strings.NewReader(`{"operations": [{"op": "complete", "id": 1, "version": 2}]}`))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.EqualValues(t, tt.code, rr.Code)
+			require.NotNil(t, receivedBatch)
+			require.Len(t, receivedBatch.Operations, 1)
+			assert.EqualValues(t, todo_domain.BatchOperation{Op: "complete", Id: 1, Version: 2}, receivedBatch.Operations[0])
+
+			var res map[string]interface{}
+			require.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+			assert.EqualValues(t, tt.result.Committed, res["committed"])
+		})
+	}
+}
+
+// ----------------
+// Test Checklist
+
+func TestTodoController_GetTodoById_Checklist(t *testing.T) {
+	todo_service.TodoService = &todoServiceMock{}
+
+	getTodoWithChecklist = func(todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+		return &todo_domain.Todo{
+			Id:        todoId,
+			Title:     "Dinner",
+			Checklist: []todo_domain.ChecklistItem{{Id: 7, TodoId: todoId, Text: "Buy eggs", Done: true}},
+			Progress:  todo_domain.ChecklistProgress{Done: 1, Total: 1},
+			Version:   2,
+		}, nil
+	}
+
+	r := newAuthenticatedRouter()
+	r.GET("/todo/:todoId", GetTodoById)
+
+	req, _ := http.NewRequest(http.MethodGet, "/todo/1?include=checklist", nil)
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	require.EqualValues(t, http.StatusOK, rr.Code)
+	assert.EqualValues(t, `"2-checklist"`, rr.Header().Get("ETag"))
+
+	var todo todo_domain.Todo
+	require.Nil(t, json.Unmarshal(rr.Body.Bytes(), &todo))
+	require.Len(t, todo.Checklist, 1)
+	assert.EqualValues(t, "Buy eggs", todo.Checklist[0].Text)
+	assert.EqualValues(t, todo_domain.ChecklistProgress{Done: 1, Total: 1}, todo.Progress)
+
+	// the tag of the todo without its checklist is a different body
+	req, _ = http.NewRequest(http.MethodGet, "/todo/1?include=checklist", nil)
+	req.Header.Set("If-None-Match", `"2"`)
+	rr = httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusOK, rr.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/todo/1?include=checklist", nil)
+	req.Header.Set("If-None-Match", `"2-checklist"`)
+	rr = httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusNotModified, rr.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/todo/1?include=subtasks", nil)
+	rr = httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "include must be checklist")
+}
+
+func TestTodoController_ChecklistItems(t *testing.T) {
+	todo_service.TodoService = &todoServiceMock{}
+
+	addChecklistItem = func(item *todo_domain.ChecklistItem) (*todo_domain.ChecklistItem, error_utils.MessageErr) {
+		created := *item
+		created.Id = 7
+		return &created, nil
+	}
+
+	toggleChecklistItem = func(todoId int64, ownerId int64, itemId int64) (*todo_domain.ChecklistItem, error_utils.MessageErr) {
+		return &todo_domain.ChecklistItem{Id: itemId, TodoId: todoId, Text: "Buy eggs", Done: true}, nil
+	}
+
+	var deleted int64
+	deleteChecklistItem = func(todoId int64, ownerId int64, itemId int64) error_utils.MessageErr {
+		deleted = itemId
+		return nil
+	}
+
+	r := newAuthenticatedRouter()
+	r.POST("/todo/:todoId/checklist", AddChecklistItem)
+	r.POST("/todo/:todoId/checklist/:itemId/toggle", ToggleChecklistItem)
+	r.DELETE("/todo/:todoId/checklist/:itemId", DeleteChecklistItem)
+
+	req, _ := http.NewRequest(http.MethodPost, "/todo/3/checklist", strings.NewReader(`{"text": "Buy eggs"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.EqualValues(t, http.StatusCreated, rr.Code)
+
+	var item todo_domain.ChecklistItem
+	require.Nil(t, json.Unmarshal(rr.Body.Bytes(), &item))
+	assert.EqualValues(t, todo_domain.ChecklistItem{Id: 7, TodoId: 3, Text: "Buy eggs"}, item)
+
+	req, _ = http.NewRequest(http.MethodPost, "/todo/3/checklist/7/toggle", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.EqualValues(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"done":true`)
+
+	req, _ = http.NewRequest(http.MethodPost, "/todo/3/checklist/abc/toggle", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusBadRequest, rr.Code)
+
+	req, _ = http.NewRequest(http.MethodDelete, "/todo/3/checklist/7", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusNoContent, rr.Code)
+	assert.EqualValues(t, 7, deleted)
+}
+
+func TestTodoController_ReorderChecklist(t *testing.T) {
+	todo_service.TodoService = &todoServiceMock{}
+
+	var gotOrder []int64
+	reorderChecklist = func(todoId int64, ownerId int64, order *todo_domain.ChecklistOrder) (*todo_domain.Checklist, error_utils.MessageErr) {
+		gotOrder = order.ItemIds
+		return &todo_domain.Checklist{Items: []todo_domain.ChecklistItem{}}, nil
+	}
+
+	r := newAuthenticatedRouter()
+	r.PUT("/todo/:todoId/checklist/order", ReorderChecklist)
+
+	req, _ := http.NewRequest(http.MethodPut, "/todo/3/checklist/order", strings.NewReader(`{"item_ids": [9, 7, 8]}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusOK, rr.Code)
+	assert.EqualValues(t, []int64{9, 7, 8}, gotOrder)
+	assert.JSONEq(t, `{"data": [], "progress": {"done": 0, "total": 0}}`, rr.Body.String())
+}
+
+func TestTodoController_GetOccurrences(t *testing.T) {
+	todo_service.TodoService = &todoServiceMock{}
+
+	var gotCount int
+	getOccurrences = func(todoId int64, ownerId int64, count int) (*todo_domain.Occurrences, error_utils.MessageErr) {
+		gotCount = count
+		return &todo_domain.Occurrences{
+			Recurrence: "FREQ=DAILY",
+			Items:      []todo_domain.Occurrence{{Occurrence: 2, DueAt: time.Date(2026, 10, 20, 8, 0, 0, 0, time.UTC)}},
+		}, nil
+	}
+
+	r := newAuthenticatedRouter()
+	r.GET("/todo/:todoId/occurrences", GetOccurrences)
+
+	req, _ := http.NewRequest(http.MethodGet, "/todo/3/occurrences", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusOK, rr.Code)
+	assert.EqualValues(t, todo_domain.DefaultOccurrencePreview, gotCount)
+	assert.JSONEq(t, `{"recurrence": "FREQ=DAILY", "data": [{"occurrence": 2, "due_at": "2026-10-20T08:00:00Z"}]}`, rr.Body.String())
+
+	req, _ = http.NewRequest(http.MethodGet, "/todo/3/occurrences?count=51", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "count must be a number from 1 to 50")
+}
+
+func TestTodoController_MoveTodo(t *testing.T) {
+	todo_service.TodoService = &todoServiceMock{}
+
+	var gotMove *todo_domain.TodoMove
+	moveTodo = func(move *todo_domain.TodoMove) (*todo_domain.Todo, error_utils.MessageErr) {
+		gotMove = move
+		return &todo_domain.Todo{Id: move.TodoId, Title: "Homework", Priority: todo_domain.PriorityHigh, Position: "a0V", Version: 8}, nil
+	}
+
+	r := newAuthenticatedRouter()
+	r.POST("/todo/:todoId/move", MoveTodo)
+
+	req, _ := http.NewRequest(http.MethodPost, "/todo/3/move", strings.NewReader(`{"after": 5}`))
+	req.Header.Set("If-Match", `"7"`)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusOK, rr.Code)
+	assert.EqualValues(t, `"8"`, rr.Header().Get("ETag"))
+	assert.Contains(t, rr.Body.String(), `"position":"a0V"`)
+	require.NotNil(t, gotMove)
+	assert.Nil(t, gotMove.Before)
+	assert.EqualValues(t, 5, *gotMove.After)
+	assert.EqualValues(t, 3, gotMove.TodoId)
+	assert.EqualValues(t, 7, gotMove.Version)
+
+	req, _ = http.NewRequest(http.MethodPost, "/todo/3/move", strings.NewReader(`{"position": "a0"}`))
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestTodoController_SearchTodos(t *testing.T) {
+	todo_service.TodoService = &todoServiceMock{}
+
+	var gotSearch *todo_domain.TodoSearch
+	searchTodos = func(search *todo_domain.TodoSearch) (*todo_domain.SearchPage, error_utils.MessageErr) {
+		gotSearch = search
+		return &todo_domain.SearchPage{
+			Results: []todo_domain.SearchResult{{
+				Todo:      todo_domain.Todo{Id: 4, Title: "Make Dinner"},
+				Rank:      0.5,
+				Highlight: todo_domain.SearchHighlight{Title: "Make <mark>Dinner</mark>"},
+			}},
+			Total: 1,
+			Limit: 10,
+		}, nil
+	}
+
+	r := newAuthenticatedRouter()
+	r.GET("/todo/search", SearchTodos)
+
+	req, _ := http.NewRequest(http.MethodGet, `/todo/search?q=%22make+dinner%22&limit=10`, nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusOK, rr.Code)
+	require.NotNil(t, gotSearch)
+	assert.EqualValues(t, `"make dinner"`, gotSearch.Query)
+	assert.EqualValues(t, 10, gotSearch.Limit)
+
+	var page todo_domain.SearchPage
+	require.Nil(t, json.Unmarshal(rr.Body.Bytes(), &page))
+	require.Len(t, page.Results, 1)
+	assert.EqualValues(t, 4, page.Results[0].Id)
+	assert.EqualValues(t, 0.5, page.Results[0].Rank)
+	assert.EqualValues(t, "Make <mark>Dinner</mark>", page.Results[0].Highlight.Title)
+	assert.EqualValues(t, 10, page.Limit)
+
+	req, _ = http.NewRequest(http.MethodGet, "/todo/search?q=dinner&offset=x", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "invalid offset query param")
+}
@@ -0,0 +1,72 @@
+package todo_controller
+
+import (
+	"assignment-4/domain/todo_domain"
+	"assignment-4/service/todo_service"
+	"assignment-4/utils/etag_utils"
+	"assignment-4/utils/response_utils"
+	"assignment-4/utils/validation_utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MoveTodo godoc
+// @Summary Move a todo in the manual order
+// @Tags todo
+// @Description Putting a todo right before or right after another todo, as GET /todo?sort=position lists them. Only the moved todo changes. Send exactly one of before and after.
+// @ID move-todo
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param todoId path int true "todo's todo id"
+// @Param RequestBody body doc_datas.MoveTodoRequest true "request body json"
+// @Param If-Match header string false "ETag from the last GET, the move fails with 412 if the todo changed since"
+// @Success 200 {object} doc_datas.GetTodoResponse
+// @Header 200 {string} ETag "current version of the todo"
+// @Failure 400 {object} error_utils.ValidationErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 404 {object} error_utils.MessageErrData
+// @Failure 412 {object} error_utils.MessageErrData
+// @Failure 428 {object} error_utils.MessageErrData "If-Match is missing and the server requires it"
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /todo/{todoId}/move [post]
+func MoveTodo(c *gin.Context) {
+	ownerId, todoId, ok := todoParams(c)
+
+	if !ok {
+		return
+	}
+
+	version, err := etag_utils.IfMatch(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	var move todo_domain.TodoMove
+
+	if err := validation_utils.BindJSON(c, &move); err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	move.TodoId = todoId
+	move.OwnerId = ownerId
+	move.Version = version
+
+	res, err := todo_service.TodoService.MoveTodo(c.Request.Context(), &move)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.Header("ETag", etag_utils.ETag(res.Version))
+	c.JSON(http.StatusOK, res)
+}
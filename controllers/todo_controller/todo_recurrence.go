@@ -0,0 +1,55 @@
+package todo_controller
+
+import (
+	"assignment-4/domain/todo_domain"
+	"assignment-4/service/todo_service"
+	"assignment-4/utils/response_utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetOccurrences godoc
+// @Summary Preview the occurrences of a recurring todo
+// @Tags todo
+// @Description Listing the due dates that follow a recurring todo, as completing each occurrence would create them. The list ends early when the rule has a COUNT or UNTIL.
+// @ID get-occurrences
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param todoId path int true "todo's todo id"
+// @Param count query int false "number of occurrences, from 1 to 50" default(5)
+// @Success 200 {object} doc_datas.OccurrencesResponse
+// @Failure 400 {object} error_utils.MessageErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 404 {object} error_utils.MessageErrData
+// @Failure 409 {object} error_utils.MessageErrData "the todo has no recurrence"
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /todo/{todoId}/occurrences [get]
+func GetOccurrences(c *gin.Context) {
+	ownerId, todoId, ok := todoParams(c)
+
+	if !ok {
+		return
+	}
+
+	count, err := todo_domain.ParseOccurrenceCount(c.Query("count"))
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	res, err := todo_service.TodoService.GetOccurrences(c.Request.Context(), todoId, ownerId, count)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
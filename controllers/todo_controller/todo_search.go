@@ -0,0 +1,56 @@
+package todo_controller
+
+import (
+	"assignment-4/domain/todo_domain"
+	"assignment-4/middlewares"
+	"assignment-4/service/todo_service"
+	"assignment-4/utils/response_utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchTodos godoc
+// @Summary Search todos
+// @Tags todo
+// @Description Full text search over the titles and descriptions of todos, best matches first. Words match whole and case insensitively, a word ending in * matches as a prefix (hom* finds homework) and words in double quotes have to follow each other ("fried rice"). Every term has to match. Trashed todos are not searched. The highlight has the title and a snippet of the description with matching words wrapped in <mark> tags; the rest of the text is HTML escaped.
+// @ID search-todos
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Security BearerAuth
+// @Param q query string true "search terms, at most 200 characters and 16 terms"
+// @Param limit query int false "page size, 1 to 100" default(20)
+// @Param offset query int false "number of results to skip"
+// @Success 200 {object} doc_datas.SearchTodosResponse
+// @Failure 400 {object} error_utils.MessageErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /todo/search [get]
+func SearchTodos(c *gin.Context) {
+	ownerId, err := middlewares.GetUserId(c)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	search := todo_domain.TodoSearch{OwnerId: ownerId}
+
+	if err := search.ParseQueryParams(c); err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	res, err := todo_service.TodoService.SearchTodos(c.Request.Context(), &search)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
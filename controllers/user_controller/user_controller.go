@@ -0,0 +1,116 @@
+package user_controller
+
+import (
+	"assignment-4/domain/user_domain"
+	"assignment-4/service/user_service"
+	"assignment-4/utils/response_utils"
+	"assignment-4/utils/validation_utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Register godoc
+// @Summary Register a user
+// @Tags users
+// @Description registering a new user account
+// @ID register-user
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Param RequestBody body doc_datas.RegisterRequest true "request body json"
+// @Success 201 {object} doc_datas.RegisterResponse
+// @Failure 400 {object} error_utils.ValidationErrData
+// @Failure 409 {object} error_utils.MessageErrData
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /users/register [post]
+func Register(c *gin.Context) {
+	var user user_domain.User
+
+	if err := validation_utils.BindJSON(c, &user); err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	res, err := user_service.UserService.Register(c.Request.Context(), &user)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// Login godoc
+// @Summary Login
+// @Tags users
+// @Description exchanging email and password for an access and a refresh token
+// @ID login-user
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Param RequestBody body doc_datas.LoginRequest true "request body json"
+// @Success 200 {object} doc_datas.TokenResponse
+// @Failure 400 {object} error_utils.ValidationErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /users/login [post]
+func Login(c *gin.Context) {
+	var loginReq user_domain.LoginRequest
+
+	if err := validation_utils.BindJSON(c, &loginReq); err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	res, err := user_service.UserService.Login(c.Request.Context(), &loginReq)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// RefreshToken godoc
+// @Summary Refresh tokens
+// @Tags users
+// @Description exchanging a refresh token for a new access and refresh token
+// @ID refresh-token
+// @Accept json
+// @Produce json
+// @Produce application/problem+json
+// @Param RequestBody body doc_datas.RefreshTokenRequest true "request body json"
+// @Success 200 {object} doc_datas.TokenResponse
+// @Failure 400 {object} error_utils.ValidationErrData
+// @Failure 401 {object} error_utils.MessageErrData
+// @Failure 500 {object} error_utils.MessageErrData
+// @Failure 503 {object} error_utils.MessageErrData
+// @Failure 504 {object} error_utils.MessageErrData
+// @Failure default {object} error_utils.Problem "error as problem details when Accept is application/problem+json"
+// @Router /users/refresh [post]
+func RefreshToken(c *gin.Context) {
+	var refreshReq user_domain.RefreshTokenRequest
+
+	if err := validation_utils.BindJSON(c, &refreshReq); err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	res, err := user_service.UserService.RefreshToken(c.Request.Context(), &refreshReq)
+
+	if err != nil {
+		response_utils.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
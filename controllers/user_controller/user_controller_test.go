@@ -0,0 +1,146 @@
+package user_controller
+
+import (
+	"assignment-4/domain/user_domain"
+	"assignment-4/service/user_service"
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/token_utils"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	register     func(user *user_domain.User) (*user_domain.User, error_utils.MessageErr)
+	login        func(loginReq *user_domain.LoginRequest) (*token_utils.Tokens, error_utils.MessageErr)
+	refreshToken func(refreshReq *user_domain.RefreshTokenRequest) (*token_utils.Tokens, error_utils.MessageErr)
+)
+
+type userServiceMock struct{}
+
+func (u *userServiceMock) Register(ctx context.Context, user *user_domain.User) (*user_domain.User, error_utils.MessageErr) {
+	return register(user)
+}
+
+func (u *userServiceMock) Login(ctx context.Context, loginReq *user_domain.LoginRequest) (*token_utils.Tokens, error_utils.MessageErr) {
+	return login(loginReq)
+}
+
+func (u *userServiceMock) RefreshToken(ctx context.Context, refreshReq *user_domain.RefreshTokenRequest) (*token_utils.Tokens, error_utils.MessageErr) {
+	return refreshToken(refreshReq)
+}
+
+// ----------------
+// Test Register
+
+func TestUserController_Register_Success(t *testing.T) {
+	user_service.UserService = &userServiceMock{}
+
+	register = func(user *user_domain.User) (*user_domain.User, error_utils.MessageErr) {
+		return &user_domain.User{Id: 1, Email: user.Email}, nil
+	}
+
+	requestJsonData, _ := json.Marshal(&user_domain.User{Email: "john@mail.com", Password: "secret123"})
+
+	r := gin.Default()
+
+	req, _ := http.NewRequest(http.MethodPost, "/users/register", bytes.NewBuffer(requestJsonData))
+	rr := httptest.NewRecorder()
+
+	r.POST("/users/register", Register)
+
+	r.ServeHTTP(rr, req)
+
+	result := rr.Result()
+
+	data, _ := ioutil.ReadAll(result.Body)
+	defer result.Body.Close()
+
+	var user map[string]interface{}
+
+	err := json.Unmarshal(data, &user)
+
+	require.Nil(t, err)
+	assert.EqualValues(t, http.StatusCreated, result.StatusCode)
+	assert.EqualValues(t, "john@mail.com", user["email"])
+	assert.NotContains(t, user, "password")
+}
+
+// ----------------
+// Test Login
+
+func TestUserController_Login_Success(t *testing.T) {
+	user_service.UserService = &userServiceMock{}
+
+	expectedVal := &token_utils.Tokens{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		TokenType:    "Bearer",
+		ExpiresIn:    900,
+	}
+
+	login = func(loginReq *user_domain.LoginRequest) (*token_utils.Tokens, error_utils.MessageErr) {
+		return expectedVal, nil
+	}
+
+	r := gin.Default()
+
+	req, _ := http.NewRequest(http.MethodPost, "/users/login", bytes.NewBufferString(`{"email": "john@mail.com", "password": "secret123"}`))
+	rr := httptest.NewRecorder()
+
+	r.POST("/users/login", Login)
+
+	r.ServeHTTP(rr, req)
+
+	result := rr.Result()
+
+	data, _ := ioutil.ReadAll(result.Body)
+	defer result.Body.Close()
+
+	var tokens token_utils.Tokens
+
+	err := json.Unmarshal(data, &tokens)
+
+	require.Nil(t, err)
+	assert.EqualValues(t, *expectedVal, tokens)
+}
+
+func TestUserController_Login_NotAuthenticated(t *testing.T) {
+	user_service.UserService = &userServiceMock{}
+
+	login = func(loginReq *user_domain.LoginRequest) (*token_utils.Tokens, error_utils.MessageErr) {
+		return nil, error_utils.NewNotAuthenticated("invalid email or password")
+	}
+
+	r := gin.Default()
+
+	req, _ := http.NewRequest(http.MethodPost, "/users/login", bytes.NewBufferString(`{"email": "john@mail.com", "password": "wrong"}`))
+	rr := httptest.NewRecorder()
+
+	r.POST("/users/login", Login)
+
+	r.ServeHTTP(rr, req)
+
+	result := rr.Result()
+
+	data, _ := ioutil.ReadAll(result.Body)
+	defer result.Body.Close()
+
+	var errData error_utils.MessageErrData
+	var errDataInterface error_utils.MessageErr = &errData
+
+	err := json.Unmarshal(data, &errData)
+
+	require.Nil(t, err)
+	assert.EqualValues(t, http.StatusUnauthorized, errDataInterface.Status())
+	assert.EqualValues(t, "not_authenticated", errDataInterface.Error())
+	assert.EqualValues(t, "invalid email or password", errDataInterface.Message())
+}
@@ -1,41 +1,106 @@
 package db
 
 import (
+	"assignment-4/config"
+	"assignment-4/migrations"
+	"context"
 	"database/sql"
 	"fmt"
-	"log"
-	"os"
+	"time"
 
-	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
+	"github.com/rs/zerolog/log"
 )
 
-var (
-	db  *sql.DB
-	err error
+const (
+	initialBackoff = 500 * time.Millisecond
+	maxBackoff     = 10 * time.Second
 )
 
-func InitializeDB() {
-	if err := godotenv.Load(); err != nil {
-		log.Fatal("error loading .env file")
+var db *sql.DB
+
+// InitializeDB connects to the database and migrates it to the latest schema.
+func InitializeDB(cfg config.DBConfig) error {
+	if err := ConnectDB(cfg); err != nil {
+		return err
 	}
 
-	dbdriver := os.Getenv("DBDRIVER")
-	dbusername := os.Getenv("DBUSERNAME")
-	dbpassword := os.Getenv("DBPASSWORD")
-	host := os.Getenv("HOST")
-	database := os.Getenv("DATABASE")
-	PORT := os.Getenv("PORT")
+	if err := migrations.Up(db); err != nil {
+		return fmt.Errorf("migrating database: %w", err)
+	}
 
-	DBURL := fmt.Sprintf("postgresql://%s:%s@%s:%s/%s?sslmode=disable", dbusername, dbpassword, host, PORT, database)
+	log.Info().Msg("database schema is up to date")
 
-	db, err = sql.Open(dbdriver, DBURL)
+	return nil
+}
 
+func ConnectDB(cfg config.DBConfig) error {
+	conn, err := sql.Open(cfg.Driver, cfg.ConnectionString())
 	if err != nil {
-		log.Fatal("Error connecting to database:", err.Error())
+		return fmt.Errorf("connecting to database: %w", err)
+	}
+
+	conn.SetMaxOpenConns(cfg.MaxOpenConns)
+	conn.SetMaxIdleConns(cfg.MaxIdleConns)
+	conn.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	conn.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	if err := pingWithRetry(conn, cfg); err != nil {
+		conn.Close()
+		return fmt.Errorf("connecting to database: %w", err)
+	}
+
+	db = conn
+
+	log.Info().Str("host", cfg.Host).Str("name", cfg.Name).Msg("connected to database")
+
+	return nil
+}
+
+// pingWithRetry waits for the database with exponential backoff, so the app
+// can start alongside a Postgres that is still booting.
+func pingWithRetry(conn *sql.DB, cfg config.DBConfig) error {
+	backoff := initialBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := ping(conn, cfg.ConnectTimeout)
+		if err == nil || attempt >= cfg.ConnectAttempts {
+			return err
+		}
+
+		log.Warn().
+			Err(err).
+			Int("attempt", attempt).
+			Int("attempts", cfg.ConnectAttempts).
+			Dur("backoff", backoff).
+			Msg("database not ready, retrying")
+		time.Sleep(backoff)
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func ping(conn *sql.DB, timeout time.Duration) error {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	return conn.PingContext(ctx)
+}
+
+// Close closes the connection pool, waiting for queries in progress to finish.
+func Close() error {
+	if db == nil {
+		return nil
 	}
 
-	fmt.Println("Successfully connected to database")
+	return db.Close()
 }
 
 func GetDB() *sql.DB {
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Queryer is what repositories need from *sql.DB and *sql.Tx.
+type Queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+type txKey struct{}
+
+// WithTx returns a ctx whose repository queries run in tx.
+func WithTx(ctx context.Context, tx *sql.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// Conn returns the transaction ctx belongs to, or the pool outside of one.
+func Conn(ctx context.Context) Queryer {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+
+	return db
+}
+
+type undoKey struct{}
+
+// UndoLog lets in-memory repositories take part in a transaction of another
+// in-memory repository: writes made with its ctx register how to undo
+// themselves with OnRollback.
+type UndoLog struct {
+	parent context.Context
+	undo   []func()
+}
+
+// WithUndoLog starts an undo log for a memory transaction, nested in the one
+// ctx belongs to, if any.
+func WithUndoLog(ctx context.Context) (context.Context, *UndoLog) {
+	log := &UndoLog{parent: ctx}
+
+	return context.WithValue(ctx, undoKey{}, log), log
+}
+
+// OnRollback registers undo to run if the memory transaction ctx belongs to
+// is rolled back. Outside of one it does nothing.
+func OnRollback(ctx context.Context, undo func()) {
+	if log, ok := ctx.Value(undoKey{}).(*UndoLog); ok {
+		log.undo = append(log.undo, undo)
+	}
+}
+
+// Rollback undoes the registered writes, newest first.
+func (l *UndoLog) Rollback() {
+	for i := len(l.undo) - 1; i >= 0; i-- {
+		l.undo[i]()
+	}
+
+	l.undo = nil
+}
+
+// Commit hands the registered writes to the enclosing transaction, which can
+// still roll them back.
+func (l *UndoLog) Commit() {
+	for _, undo := range l.undo {
+		OnRollback(l.parent, undo)
+	}
+
+	l.undo = nil
+}
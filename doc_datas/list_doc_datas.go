@@ -0,0 +1,42 @@
+package doc_datas
+
+import "time"
+
+// Create List
+
+type CreateListRequest struct {
+	Name        string `json:"name" example:"Groceries" maxLength:"100"`
+	Description string `json:"description" example:"Things to buy this week" maxLength:"2000"`
+}
+
+// Update List
+
+type UpdateListRequest struct {
+	Name        string `json:"name" example:"Weekly Groceries" maxLength:"100"`
+	Description string `json:"description" example:"Things to buy every week" maxLength:"2000"`
+}
+
+// Get List
+
+type ListResponse struct {
+	Id             int64      `json:"id" example:"2"`
+	Name           string     `json:"name" example:"Groceries"`
+	Description    string     `json:"description" example:"Things to buy this week"`
+	ArchivedAt     *time.Time `json:"archived_at" example:"2022-01-20T10:00:00Z"`
+	CreatedAt      time.Time  `json:"created_at" example:"2022-01-12T08:00:00Z"`
+	UpdatedAt      time.Time  `json:"updated_at" example:"2022-01-19T15:30:00Z"`
+	TodoCount      int64      `json:"todo_count" example:"5"`
+	CompletedCount int64      `json:"completed_count" example:"3"`
+}
+
+// Get All Lists
+
+type GetAllListsResponse struct {
+	Data []ListResponse `json:"data"`
+}
+
+// Move Todos
+
+type MoveTodosRequest struct {
+	TodoIds []int64 `json:"todo_ids" example:"1,4,7" minItems:"1" maxItems:"100"`
+}
@@ -0,0 +1,34 @@
+package doc_datas
+
+import "time"
+
+// Create Tag
+
+type CreateTagRequest struct {
+	Name  string `json:"name" example:"urgent" maxLength:"50"`
+	Color string `json:"color" example:"#e53935" default:"#808080"`
+}
+
+// Update Tag
+
+type UpdateTagRequest struct {
+	Name  string `json:"name" example:"important" maxLength:"50"`
+	Color string `json:"color" example:"#fb8c00" default:"#808080"`
+}
+
+// Get Tag
+
+type TagResponse struct {
+	Id        int64     `json:"id" example:"3"`
+	Name      string    `json:"name" example:"urgent"`
+	Color     string    `json:"color" example:"#e53935"`
+	TodoCount int64     `json:"todo_count" example:"4"`
+	CreatedAt time.Time `json:"created_at" example:"2022-01-12T08:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2022-01-19T15:30:00Z"`
+}
+
+// Get All Tags
+
+type GetAllTagsResponse struct {
+	Data []TagResponse `json:"data"`
+}
@@ -1,53 +1,277 @@
 package doc_datas
 
+import (
+	"assignment-4/utils/error_utils"
+	"time"
+)
+
 // Create ToDo
 
 type CreateTodoResponse struct {
-	Id          int64  `json:"id" example:"1"`
-	Title       string `json:"title" example:"Make Dinner"`
-	Description string `json:"description" example:"Cook fried rice with egg and chicken"`
-	Completed   bool   `json:"completed" example:"false"`
+	Id               int64                     `json:"id" example:"1"`
+	Title            string                    `json:"title" example:"Make Dinner"`
+	Description      string                    `json:"description" example:"Cook fried rice with egg and chicken"`
+	Completed        bool                      `json:"completed" example:"false"`
+	Priority         string                    `json:"priority" example:"high" enums:"none,low,medium,high,urgent"`
+	Position         string                    `json:"position" example:"a0V"`
+	DueAt            *time.Time                `json:"due_at" example:"2022-01-19T17:00:00Z"`
+	RemindAt         *time.Time                `json:"remind_at" example:"2022-01-19T09:00:00Z"`
+	ListId           *int64                    `json:"list_id" example:"2"`
+	Tags             []string                  `json:"tags" example:"school,urgent"`
+	Recurrence       *string                   `json:"recurrence" example:"FREQ=WEEKLY;BYDAY=MO,TH"`
+	Occurrence       int                       `json:"occurrence" example:"1"`
+	NextOccurrenceId *int64                    `json:"next_occurrence_id" example:"4"`
+	Progress         ChecklistProgressResponse `json:"progress"`
+	CompletedAt      *time.Time                `json:"completed_at" example:"2022-01-19T15:30:00Z"`
+	CreatedAt        time.Time                 `json:"created_at" example:"2022-01-12T08:00:00Z"`
+	UpdatedAt        time.Time                 `json:"updated_at" example:"2022-01-19T15:30:00Z"`
+	Version          int64                     `json:"version" example:"3"`
 }
 
 type CreateTodoRequest struct {
-	Title       string `json:"title" example:"Make Dinner"`
-	Description string `json:"description" example:"Cook fried rice with egg and chicken"`
-	Completed   bool   `json:"completed" example:"false"`
+	Title       string     `json:"title" example:"Make Dinner" maxLength:"255"`
+	Description string     `json:"description" example:"Cook fried rice with egg and chicken" maxLength:"2000"`
+	Completed   bool       `json:"completed" example:"false"`
+	Priority    string     `json:"priority" example:"high" enums:"none,low,medium,high,urgent" default:"none"`
+	DueAt       *time.Time `json:"due_at" example:"2022-01-19T17:00:00Z"`
+	RemindAt    *time.Time `json:"remind_at" example:"2022-01-19T09:00:00Z"`
+	ListId      *int64     `json:"list_id" example:"2"`
+	Tags        []string   `json:"tags" example:"school,urgent"`
+	Recurrence  *string    `json:"recurrence" example:"FREQ=WEEKLY;BYDAY=MO,TH"`
 }
 
 // Update ToDo
 
 type UpdateTodoResponse struct {
-	Id          int64  `json:"id" example:"1"`
-	Title       string `json:"title" example:"Make Delicious Dinner"`
-	Description string `json:"description" example:"Cook fried chicken with spicy sauce"`
-	Completed   bool   `json:"completed" example:"false"`
+	Id               int64                     `json:"id" example:"1"`
+	Title            string                    `json:"title" example:"Make Delicious Dinner"`
+	Description      string                    `json:"description" example:"Cook fried chicken with spicy sauce"`
+	Completed        bool                      `json:"completed" example:"false"`
+	Priority         string                    `json:"priority" example:"high" enums:"none,low,medium,high,urgent"`
+	Position         string                    `json:"position" example:"a0V"`
+	DueAt            *time.Time                `json:"due_at" example:"2022-01-19T17:00:00Z"`
+	RemindAt         *time.Time                `json:"remind_at" example:"2022-01-19T09:00:00Z"`
+	ListId           *int64                    `json:"list_id" example:"2"`
+	Tags             []string                  `json:"tags" example:"school,urgent"`
+	Recurrence       *string                   `json:"recurrence" example:"FREQ=WEEKLY;BYDAY=MO,TH"`
+	Occurrence       int                       `json:"occurrence" example:"1"`
+	NextOccurrenceId *int64                    `json:"next_occurrence_id" example:"4"`
+	Progress         ChecklistProgressResponse `json:"progress"`
+	CompletedAt      *time.Time                `json:"completed_at" example:"2022-01-19T15:30:00Z"`
+	CreatedAt        time.Time                 `json:"created_at" example:"2022-01-12T08:00:00Z"`
+	UpdatedAt        time.Time                 `json:"updated_at" example:"2022-01-19T15:30:00Z"`
+	Version          int64                     `json:"version" example:"3"`
 }
 
 type UpdateTodoRequest struct {
-	Title       string `json:"title" example:"Make Delicious Dinner"`
-	Description string `json:"description" example:"Cook fried chicken with spicy sauce"`
-	Completed   bool   `json:"completed" example:"false"`
+	Title       string     `json:"title" example:"Make Delicious Dinner" maxLength:"255"`
+	Description string     `json:"description" example:"Cook fried chicken with spicy sauce" maxLength:"2000"`
+	Completed   bool       `json:"completed" example:"false"`
+	Priority    string     `json:"priority" example:"high" enums:"none,low,medium,high,urgent" default:"none"`
+	DueAt       *time.Time `json:"due_at" example:"2022-01-19T17:00:00Z"`
+	RemindAt    *time.Time `json:"remind_at" example:"2022-01-19T09:00:00Z"`
+	ListId      *int64     `json:"list_id" example:"2"`
+	Tags        []string   `json:"tags" example:"school,urgent"`
+	Recurrence  *string    `json:"recurrence" example:"FREQ=WEEKLY;BYDAY=MO,TH"`
+}
+
+// Patch ToDo
+
+type PatchTodoRequest struct {
+	Title       string     `json:"title,omitempty" example:"Make Delicious Dinner" maxLength:"255"`
+	Description string     `json:"description,omitempty" example:"Cook fried chicken with spicy sauce" maxLength:"2000"`
+	Completed   bool       `json:"completed,omitempty" example:"true"`
+	Priority    string     `json:"priority,omitempty" example:"urgent" enums:"none,low,medium,high,urgent"`
+	DueAt       *time.Time `json:"due_at" example:"2022-01-19T17:00:00Z"`
+	RemindAt    *time.Time `json:"remind_at" example:"2022-01-19T09:00:00Z"`
+	ListId      *int64     `json:"list_id" example:"2"`
+	Tags        []string   `json:"tags" example:"school,urgent"`
+	Recurrence  *string    `json:"recurrence" example:"FREQ=WEEKLY;BYDAY=MO,TH"`
+}
+
+type PatchTodoResponse struct {
+	Id               int64                     `json:"id" example:"1"`
+	Title            string                    `json:"title" example:"Make Delicious Dinner"`
+	Description      string                    `json:"description" example:"Cook fried chicken with spicy sauce"`
+	Completed        bool                      `json:"completed" example:"true"`
+	Priority         string                    `json:"priority" example:"high" enums:"none,low,medium,high,urgent"`
+	Position         string                    `json:"position" example:"a0V"`
+	DueAt            *time.Time                `json:"due_at" example:"2022-01-19T17:00:00Z"`
+	RemindAt         *time.Time                `json:"remind_at" example:"2022-01-19T09:00:00Z"`
+	ListId           *int64                    `json:"list_id" example:"2"`
+	Tags             []string                  `json:"tags" example:"school,urgent"`
+	Recurrence       *string                   `json:"recurrence" example:"FREQ=WEEKLY;BYDAY=MO,TH"`
+	Occurrence       int                       `json:"occurrence" example:"1"`
+	NextOccurrenceId *int64                    `json:"next_occurrence_id" example:"4"`
+	Progress         ChecklistProgressResponse `json:"progress"`
+	CompletedAt      *time.Time                `json:"completed_at" example:"2022-01-19T15:30:00Z"`
+	CreatedAt        time.Time                 `json:"created_at" example:"2022-01-12T08:00:00Z"`
+	UpdatedAt        time.Time                 `json:"updated_at" example:"2022-01-19T15:30:00Z"`
+	Version          int64                     `json:"version" example:"3"`
 }
 
 // Get ToDo By ID
 
 type GetTodoResponse struct {
-	Id          int64  `json:"id" example:"1"`
-	Title       string `json:"title" example:"Make Delicious Dinner"`
-	Description string `json:"description" example:"Cook fried chicken with spicy sauce"`
-	Completed   bool   `json:"completed" example:"false"`
+	Id               int64                     `json:"id" example:"1"`
+	Title            string                    `json:"title" example:"Make Delicious Dinner"`
+	Description      string                    `json:"description" example:"Cook fried chicken with spicy sauce"`
+	Completed        bool                      `json:"completed" example:"false"`
+	Priority         string                    `json:"priority" example:"high" enums:"none,low,medium,high,urgent"`
+	Position         string                    `json:"position" example:"a0V"`
+	DueAt            *time.Time                `json:"due_at" example:"2022-01-19T17:00:00Z"`
+	RemindAt         *time.Time                `json:"remind_at" example:"2022-01-19T09:00:00Z"`
+	ListId           *int64                    `json:"list_id" example:"2"`
+	Tags             []string                  `json:"tags" example:"school,urgent"`
+	Recurrence       *string                   `json:"recurrence" example:"FREQ=WEEKLY;BYDAY=MO,TH"`
+	Occurrence       int                       `json:"occurrence" example:"1"`
+	NextOccurrenceId *int64                    `json:"next_occurrence_id" example:"4"`
+	Progress         ChecklistProgressResponse `json:"progress"`
+	CompletedAt      *time.Time                `json:"completed_at" example:"2022-01-19T15:30:00Z"`
+	CreatedAt        time.Time                 `json:"created_at" example:"2022-01-12T08:00:00Z"`
+	UpdatedAt        time.Time                 `json:"updated_at" example:"2022-01-19T15:30:00Z"`
+	Version          int64                     `json:"version" example:"3"`
+	DeletedAt        *time.Time                `json:"deleted_at,omitempty" example:"2022-01-20T10:00:00Z"`
+	Checklist        []ChecklistItemResponse   `json:"checklist,omitempty"`
 }
 
 // Get All ToDo
 
 type GetAllTodosResponse struct {
-	Todos []GetTodoResponse
+	Data       []GetTodoResponse `json:"data"`
+	Total      int64             `json:"total" example:"42"`
+	Limit      int               `json:"limit" example:"20"`
+	NextCursor string            `json:"next_cursor,omitempty" example:"eyJzIjoiaWQiLCJpIjoyMH0"`
 }
 
 // Delete ToDo
 
 type DeleteTodoResponse struct {
-	StatusDelete string `json:"status_delete" example:"Success"`
-	AffectedRow  int64  `json:"affected_row" example:"1"`
+	Id        int64      `json:"id" example:"1"`
+	Permanent bool       `json:"permanent" example:"false"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" example:"2022-01-20T10:00:00Z"`
+}
+
+// Batch
+
+type BatchOperationRequest struct {
+	Op        string             `json:"op" example:"complete" enums:"create,update,delete,complete"`
+	Id        int64              `json:"id,omitempty" example:"1"`
+	Version   int64              `json:"version,omitempty" example:"3"`
+	Permanent bool               `json:"permanent,omitempty" example:"false"`
+	Todo      *CreateTodoRequest `json:"todo,omitempty"`
+}
+
+type BatchRequest struct {
+	Mode       string                  `json:"mode" example:"all_or_nothing" enums:"all_or_nothing,per_item"`
+	Operations []BatchOperationRequest `json:"operations" maxItems:"100"`
+}
+
+type BatchItemResponse struct {
+	Index   int                         `json:"index" example:"0"`
+	Op      string                      `json:"op" example:"complete"`
+	Status  int                         `json:"status" example:"200"`
+	Todo    *GetTodoResponse            `json:"todo,omitempty"`
+	Deleted *DeleteTodoResponse         `json:"deleted,omitempty"`
+	Error   *error_utils.MessageErrData `json:"error,omitempty"`
+}
+
+type BatchResponse struct {
+	Mode      string              `json:"mode" example:"all_or_nothing"`
+	Committed bool                `json:"committed" example:"true"`
+	Succeeded int                 `json:"succeeded" example:"1"`
+	Failed    int                 `json:"failed" example:"0"`
+	Results   []BatchItemResponse `json:"results"`
+}
+
+// Checklist
+
+type ChecklistProgressResponse struct {
+	Done  int64 `json:"done" example:"1"`
+	Total int64 `json:"total" example:"3"`
+}
+
+type ChecklistItemResponse struct {
+	Id        int64     `json:"id" example:"7"`
+	TodoId    int64     `json:"todo_id" example:"1"`
+	Text      string    `json:"text" example:"Buy eggs"`
+	Done      bool      `json:"done" example:"true"`
+	Position  int       `json:"position" example:"0"`
+	CreatedAt time.Time `json:"created_at" example:"2022-01-12T08:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2022-01-19T15:30:00Z"`
+}
+
+type ChecklistResponse struct {
+	Data     []ChecklistItemResponse   `json:"data"`
+	Progress ChecklistProgressResponse `json:"progress"`
+}
+
+type AddChecklistItemRequest struct {
+	Text string `json:"text" example:"Buy eggs" maxLength:"500"`
+	Done bool   `json:"done" example:"false"`
+}
+
+type UpdateChecklistItemRequest struct {
+	Text *string `json:"text,omitempty" example:"Buy a dozen eggs" maxLength:"500"`
+	Done *bool   `json:"done,omitempty" example:"true"`
+}
+
+type ReorderChecklistRequest struct {
+	ItemIds []int64 `json:"item_ids" example:"9,7,8" maxItems:"100"`
+}
+
+// Recurrence
+
+type OccurrenceResponse struct {
+	Occurrence int       `json:"occurrence" example:"2"`
+	DueAt      time.Time `json:"due_at" example:"2022-01-24T17:00:00Z"`
+}
+
+type OccurrencesResponse struct {
+	Recurrence string               `json:"recurrence" example:"FREQ=WEEKLY;BYDAY=MO,TH"`
+	Data       []OccurrenceResponse `json:"data"`
+}
+
+// Move ToDo
+
+type MoveTodoRequest struct {
+	Before *int64 `json:"before,omitempty" example:"7"`
+	After  *int64 `json:"after,omitempty" example:"3"`
+}
+
+// Search ToDo
+
+type SearchHighlightResponse struct {
+	Title       string `json:"title" example:"Make Delicious <mark>Dinner</mark>"`
+	Description string `json:"description" example:"Cook fried <mark>chicken</mark> with spicy sauce"`
+}
+
+type SearchResultResponse struct {
+	Id               int64                     `json:"id" example:"1"`
+	Title            string                    `json:"title" example:"Make Delicious Dinner"`
+	Description      string                    `json:"description" example:"Cook fried chicken with spicy sauce"`
+	Completed        bool                      `json:"completed" example:"false"`
+	Priority         string                    `json:"priority" example:"high" enums:"none,low,medium,high,urgent"`
+	Position         string                    `json:"position" example:"a0V"`
+	DueAt            *time.Time                `json:"due_at" example:"2022-01-19T17:00:00Z"`
+	RemindAt         *time.Time                `json:"remind_at" example:"2022-01-19T09:00:00Z"`
+	ListId           *int64                    `json:"list_id" example:"2"`
+	Tags             []string                  `json:"tags" example:"school,urgent"`
+	Recurrence       *string                   `json:"recurrence" example:"FREQ=WEEKLY;BYDAY=MO,TH"`
+	Occurrence       int                       `json:"occurrence" example:"1"`
+	NextOccurrenceId *int64                    `json:"next_occurrence_id" example:"4"`
+	Progress         ChecklistProgressResponse `json:"progress"`
+	CompletedAt      *time.Time                `json:"completed_at" example:"2022-01-19T15:30:00Z"`
+	CreatedAt        time.Time                 `json:"created_at" example:"2022-01-12T08:00:00Z"`
+	UpdatedAt        time.Time                 `json:"updated_at" example:"2022-01-19T15:30:00Z"`
+	Version          int64                     `json:"version" example:"3"`
+	Rank             float64                   `json:"rank" example:"0.75"`
+	Highlight        SearchHighlightResponse   `json:"highlight"`
+}
+
+type SearchTodosResponse struct {
+	Data   []SearchResultResponse `json:"data"`
+	Total  int64                  `json:"total" example:"3"`
+	Limit  int                    `json:"limit" example:"20"`
+	Offset int                    `json:"offset" example:"0"`
 }
@@ -0,0 +1,33 @@
+package doc_datas
+
+// Register User
+
+type RegisterRequest struct {
+	Email    string `json:"email" example:"john@mail.com"`
+	Password string `json:"password" example:"secret123"`
+}
+
+type RegisterResponse struct {
+	Id    int64  `json:"id" example:"1"`
+	Email string `json:"email" example:"john@mail.com"`
+}
+
+// Login User
+
+type LoginRequest struct {
+	Email    string `json:"email" example:"john@mail.com"`
+	Password string `json:"password" example:"secret123"`
+}
+
+// Refresh Token
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+}
+
+type TokenResponse struct {
+	AccessToken  string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken string `json:"refresh_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	TokenType    string `json:"token_type" example:"Bearer"`
+	ExpiresIn    int64  `json:"expires_in" example:"900"`
+}
@@ -23,34 +23,61 @@ var doc = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/todo": {
+        "/healthz": {
+            "get": {
+                "description": "Reporting that the process is up, it does not touch the database",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "operationId": "healthz",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/health_service.Check"
+                        }
+                    }
+                }
+            }
+        },
+        "/lists": {
             "get": {
-                "description": "Getting all todos",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Getting the active or the archived lists, with the number of their todos and of their completed todos",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
-                    "todo"
+                    "lists"
                 ],
-                "summary": "Get all todos",
-                "operationId": "get-all-todos",
+                "summary": "Get all lists",
+                "operationId": "get-all-lists",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "todo's todo id",
-                        "name": "todoId",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "default": false,
+                        "description": "archived lists instead of active ones",
+                        "name": "archived",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/doc_datas.GetAllTodosResponse"
+                            "$ref": "#/definitions/doc_datas.GetAllListsResponse"
                         }
                     },
                     "400": {
@@ -59,8 +86,8 @@ var doc = `{
                             "$ref": "#/definitions/error_utils.MessageErrData"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/error_utils.MessageErrData"
                         }
@@ -70,22 +97,46 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/error_utils.MessageErrData"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
                     }
                 }
             },
             "post": {
-                "description": "creating a new todo",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "creating a new list to group todos",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
-                    "todo"
+                    "lists"
                 ],
-                "summary": "Create a todo",
-                "operationId": "create-todo",
+                "summary": "Create a list",
+                "operationId": "create-list",
                 "parameters": [
                     {
                         "description": "request body json",
@@ -93,7 +144,7 @@ var doc = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/doc_datas.CreateTodoRequest"
+                            "$ref": "#/definitions/doc_datas.CreateListRequest"
                         }
                     }
                 ],
@@ -101,11 +152,23 @@ var doc = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/doc_datas.CreateTodoResponse"
+                            "$ref": "#/definitions/doc_datas.ListResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.ValidationErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "409": {
+                        "description": "a list with this name already exists",
                         "schema": {
                             "$ref": "#/definitions/error_utils.MessageErrData"
                         }
@@ -115,29 +178,53 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/error_utils.MessageErrData"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
                     }
                 }
             }
         },
-        "/todo/{todoId}": {
+        "/lists/{listId}": {
             "get": {
-                "description": "Getting a todo by ID",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Getting a list by ID with the number of its todos and of its completed todos",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
-                    "todo"
+                    "lists"
                 ],
-                "summary": "Get todo by ID",
-                "operationId": "get-todo",
+                "summary": "Get list by ID",
+                "operationId": "get-list",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "todo's todo id",
-                        "name": "todoId",
+                        "description": "list id",
+                        "name": "listId",
                         "in": "path",
                         "required": true
                     }
@@ -146,7 +233,7 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/doc_datas.GetTodoResponse"
+                            "$ref": "#/definitions/doc_datas.ListResponse"
                         }
                     },
                     "400": {
@@ -155,6 +242,12 @@ var doc = `{
                             "$ref": "#/definitions/error_utils.MessageErrData"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -166,22 +259,46 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/error_utils.MessageErrData"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
                     }
                 }
             },
             "put": {
-                "description": "Updating a todo by ID",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Renaming a list or changing its description",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
-                    "todo"
+                    "lists"
                 ],
-                "summary": "Update todo",
-                "operationId": "update-todo",
+                "summary": "Update list",
+                "operationId": "update-list",
                 "parameters": [
                     {
                         "description": "request body json",
@@ -189,13 +306,13 @@ var doc = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/doc_datas.UpdateTodoRequest"
+                            "$ref": "#/definitions/doc_datas.UpdateListRequest"
                         }
                     },
                     {
                         "type": "integer",
-                        "description": "todo's todo id",
-                        "name": "todoId",
+                        "description": "list id",
+                        "name": "listId",
                         "in": "path",
                         "required": true
                     }
@@ -204,11 +321,17 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/doc_datas.UpdateTodoResponse"
+                            "$ref": "#/definitions/doc_datas.ListResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.ValidationErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/error_utils.MessageErrData"
                         }
@@ -219,32 +342,140 @@ var doc = `{
                             "$ref": "#/definitions/error_utils.MessageErrData"
                         }
                     },
+                    "409": {
+                        "description": "a list with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/error_utils.MessageErrData"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
                     }
                 }
             },
             "delete": {
-                "description": "Deleting a todo by ID",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deleting a list by ID. Its todos are kept and no longer belong to a list.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "lists"
+                ],
+                "summary": "Delete list by ID",
+                "operationId": "delete-list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "list id",
+                        "name": "listId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "list deleted"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/{listId}/archive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Archiving a list. Its todos stay in it, but no todos can be added until it is unarchived.",
+                "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
                 "tags": [
-                    "todo"
+                    "lists"
                 ],
-                "summary": "Delete todo by ID",
-                "operationId": "delete-todo",
+                "summary": "Archive list",
+                "operationId": "archive-list",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "todo's todo id",
-                        "name": "todoId",
+                        "description": "list id",
+                        "name": "listId",
                         "in": "path",
                         "required": true
                     }
@@ -253,7 +484,7 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/doc_datas.DeleteTodoResponse"
+                            "$ref": "#/definitions/doc_datas.ListResponse"
                         }
                     },
                     "400": {
@@ -262,6 +493,12 @@ var doc = `{
                             "$ref": "#/definitions/error_utils.MessageErrData"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -273,92 +510,3983 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/error_utils.MessageErrData"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/{listId}/todos": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Getting a page of the todos in a list, with the same filters as listing todos",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "lists"
+                ],
+                "summary": "Get todos of a list",
+                "operationId": "get-list-todos",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "list id",
+                        "name": "listId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "page size, 1 to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of todos to skip, cannot be combined with cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "filter by completion status",
+                        "name": "completed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "only todos due before this RFC 3339 timestamp",
+                        "name": "due_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only todos that are (true) or are not (false) open past their due date",
+                        "name": "overdue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "case insensitive substring of title or description",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "only todos with these tags, repeat the param for more than one",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "whether todos need any or all of the tags",
+                        "name": "tag_mode",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "none",
+                            "low",
+                            "medium",
+                            "high",
+                            "urgent"
+                        ],
+                        "type": "string",
+                        "description": "only todos with this priority",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "-id",
+                            "title",
+                            "position",
+                            "-priority"
+                        ],
+                        "type": "string",
+                        "default": "id",
+                        "description": "sort order, position is the manual order of POST /todo/{todoId}/move and -priority puts the most urgent first",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.GetAllTodosResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moving up to 100 todos into a list, out of whatever list they were in. Either all todos are moved or none.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "lists"
+                ],
+                "summary": "Move todos into a list",
+                "operationId": "move-todos",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "list id",
+                        "name": "listId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body json",
+                        "name": "RequestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.MoveTodosRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.ListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "404": {
+                        "description": "the list or one of the todos does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "409": {
+                        "description": "the list is archived",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/{listId}/unarchive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Making an archived list active again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "lists"
+                ],
+                "summary": "Unarchive list",
+                "operationId": "unarchive-list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "list id",
+                        "name": "listId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.ListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/problems": {
+            "get": {
+                "description": "Listing every stable error code and the problem type URI it maps to",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "problems"
+                ],
+                "summary": "List error codes",
+                "operationId": "get-problem-types",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/error_utils.ErrorCode"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/problems/{code}": {
+            "get": {
+                "description": "Describing a single error code, this is where problem type URIs resolve to",
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "problems"
+                ],
+                "summary": "Get error code",
+                "operationId": "get-problem-type",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "error code, e.g. not_found",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.ErrorCode"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Pinging the database, checking the migration version and reporting connection pool stats",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "operationId": "readyz",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/health_service.Readiness"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/health_service.Readiness"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Getting all tags by name, each with the number of todos carrying it, trashed todos left out",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get all tags",
+                "operationId": "get-all-tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.GetAllTagsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "creating a new tag. Tags named on a todo that do not exist yet are created with the default color.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Create a tag",
+                "operationId": "create-tag",
+                "parameters": [
+                    {
+                        "description": "request body json",
+                        "name": "RequestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.CreateTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.TagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.ValidationErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "409": {
+                        "description": "a tag with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{tagId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Getting a tag by ID with the number of todos carrying it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get tag by ID",
+                "operationId": "get-tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "tag id",
+                        "name": "tagId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.TagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Renaming a tag or changing its color. Todos carrying the tag show the new name.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Update tag",
+                "operationId": "update-tag",
+                "parameters": [
+                    {
+                        "description": "request body json",
+                        "name": "RequestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.UpdateTagRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "tag id",
+                        "name": "tagId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.TagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.ValidationErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "409": {
+                        "description": "a tag with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deleting a tag by ID and taking it off every todo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Delete tag by ID",
+                "operationId": "delete-tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "tag id",
+                        "name": "tagId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "tag deleted"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Getting a page of todos, optionally filtered and sorted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Get all todos",
+                "operationId": "get-all-todos",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "page size, 1 to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of todos to skip, cannot be combined with cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "filter by completion status",
+                        "name": "completed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "only todos due before this RFC 3339 timestamp",
+                        "name": "due_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only todos that are (true) or are not (false) open past their due date",
+                        "name": "overdue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "case insensitive substring of title or description",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "only todos with these tags, repeat the param for more than one",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "whether todos need any or all of the tags",
+                        "name": "tag_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only todos in this list",
+                        "name": "list_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "none",
+                            "low",
+                            "medium",
+                            "high",
+                            "urgent"
+                        ],
+                        "type": "string",
+                        "description": "only todos with this priority",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "-id",
+                            "title",
+                            "position",
+                            "-priority"
+                        ],
+                        "type": "string",
+                        "default": "id",
+                        "description": "sort order, position is the manual order of POST /todo/{todoId}/move and -priority puts the most urgent first",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.GetAllTodosResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "creating a new todo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Create a todo",
+                "operationId": "create-todo",
+                "parameters": [
+                    {
+                        "description": "request body json",
+                        "name": "RequestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.CreateTodoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.CreateTodoResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the new todo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.ValidationErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Running up to 100 create, update, delete and complete operations in one transaction, in order. In all_or_nothing mode (the default) the first failing operation rolls back the whole batch, the response then has its status and only that operation in results. In per_item mode only failing operations are rolled back and the response is 200 with a result per operation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Apply a batch of todo operations",
+                "operationId": "apply-batch",
+                "parameters": [
+                    {
+                        "description": "request body json",
+                        "name": "RequestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.BatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.BatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.ValidationErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "404": {
+                        "description": "an all_or_nothing batch failed, e.g. because a todo does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.BatchResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "an all_or_nothing batch failed on a stale version",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.BatchResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Full text search over the titles and descriptions of todos, best matches first. Words match whole and case insensitively, a word ending in * matches as a prefix (hom* finds homework) and words in double quotes have to follow each other (\"fried rice\"). Every term has to match. Trashed todos are not searched. The highlight has the title and a snippet of the description with matching words wrapped in \u003cmark\u003e tags; the rest of the text is HTML escaped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Search todos",
+                "operationId": "search-todos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "search terms, at most 200 characters and 16 terms",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "page size, 1 to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of results to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.SearchTodosResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Getting a page of deleted todos that can still be restored, with the same filters as listing todos",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Get trashed todos",
+                "operationId": "get-trash",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "page size, 1 to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of todos to skip, cannot be combined with cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "filter by completion status",
+                        "name": "completed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "only todos due before this RFC 3339 timestamp",
+                        "name": "due_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only todos that are (true) or are not (false) open past their due date",
+                        "name": "overdue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "case insensitive substring of title or description",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "only todos with these tags, repeat the param for more than one",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "whether todos need any or all of the tags",
+                        "name": "tag_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only todos in this list",
+                        "name": "list_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "none",
+                            "low",
+                            "medium",
+                            "high",
+                            "urgent"
+                        ],
+                        "type": "string",
+                        "description": "only todos with this priority",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "-id",
+                            "title",
+                            "position",
+                            "-priority"
+                        ],
+                        "type": "string",
+                        "default": "id",
+                        "description": "sort order, position is the manual order of POST /todo/{todoId}/move and -priority puts the most urgent first",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.GetAllTodosResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{todoId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Getting a todo by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Get todo by ID",
+                "operationId": "get-todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "todo's todo id",
+                        "name": "todoId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "checklist"
+                        ],
+                        "type": "string",
+                        "description": "also return the checklist items of the todo",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from an earlier GET, answered with 304 if the todo is unchanged",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.GetTodoResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "current version of the todo, with a -checklist suffix for include=checklist"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "todo is unchanged"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updating a todo by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Update todo",
+                "operationId": "update-todo",
+                "parameters": [
+                    {
+                        "description": "request body json",
+                        "name": "RequestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.UpdateTodoRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "todo's todo id",
+                        "name": "todoId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from the last GET, the write fails with 412 if the todo changed since",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.UpdateTodoResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "current version of the todo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.ValidationErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is missing and the server requires it",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moving a todo to the trash, from where it can be restored until it is purged. With permanent=true the todo is deleted for good, also from the trash.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Delete todo by ID",
+                "operationId": "delete-todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "todo's todo id",
+                        "name": "todoId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "delete for good instead of moving to the trash",
+                        "name": "permanent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from the last GET, the write fails with 412 if the todo changed since",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.DeleteTodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is missing and the server requires it",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Applying a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902) to a todo by ID, chosen by Content-Type",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Partially update todo",
+                "operationId": "patch-todo",
+                "parameters": [
+                    {
+                        "description": "merge patch document, or for json patch an array of operations such as [{\\",
+                        "name": "RequestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.PatchTodoRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "todo's todo id",
+                        "name": "todoId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from the last GET, the write fails with 412 if the todo changed since",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.PatchTodoResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "current version of the todo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.ValidationErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is missing and the server requires it",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{todoId}/checklist": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Getting the checklist items of a todo in order, with its progress",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "checklist"
+                ],
+                "summary": "Get the checklist of a todo",
+                "operationId": "get-checklist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "todo's todo id",
+                        "name": "todoId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.ChecklistResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Appending an item to the checklist of a todo, which holds at most 100 items",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "checklist"
+                ],
+                "summary": "Add a checklist item",
+                "operationId": "add-checklist-item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "todo's todo id",
+                        "name": "todoId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body json",
+                        "name": "RequestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.AddChecklistItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.ChecklistItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.ValidationErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "409": {
+                        "description": "the checklist is full",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{todoId}/checklist/order": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Putting the checklist items of a todo in a new order. item_ids has to list every item exactly once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "checklist"
+                ],
+                "summary": "Reorder a checklist",
+                "operationId": "reorder-checklist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "todo's todo id",
+                        "name": "todoId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body json",
+                        "name": "RequestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.ReorderChecklistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.ChecklistResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.ValidationErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{todoId}/checklist/{itemId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removing an item from the checklist of a todo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "checklist"
+                ],
+                "summary": "Delete a checklist item",
+                "operationId": "delete-checklist-item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "todo's todo id",
+                        "name": "todoId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "checklist item id",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "checklist item deleted"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changing the text of a checklist item, marking it done or both. Fields that are left out keep their value.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "checklist"
+                ],
+                "summary": "Update a checklist item",
+                "operationId": "update-checklist-item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "todo's todo id",
+                        "name": "todoId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "checklist item id",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body json",
+                        "name": "RequestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.UpdateChecklistItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.ChecklistItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.ValidationErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{todoId}/checklist/{itemId}/toggle": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marking an open checklist item done, or a done one open again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "checklist"
+                ],
+                "summary": "Toggle a checklist item",
+                "operationId": "toggle-checklist-item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "todo's todo id",
+                        "name": "todoId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "checklist item id",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.ChecklistItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{todoId}/move": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Putting a todo right before or right after another todo, as GET /todo?sort=position lists them. Only the moved todo changes. Send exactly one of before and after.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Move a todo in the manual order",
+                "operationId": "move-todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "todo's todo id",
+                        "name": "todoId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body json",
+                        "name": "RequestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.MoveTodoRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from the last GET, the move fails with 412 if the todo changed since",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.GetTodoResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "current version of the todo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.ValidationErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is missing and the server requires it",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{todoId}/occurrences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Listing the due dates that follow a recurring todo, as completing each occurrence would create them. The list ends early when the rule has a COUNT or UNTIL.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Preview the occurrences of a recurring todo",
+                "operationId": "get-occurrences",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "todo's todo id",
+                        "name": "todoId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "number of occurrences, from 1 to 50",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.OccurrencesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "409": {
+                        "description": "the todo has no recurrence",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{todoId}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moving a deleted todo out of the trash",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Restore todo by ID",
+                "operationId": "restore-todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "todo's todo id",
+                        "name": "todoId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.GetTodoResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "current version of the todo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "404": {
+                        "description": "todo is not in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/login": {
+            "post": {
+                "description": "exchanging email and password for an access and a refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Login",
+                "operationId": "login-user",
+                "parameters": [
+                    {
+                        "description": "request body json",
+                        "name": "RequestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.ValidationErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/refresh": {
+            "post": {
+                "description": "exchanging a refresh token for a new access and refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Refresh tokens",
+                "operationId": "refresh-token",
+                "parameters": [
+                    {
+                        "description": "request body json",
+                        "name": "RequestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.ValidationErrData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/register": {
+            "post": {
+                "description": "registering a new user account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Register a user",
+                "operationId": "register-user",
+                "parameters": [
+                    {
+                        "description": "request body json",
+                        "name": "RequestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/doc_datas.RegisterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.ValidationErrData"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.MessageErrData"
+                        }
+                    },
+                    "default": {
+                        "description": "error as problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/error_utils.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "doc_datas.AddChecklistItemRequest": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "text": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Buy eggs"
+                }
+            }
+        },
+        "doc_datas.BatchItemResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "$ref": "#/definitions/doc_datas.DeleteTodoResponse"
+                },
+                "error": {
+                    "$ref": "#/definitions/error_utils.MessageErrData"
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "op": {
+                    "type": "string",
+                    "example": "complete"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "todo": {
+                    "$ref": "#/definitions/doc_datas.GetTodoResponse"
+                }
+            }
+        },
+        "doc_datas.BatchOperationRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "delete",
+                        "complete"
+                    ],
+                    "example": "complete"
+                },
+                "permanent": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "todo": {
+                    "$ref": "#/definitions/doc_datas.CreateTodoRequest"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "doc_datas.BatchRequest": {
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "all_or_nothing",
+                        "per_item"
+                    ],
+                    "example": "all_or_nothing"
+                },
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/doc_datas.BatchOperationRequest"
+                    }
+                }
+            }
+        },
+        "doc_datas.BatchResponse": {
+            "type": "object",
+            "properties": {
+                "committed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "mode": {
+                    "type": "string",
+                    "example": "all_or_nothing"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/doc_datas.BatchItemResponse"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "doc_datas.ChecklistItemResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2022-01-12T08:00:00Z"
+                },
+                "done": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Buy eggs"
+                },
+                "todo_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2022-01-19T15:30:00Z"
+                }
+            }
+        },
+        "doc_datas.ChecklistProgressResponse": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "doc_datas.ChecklistResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/doc_datas.ChecklistItemResponse"
+                    }
+                },
+                "progress": {
+                    "$ref": "#/definitions/doc_datas.ChecklistProgressResponse"
+                }
+            }
+        },
+        "doc_datas.CreateListRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "Things to buy this week"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Groceries"
+                }
+            }
+        },
+        "doc_datas.CreateTagRequest": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "default": "#808080",
+                    "example": "#e53935"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "urgent"
+                }
+            }
+        },
+        "doc_datas.CreateTodoRequest": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "Cook fried rice with egg and chicken"
+                },
+                "due_at": {
+                    "type": "string",
+                    "example": "2022-01-19T17:00:00Z"
+                },
+                "list_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "priority": {
+                    "type": "string",
+                    "default": "none",
+                    "enum": [
+                        "none",
+                        "low",
+                        "medium",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "high"
+                },
+                "recurrence": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO,TH"
+                },
+                "remind_at": {
+                    "type": "string",
+                    "example": "2022-01-19T09:00:00Z"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "school",
+                        "urgent"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Make Dinner"
+                }
+            }
+        },
+        "doc_datas.CreateTodoResponse": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "completed_at": {
+                    "type": "string",
+                    "example": "2022-01-19T15:30:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2022-01-12T08:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Cook fried rice with egg and chicken"
+                },
+                "due_at": {
+                    "type": "string",
+                    "example": "2022-01-19T17:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "list_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "next_occurrence_id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "occurrence": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "position": {
+                    "type": "string",
+                    "example": "a0V"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "low",
+                        "medium",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "high"
+                },
+                "progress": {
+                    "$ref": "#/definitions/doc_datas.ChecklistProgressResponse"
+                },
+                "recurrence": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO,TH"
+                },
+                "remind_at": {
+                    "type": "string",
+                    "example": "2022-01-19T09:00:00Z"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "school",
+                        "urgent"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Make Dinner"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2022-01-19T15:30:00Z"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "doc_datas.DeleteTodoResponse": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2022-01-20T10:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "permanent": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "doc_datas.GetAllListsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/doc_datas.ListResponse"
+                    }
+                }
+            }
+        },
+        "doc_datas.GetAllTagsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/doc_datas.TagResponse"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "doc_datas.CreateTodoRequest": {
-            "type": "object",
-            "properties": {
-                "completed": {
-                    "type": "boolean",
-                    "example": false
-                },
-                "description": {
-                    "type": "string",
-                    "example": "Cook fried rice with egg and chicken"
-                },
-                "title": {
-                    "type": "string",
-                    "example": "Make Dinner"
-                }
-            }
-        },
-        "doc_datas.CreateTodoResponse": {
-            "type": "object",
-            "properties": {
-                "completed": {
-                    "type": "boolean",
-                    "example": false
-                },
-                "description": {
-                    "type": "string",
-                    "example": "Cook fried rice with egg and chicken"
-                },
-                "id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "title": {
-                    "type": "string",
-                    "example": "Make Dinner"
-                }
-            }
-        },
-        "doc_datas.DeleteTodoResponse": {
-            "type": "object",
-            "properties": {
-                "affected_row": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "status_delete": {
-                    "type": "string",
-                    "example": "Success"
-                }
-            }
         },
         "doc_datas.GetAllTodosResponse": {
             "type": "object",
             "properties": {
-                "todos": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/doc_datas.GetTodoResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiaWQiLCJpIjoyMH0"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "doc_datas.GetTodoResponse": {
+            "type": "object",
+            "properties": {
+                "checklist": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/doc_datas.ChecklistItemResponse"
+                    }
+                },
+                "completed": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "completed_at": {
+                    "type": "string",
+                    "example": "2022-01-19T15:30:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2022-01-12T08:00:00Z"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2022-01-20T10:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Cook fried chicken with spicy sauce"
+                },
+                "due_at": {
+                    "type": "string",
+                    "example": "2022-01-19T17:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "list_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "next_occurrence_id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "occurrence": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "position": {
+                    "type": "string",
+                    "example": "a0V"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "low",
+                        "medium",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "high"
+                },
+                "progress": {
+                    "$ref": "#/definitions/doc_datas.ChecklistProgressResponse"
+                },
+                "recurrence": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO,TH"
+                },
+                "remind_at": {
+                    "type": "string",
+                    "example": "2022-01-19T09:00:00Z"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "school",
+                        "urgent"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Make Delicious Dinner"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2022-01-19T15:30:00Z"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "doc_datas.ListResponse": {
+            "type": "object",
+            "properties": {
+                "archived_at": {
+                    "type": "string",
+                    "example": "2022-01-20T10:00:00Z"
+                },
+                "completed_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2022-01-12T08:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Things to buy this week"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Groceries"
+                },
+                "todo_count": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2022-01-19T15:30:00Z"
+                }
+            }
+        },
+        "doc_datas.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john@mail.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "secret123"
+                }
+            }
+        },
+        "doc_datas.MoveTodoRequest": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "before": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "doc_datas.MoveTodosRequest": {
+            "type": "object",
+            "properties": {
+                "todo_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        4,
+                        7
+                    ]
+                }
+            }
+        },
+        "doc_datas.OccurrenceResponse": {
+            "type": "object",
+            "properties": {
+                "due_at": {
+                    "type": "string",
+                    "example": "2022-01-24T17:00:00Z"
+                },
+                "occurrence": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "doc_datas.OccurrencesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/doc_datas.OccurrenceResponse"
+                    }
+                },
+                "recurrence": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO,TH"
+                }
+            }
+        },
+        "doc_datas.PatchTodoRequest": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "Cook fried chicken with spicy sauce"
+                },
+                "due_at": {
+                    "type": "string",
+                    "example": "2022-01-19T17:00:00Z"
+                },
+                "list_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "low",
+                        "medium",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "urgent"
+                },
+                "recurrence": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO,TH"
+                },
+                "remind_at": {
+                    "type": "string",
+                    "example": "2022-01-19T09:00:00Z"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "school",
+                        "urgent"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Make Delicious Dinner"
+                }
+            }
+        },
+        "doc_datas.PatchTodoResponse": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "completed_at": {
+                    "type": "string",
+                    "example": "2022-01-19T15:30:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2022-01-12T08:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Cook fried chicken with spicy sauce"
+                },
+                "due_at": {
+                    "type": "string",
+                    "example": "2022-01-19T17:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "list_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "next_occurrence_id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "occurrence": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "position": {
+                    "type": "string",
+                    "example": "a0V"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "low",
+                        "medium",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "high"
+                },
+                "progress": {
+                    "$ref": "#/definitions/doc_datas.ChecklistProgressResponse"
+                },
+                "recurrence": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO,TH"
+                },
+                "remind_at": {
+                    "type": "string",
+                    "example": "2022-01-19T09:00:00Z"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "school",
+                        "urgent"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Make Delicious Dinner"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2022-01-19T15:30:00Z"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "doc_datas.RefreshTokenRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "doc_datas.RegisterRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john@mail.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "secret123"
+                }
+            }
+        },
+        "doc_datas.RegisterResponse": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john@mail.com"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "doc_datas.ReorderChecklistRequest": {
+            "type": "object",
+            "properties": {
+                "item_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        9,
+                        7,
+                        8
+                    ]
+                }
+            }
+        },
+        "doc_datas.SearchHighlightResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Cook fried \u003cmark\u003echicken\u003c/mark\u003e with spicy sauce"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Make Delicious \u003cmark\u003eDinner\u003c/mark\u003e"
+                }
+            }
+        },
+        "doc_datas.SearchResultResponse": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "completed_at": {
+                    "type": "string",
+                    "example": "2022-01-19T15:30:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2022-01-12T08:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Cook fried chicken with spicy sauce"
+                },
+                "due_at": {
+                    "type": "string",
+                    "example": "2022-01-19T17:00:00Z"
+                },
+                "highlight": {
+                    "$ref": "#/definitions/doc_datas.SearchHighlightResponse"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "list_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "next_occurrence_id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "occurrence": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "position": {
+                    "type": "string",
+                    "example": "a0V"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "low",
+                        "medium",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "high"
+                },
+                "progress": {
+                    "$ref": "#/definitions/doc_datas.ChecklistProgressResponse"
+                },
+                "rank": {
+                    "type": "number",
+                    "example": 0.75
+                },
+                "recurrence": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO,TH"
+                },
+                "remind_at": {
+                    "type": "string",
+                    "example": "2022-01-19T09:00:00Z"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "school",
+                        "urgent"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Make Delicious Dinner"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2022-01-19T15:30:00Z"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "doc_datas.SearchTodosResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/doc_datas.GetTodoResponse"
+                        "$ref": "#/definitions/doc_datas.SearchResultResponse"
                     }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "doc_datas.GetTodoResponse": {
+        "doc_datas.TagResponse": {
             "type": "object",
             "properties": {
-                "completed": {
-                    "type": "boolean",
-                    "example": false
+                "color": {
+                    "type": "string",
+                    "example": "#e53935"
                 },
-                "description": {
+                "created_at": {
                     "type": "string",
-                    "example": "Cook fried chicken with spicy sauce"
+                    "example": "2022-01-12T08:00:00Z"
                 },
                 "id": {
                     "type": "integer",
-                    "example": 1
+                    "example": 3
                 },
-                "title": {
+                "name": {
                     "type": "string",
-                    "example": "Make Delicious Dinner"
+                    "example": "urgent"
+                },
+                "todo_count": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2022-01-19T15:30:00Z"
+                }
+            }
+        },
+        "doc_datas.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "expires_in": {
+                    "type": "integer",
+                    "example": 900
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
+                }
+            }
+        },
+        "doc_datas.UpdateChecklistItemRequest": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "text": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Buy a dozen eggs"
+                }
+            }
+        },
+        "doc_datas.UpdateListRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "Things to buy every week"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Weekly Groceries"
+                }
+            }
+        },
+        "doc_datas.UpdateTagRequest": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "default": "#808080",
+                    "example": "#fb8c00"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "important"
                 }
             }
         },
@@ -371,10 +4499,50 @@ var doc = `{
                 },
                 "description": {
                     "type": "string",
+                    "maxLength": 2000,
                     "example": "Cook fried chicken with spicy sauce"
                 },
+                "due_at": {
+                    "type": "string",
+                    "example": "2022-01-19T17:00:00Z"
+                },
+                "list_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "priority": {
+                    "type": "string",
+                    "default": "none",
+                    "enum": [
+                        "none",
+                        "low",
+                        "medium",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "high"
+                },
+                "recurrence": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO,TH"
+                },
+                "remind_at": {
+                    "type": "string",
+                    "example": "2022-01-19T09:00:00Z"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "school",
+                        "urgent"
+                    ]
+                },
                 "title": {
                     "type": "string",
+                    "maxLength": 255,
                     "example": "Make Delicious Dinner"
                 }
             }
@@ -386,17 +4554,124 @@ var doc = `{
                     "type": "boolean",
                     "example": false
                 },
+                "completed_at": {
+                    "type": "string",
+                    "example": "2022-01-19T15:30:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2022-01-12T08:00:00Z"
+                },
                 "description": {
                     "type": "string",
                     "example": "Cook fried chicken with spicy sauce"
                 },
+                "due_at": {
+                    "type": "string",
+                    "example": "2022-01-19T17:00:00Z"
+                },
                 "id": {
                     "type": "integer",
                     "example": 1
                 },
+                "list_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "next_occurrence_id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "occurrence": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "position": {
+                    "type": "string",
+                    "example": "a0V"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "low",
+                        "medium",
+                        "high",
+                        "urgent"
+                    ],
+                    "example": "high"
+                },
+                "progress": {
+                    "$ref": "#/definitions/doc_datas.ChecklistProgressResponse"
+                },
+                "recurrence": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO,TH"
+                },
+                "remind_at": {
+                    "type": "string",
+                    "example": "2022-01-19T09:00:00Z"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "school",
+                        "urgent"
+                    ]
+                },
                 "title": {
                     "type": "string",
                     "example": "Make Delicious Dinner"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2022-01-19T15:30:00Z"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "error_utils.ErrorCode": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "not_found"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "The requested resource does not exist or is not visible to the caller."
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Resource not found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "/problems/not_found"
+                }
+            }
+        },
+        "error_utils.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
                 }
             }
         },
@@ -409,10 +4684,149 @@ var doc = `{
                 "message": {
                     "type": "string"
                 },
+                "request_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "error_utils.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "not_found"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "no record found"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/error_utils.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/todo/42"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "3f2a9c1e8b7d4a6f9e0c1b2a3d4e5f60"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Resource not found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "/problems/not_found"
+                }
+            }
+        },
+        "error_utils.ValidationErrData": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/error_utils.FieldError"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
                 "status": {
                     "type": "integer"
                 }
             }
+        },
+        "health_service.Check": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "health_service.PoolStats": {
+            "type": "object",
+            "properties": {
+                "idle": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "in_use": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_open_connections": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "open_connections": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "wait_count": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "wait_duration": {
+                    "type": "string",
+                    "example": "0s"
+                }
+            }
+        },
+        "health_service.Readiness": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/health_service.Check"
+                    }
+                },
+                "latest_migration": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "migration_version": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "pool": {
+                    "$ref": "#/definitions/health_service.PoolStats"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -433,7 +4847,7 @@ var SwaggerInfo = swaggerInfo{
 	BasePath:    "",
 	Schemes:     []string{},
 	Title:       "",
-	Description: "",
+	Description: "access token from /users/login, formatted as \"Bearer {token}\"",
 }
 
 type s struct{}
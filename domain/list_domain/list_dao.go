@@ -0,0 +1,174 @@
+package list_domain
+
+import (
+	"assignment-4/db"
+	"assignment-4/utils/error_formats"
+	"assignment-4/utils/error_utils"
+	"context"
+	"database/sql"
+)
+
+const (
+	listColumns = `id, name, description, archived_at, created_at, updated_at, owner_id`
+
+	queryCreateList = `
+		INSERT INTO lists
+		(name, description, owner_id)
+		VALUES ($1, $2, $3)
+		RETURNING ` + listColumns
+	queryUpdateList = `
+		UPDATE lists
+		SET name = $3, description = $4, updated_at = NOW()
+		WHERE id = $1 AND owner_id = $2
+		RETURNING ` + listColumns
+	queryArchiveList = `
+		UPDATE lists
+		SET archived_at = CASE WHEN $3 THEN COALESCE(archived_at, NOW()) END, updated_at = NOW()
+		WHERE id = $1 AND owner_id = $2
+		RETURNING ` + listColumns
+	queryGetListById = `
+		SELECT ` + listColumns + `
+		FROM lists
+		WHERE id = $1 AND owner_id = $2
+	`
+	queryGetAllLists = `
+		SELECT ` + listColumns + `
+		FROM lists
+		WHERE owner_id = $1 AND (archived_at IS NOT NULL) = $2
+		ORDER BY id
+	`
+	queryDeleteListById = `
+		DELETE
+		FROM lists
+		WHERE id = $1 AND owner_id = $2
+	`
+)
+
+var ListDomain listDomain = &listRepo{}
+
+type listDomain interface {
+	CreateList(context.Context, *List) (*List, error_utils.MessageErr)
+	UpdateList(context.Context, *List) (*List, error_utils.MessageErr)
+	ArchiveList(context.Context, int64, int64, bool) (*List, error_utils.MessageErr)
+	GetListById(context.Context, int64, int64) (*List, error_utils.MessageErr)
+	GetAllLists(context.Context, *ListQuery) ([]List, error_utils.MessageErr)
+	DeleteListById(context.Context, int64, int64) error_utils.MessageErr
+}
+
+type listRepo struct{}
+
+func (m *listRepo) CreateList(ctx context.Context, listReq *List) (*List, error_utils.MessageErr) {
+	db := db.Conn(ctx)
+
+	row := db.QueryRowContext(ctx, queryCreateList, listReq.Name, listReq.Description, listReq.OwnerId)
+
+	var list List
+	err := scanList(row, &list)
+
+	if err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+
+	return &list, nil
+}
+
+func (m *listRepo) UpdateList(ctx context.Context, listReq *List) (*List, error_utils.MessageErr) {
+	db := db.Conn(ctx)
+
+	row := db.QueryRowContext(ctx, queryUpdateList, listReq.Id, listReq.OwnerId, listReq.Name, listReq.Description)
+
+	var list List
+	err := scanList(row, &list)
+
+	if err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+
+	return &list, nil
+}
+
+func (m *listRepo) ArchiveList(ctx context.Context, listId int64, ownerId int64, archived bool) (*List, error_utils.MessageErr) {
+	db := db.Conn(ctx)
+
+	row := db.QueryRowContext(ctx, queryArchiveList, listId, ownerId, archived)
+
+	var list List
+	err := scanList(row, &list)
+
+	if err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+
+	return &list, nil
+}
+
+func (m *listRepo) GetListById(ctx context.Context, listId int64, ownerId int64) (*List, error_utils.MessageErr) {
+	db := db.Conn(ctx)
+
+	row := db.QueryRowContext(ctx, queryGetListById, listId, ownerId)
+
+	var list List
+	err := scanList(row, &list)
+
+	if err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+
+	return &list, nil
+}
+
+func (m *listRepo) GetAllLists(ctx context.Context, query *ListQuery) ([]List, error_utils.MessageErr) {
+	db := db.Conn(ctx)
+
+	rows, err := db.QueryContext(ctx, queryGetAllLists, query.OwnerId, query.Archived)
+	if err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+	defer rows.Close()
+
+	lists := []List{}
+
+	for rows.Next() {
+		var list List
+		if err := scanList(rows, &list); err != nil {
+			return nil, error_formats.ParseError(err)
+		}
+		lists = append(lists, list)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+
+	return lists, nil
+}
+
+// DeleteListById deletes the list. Its todos stay, without a list, as the
+// foreign key on todos.list_id is ON DELETE SET NULL.
+func (m *listRepo) DeleteListById(ctx context.Context, listId int64, ownerId int64) error_utils.MessageErr {
+	db := db.Conn(ctx)
+
+	res, err := db.ExecContext(ctx, queryDeleteListById, listId, ownerId)
+	if err != nil {
+		return error_formats.ParseError(err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return error_formats.ParseError(err)
+	}
+
+	if count == 0 {
+		return error_formats.ParseError(sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanList(row rowScanner, list *List) error {
+	return row.Scan(&list.Id, &list.Name, &list.Description, &list.ArchivedAt, &list.CreatedAt, &list.UpdatedAt, &list.OwnerId)
+}
@@ -0,0 +1,103 @@
+package list_domain
+
+import (
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/validation_utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaxMoveTodos caps how many todos one move request may name.
+const MaxMoveTodos = 100
+
+// List groups todos of one owner. TodoCount and CompletedCount cover the
+// todos in the list that are not in the trash.
+type List struct {
+	Id             int64      `json:"id" readonly:"true"`
+	Name           string     `json:"name" valid:"required~name is required,maxstringlength(100)~name must be at most 100 characters"`
+	Description    string     `json:"description" valid:"maxstringlength(2000)~description must be at most 2000 characters"`
+	ArchivedAt     *time.Time `json:"archived_at" readonly:"true"`
+	CreatedAt      time.Time  `json:"created_at" readonly:"true"`
+	UpdatedAt      time.Time  `json:"updated_at" readonly:"true"`
+	TodoCount      int64      `json:"todo_count" readonly:"true"`
+	CompletedCount int64      `json:"completed_count" readonly:"true"`
+	OwnerId        int64      `json:"-"`
+}
+
+type ListQuery struct {
+	OwnerId  int64
+	Archived bool
+}
+
+type ListCollection struct {
+	Lists []List `json:"data"`
+}
+
+// MoveTodosRequest names the todos to put into a list.
+type MoveTodosRequest struct {
+	TodoIds []int64 `json:"todo_ids"`
+}
+
+// NewListArchivedError is returned when todos are added to an archived list.
+func NewListArchivedError() error_utils.MessageErr {
+	return error_utils.NewConflictError("list is archived, unarchive it before adding todos")
+}
+
+func (l *List) Validate() error_utils.MessageErr {
+	l.Name = strings.TrimSpace(l.Name)
+
+	fields := validation_utils.ValidateStruct(l)
+
+	if len(fields) > 0 {
+		return error_utils.NewValidationError(fields)
+	}
+
+	return nil
+}
+
+// IsArchived reports whether the list is archived and no longer takes todos.
+func (l *List) IsArchived() bool {
+	return l.ArchivedAt != nil
+}
+
+func (r *MoveTodosRequest) Validate() error_utils.MessageErr {
+	if len(r.TodoIds) == 0 {
+		return error_utils.NewBadRequest("todo_ids must not be empty")
+	}
+
+	if len(r.TodoIds) > MaxMoveTodos {
+		return error_utils.NewBadRequest("at most " + strconv.Itoa(MaxMoveTodos) + " todos can be moved at once")
+	}
+
+	seen := map[int64]bool{}
+	unique := r.TodoIds[:0]
+
+	for _, id := range r.TodoIds {
+		if id <= 0 {
+			return error_utils.NewBadRequest("todo_ids must be positive")
+		}
+
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	r.TodoIds = unique
+
+	return nil
+}
+
+func (l *List) GetListIdParam(c *gin.Context) (int64, error_utils.MessageErr) {
+	paramId := c.Param("listId")
+	listId, err := strconv.Atoi(paramId)
+
+	if err != nil {
+		return 0, error_utils.NewBadRequest("invalid list id params")
+	}
+
+	return int64(listId), nil
+}
@@ -0,0 +1,190 @@
+package list_domain
+
+import (
+	"assignment-4/db"
+	"assignment-4/utils/error_formats"
+	"assignment-4/utils/error_utils"
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type listMemoryRepo struct {
+	mu     sync.RWMutex
+	lastId int64
+	lists  map[int64]List
+}
+
+func NewListMemoryRepo() listDomain {
+	return &listMemoryRepo{
+		lists: map[int64]List{},
+	}
+}
+
+func (m *listMemoryRepo) CreateList(ctx context.Context, listReq *List) (*List, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.nameTaken(listReq.OwnerId, listReq.Name, 0) {
+		return nil, newNameTakenError()
+	}
+
+	m.lastId++
+	now := time.Now()
+
+	list := List{
+		Id:          m.lastId,
+		Name:        listReq.Name,
+		Description: listReq.Description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		OwnerId:     listReq.OwnerId,
+	}
+	m.lists[list.Id] = list
+
+	return &list, nil
+}
+
+func (m *listMemoryRepo) UpdateList(ctx context.Context, listReq *List) (*List, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	list, ok := m.lists[listReq.Id]
+	if !ok || list.OwnerId != listReq.OwnerId {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	if m.nameTaken(listReq.OwnerId, listReq.Name, list.Id) {
+		return nil, newNameTakenError()
+	}
+
+	list.Name = listReq.Name
+	list.Description = listReq.Description
+	list.UpdatedAt = time.Now()
+	m.lists[list.Id] = list
+
+	return &list, nil
+}
+
+func (m *listMemoryRepo) ArchiveList(ctx context.Context, listId int64, ownerId int64, archived bool) (*List, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	list, ok := m.lists[listId]
+	if !ok || list.OwnerId != ownerId {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	now := time.Now()
+
+	if !archived {
+		list.ArchivedAt = nil
+	} else if list.ArchivedAt == nil {
+		list.ArchivedAt = &now
+	}
+	list.UpdatedAt = now
+	m.lists[list.Id] = list
+
+	return &list, nil
+}
+
+func (m *listMemoryRepo) GetListById(ctx context.Context, listId int64, ownerId int64) (*List, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	list, ok := m.lists[listId]
+	if !ok || list.OwnerId != ownerId {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	return &list, nil
+}
+
+func (m *listMemoryRepo) GetAllLists(ctx context.Context, query *ListQuery) ([]List, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	lists := []List{}
+
+	for _, list := range m.lists {
+		if list.OwnerId == query.OwnerId && list.IsArchived() == query.Archived {
+			lists = append(lists, list)
+		}
+	}
+
+	sort.Slice(lists, func(i, j int) bool {
+		return lists[i].Id < lists[j].Id
+	})
+
+	return lists, nil
+}
+
+func (m *listMemoryRepo) DeleteListById(ctx context.Context, listId int64, ownerId int64) error_utils.MessageErr {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	list, ok := m.lists[listId]
+	if !ok || list.OwnerId != ownerId {
+		return error_utils.NewNotFoundError("no record found")
+	}
+
+	db.OnRollback(ctx, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		m.lists[list.Id] = list
+	})
+
+	delete(m.lists, listId)
+
+	return nil
+}
+
+// nameTaken mirrors the lists_owner_id_name_key constraint. The caller holds
+// m.mu.
+func (m *listMemoryRepo) nameTaken(ownerId int64, name string, exceptId int64) bool {
+	for _, list := range m.lists {
+		if list.OwnerId == ownerId && list.Name == name && list.Id != exceptId {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newNameTakenError() error_utils.MessageErr {
+	return error_utils.NewConflictError("a list with this name already exists")
+}
+
+func checkContext(ctx context.Context) error_utils.MessageErr {
+	if err := ctx.Err(); err != nil {
+		return error_formats.ParseError(err)
+	}
+
+	return nil
+}
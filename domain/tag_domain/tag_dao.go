@@ -0,0 +1,175 @@
+package tag_domain
+
+import (
+	"assignment-4/db"
+	"assignment-4/utils/error_formats"
+	"assignment-4/utils/error_utils"
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+const (
+	tagColumns = `id, name, color, created_at, updated_at, owner_id`
+
+	queryCreateTag = `
+		INSERT INTO tags
+		(name, color, owner_id)
+		VALUES ($1, $2, $3)
+		RETURNING ` + tagColumns
+	queryEnsureTags = `
+		INSERT INTO tags
+		(name, color, owner_id)
+		SELECT UNNEST($2::text[]), $3, $1
+		ON CONFLICT (owner_id, name) DO NOTHING
+	`
+	queryUpdateTag = `
+		UPDATE tags
+		SET name = $3, color = $4, updated_at = NOW()
+		WHERE id = $1 AND owner_id = $2
+		RETURNING ` + tagColumns
+	queryGetTagById = `
+		SELECT ` + tagColumns + `
+		FROM tags
+		WHERE id = $1 AND owner_id = $2
+	`
+	queryGetAllTags = `
+		SELECT ` + tagColumns + `
+		FROM tags
+		WHERE owner_id = $1
+		ORDER BY name COLLATE "C"
+	`
+	queryDeleteTagById = `
+		DELETE
+		FROM tags
+		WHERE id = $1 AND owner_id = $2
+	`
+)
+
+var TagDomain tagDomain = &tagRepo{}
+
+type tagDomain interface {
+	CreateTag(context.Context, *Tag) (*Tag, error_utils.MessageErr)
+	EnsureTags(context.Context, int64, []string) error_utils.MessageErr
+	UpdateTag(context.Context, *Tag) (*Tag, error_utils.MessageErr)
+	GetTagById(context.Context, int64, int64) (*Tag, error_utils.MessageErr)
+	GetAllTags(context.Context, int64) ([]Tag, error_utils.MessageErr)
+	DeleteTagById(context.Context, int64, int64) error_utils.MessageErr
+}
+
+type tagRepo struct{}
+
+func (m *tagRepo) CreateTag(ctx context.Context, tagReq *Tag) (*Tag, error_utils.MessageErr) {
+	db := db.Conn(ctx)
+
+	row := db.QueryRowContext(ctx, queryCreateTag, tagReq.Name, tagReq.Color, tagReq.OwnerId)
+
+	var tag Tag
+	err := scanTag(row, &tag)
+
+	if err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+
+	return &tag, nil
+}
+
+// EnsureTags creates the tags of ownerId named in names that do not exist
+// yet, with the default color. Inside a todo transaction the new tags are
+// rolled back with it.
+func (m *tagRepo) EnsureTags(ctx context.Context, ownerId int64, names []string) error_utils.MessageErr {
+	db := db.Conn(ctx)
+
+	_, err := db.ExecContext(ctx, queryEnsureTags, ownerId, pq.Array(names), DefaultColor)
+
+	if err != nil {
+		return error_formats.ParseError(err)
+	}
+
+	return nil
+}
+
+func (m *tagRepo) UpdateTag(ctx context.Context, tagReq *Tag) (*Tag, error_utils.MessageErr) {
+	db := db.Conn(ctx)
+
+	row := db.QueryRowContext(ctx, queryUpdateTag, tagReq.Id, tagReq.OwnerId, tagReq.Name, tagReq.Color)
+
+	var tag Tag
+	err := scanTag(row, &tag)
+
+	if err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+
+	return &tag, nil
+}
+
+func (m *tagRepo) GetTagById(ctx context.Context, tagId int64, ownerId int64) (*Tag, error_utils.MessageErr) {
+	db := db.Conn(ctx)
+
+	row := db.QueryRowContext(ctx, queryGetTagById, tagId, ownerId)
+
+	var tag Tag
+	err := scanTag(row, &tag)
+
+	if err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+
+	return &tag, nil
+}
+
+func (m *tagRepo) GetAllTags(ctx context.Context, ownerId int64) ([]Tag, error_utils.MessageErr) {
+	db := db.Conn(ctx)
+
+	rows, err := db.QueryContext(ctx, queryGetAllTags, ownerId)
+	if err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+	defer rows.Close()
+
+	tags := []Tag{}
+
+	for rows.Next() {
+		var tag Tag
+		if err := scanTag(rows, &tag); err != nil {
+			return nil, error_formats.ParseError(err)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+
+	return tags, nil
+}
+
+func (m *tagRepo) DeleteTagById(ctx context.Context, tagId int64, ownerId int64) error_utils.MessageErr {
+	db := db.Conn(ctx)
+
+	res, err := db.ExecContext(ctx, queryDeleteTagById, tagId, ownerId)
+	if err != nil {
+		return error_formats.ParseError(err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return error_formats.ParseError(err)
+	}
+
+	if count == 0 {
+		return error_formats.ParseError(sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTag(row rowScanner, tag *Tag) error {
+	return row.Scan(&tag.Id, &tag.Name, &tag.Color, &tag.CreatedAt, &tag.UpdatedAt, &tag.OwnerId)
+}
@@ -0,0 +1,69 @@
+package tag_domain
+
+import (
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/validation_utils"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultColor is given to tags created without a color, including the ones
+// created on the fly when a todo names a tag that does not exist yet.
+const DefaultColor = "#808080"
+
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Tag labels todos of one owner. TodoCount is the number of todos carrying
+// it that are not in the trash.
+type Tag struct {
+	Id        int64     `json:"id" readonly:"true"`
+	Name      string    `json:"name" valid:"required~name is required,maxstringlength(50)~name must be at most 50 characters"`
+	Color     string    `json:"color"`
+	TodoCount int64     `json:"todo_count" readonly:"true"`
+	CreatedAt time.Time `json:"created_at" readonly:"true"`
+	UpdatedAt time.Time `json:"updated_at" readonly:"true"`
+	OwnerId   int64     `json:"-"`
+}
+
+type TagCollection struct {
+	Tags []Tag `json:"data"`
+}
+
+func (t *Tag) Validate() error_utils.MessageErr {
+	t.Name = strings.TrimSpace(t.Name)
+
+	if t.Color == "" {
+		t.Color = DefaultColor
+	}
+
+	fields := validation_utils.ValidateStruct(t)
+
+	if !colorPattern.MatchString(t.Color) {
+		fields = append(fields, error_utils.FieldError{
+			Field:   "color",
+			Rule:    "hexcolor",
+			Message: "color must be a hex color such as #1e90ff",
+		})
+	}
+
+	if len(fields) > 0 {
+		return error_utils.NewValidationError(fields)
+	}
+
+	return nil
+}
+
+func (t *Tag) GetTagIdParam(c *gin.Context) (int64, error_utils.MessageErr) {
+	paramId := c.Param("tagId")
+	tagId, err := strconv.Atoi(paramId)
+
+	if err != nil {
+		return 0, error_utils.NewBadRequest("invalid tag id params")
+	}
+
+	return int64(tagId), nil
+}
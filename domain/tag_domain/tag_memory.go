@@ -0,0 +1,203 @@
+package tag_domain
+
+import (
+	"assignment-4/db"
+	"assignment-4/utils/error_formats"
+	"assignment-4/utils/error_utils"
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type tagMemoryRepo struct {
+	mu     sync.RWMutex
+	lastId int64
+	tags   map[int64]Tag
+}
+
+func NewTagMemoryRepo() tagDomain {
+	return &tagMemoryRepo{
+		tags: map[int64]Tag{},
+	}
+}
+
+func (m *tagMemoryRepo) CreateTag(ctx context.Context, tagReq *Tag) (*Tag, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.byName(tagReq.OwnerId, tagReq.Name); ok {
+		return nil, newNameTakenError()
+	}
+
+	tag := m.insert(tagReq.OwnerId, tagReq.Name, tagReq.Color)
+
+	return &tag, nil
+}
+
+func (m *tagMemoryRepo) EnsureTags(ctx context.Context, ownerId int64, names []string) error_utils.MessageErr {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, name := range names {
+		if _, ok := m.byName(ownerId, name); !ok {
+			tag := m.insert(ownerId, name, DefaultColor)
+
+			db.OnRollback(ctx, func() {
+				m.mu.Lock()
+				defer m.mu.Unlock()
+
+				delete(m.tags, tag.Id)
+			})
+		}
+	}
+
+	return nil
+}
+
+func (m *tagMemoryRepo) UpdateTag(ctx context.Context, tagReq *Tag) (*Tag, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tag, ok := m.tags[tagReq.Id]
+	if !ok || tag.OwnerId != tagReq.OwnerId {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	if other, ok := m.byName(tagReq.OwnerId, tagReq.Name); ok && other.Id != tag.Id {
+		return nil, newNameTakenError()
+	}
+
+	m.restoreOnRollback(ctx, tag)
+
+	tag.Name = tagReq.Name
+	tag.Color = tagReq.Color
+	tag.UpdatedAt = time.Now()
+	m.tags[tag.Id] = tag
+
+	return &tag, nil
+}
+
+func (m *tagMemoryRepo) GetTagById(ctx context.Context, tagId int64, ownerId int64) (*Tag, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tag, ok := m.tags[tagId]
+	if !ok || tag.OwnerId != ownerId {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	return &tag, nil
+}
+
+func (m *tagMemoryRepo) GetAllTags(ctx context.Context, ownerId int64) ([]Tag, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tags := []Tag{}
+
+	for _, tag := range m.tags {
+		if tag.OwnerId == ownerId {
+			tags = append(tags, tag)
+		}
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Name < tags[j].Name
+	})
+
+	return tags, nil
+}
+
+func (m *tagMemoryRepo) DeleteTagById(ctx context.Context, tagId int64, ownerId int64) error_utils.MessageErr {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tag, ok := m.tags[tagId]
+	if !ok || tag.OwnerId != ownerId {
+		return error_utils.NewNotFoundError("no record found")
+	}
+
+	m.restoreOnRollback(ctx, tag)
+
+	delete(m.tags, tagId)
+
+	return nil
+}
+
+// restoreOnRollback puts tag back as it is now if the transaction ctx
+// belongs to is rolled back.
+func (m *tagMemoryRepo) restoreOnRollback(ctx context.Context, tag Tag) {
+	db.OnRollback(ctx, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		m.tags[tag.Id] = tag
+	})
+}
+
+// byName mirrors the tags_owner_id_name_key constraint. The caller holds
+// m.mu.
+func (m *tagMemoryRepo) byName(ownerId int64, name string) (Tag, bool) {
+	for _, tag := range m.tags {
+		if tag.OwnerId == ownerId && tag.Name == name {
+			return tag, true
+		}
+	}
+
+	return Tag{}, false
+}
+
+// insert stores a new tag. The caller holds m.mu for writing.
+func (m *tagMemoryRepo) insert(ownerId int64, name string, color string) Tag {
+	m.lastId++
+	now := time.Now()
+
+	tag := Tag{
+		Id:        m.lastId,
+		Name:      name,
+		Color:     color,
+		CreatedAt: now,
+		UpdatedAt: now,
+		OwnerId:   ownerId,
+	}
+	m.tags[tag.Id] = tag
+
+	return tag
+}
+
+func newNameTakenError() error_utils.MessageErr {
+	return error_utils.NewConflictError("a tag with this name already exists")
+}
+
+func checkContext(ctx context.Context) error_utils.MessageErr {
+	if err := ctx.Err(); err != nil {
+		return error_formats.ParseError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package todo_domain
+
+import (
+	"assignment-4/utils/error_utils"
+	"strconv"
+)
+
+const (
+	MaxBatchOperations = 100
+
+	BatchModeAllOrNothing = "all_or_nothing"
+	BatchModePerItem      = "per_item"
+
+	BatchOpCreate   = "create"
+	BatchOpUpdate   = "update"
+	BatchOpDelete   = "delete"
+	BatchOpComplete = "complete"
+)
+
+// Batch is a list of operations applied in one transaction. In
+// all_or_nothing mode the first failure undoes the whole batch, in per_item
+// mode only the failed operation is undone.
+type Batch struct {
+	Mode       string           `json:"mode"`
+	Operations []BatchOperation `json:"operations"`
+}
+
+// BatchOperation is one step of a Batch. Todo is the body of create and
+// update, Id the todo every other op works on. A nonzero Version makes the
+// op conditional, like If-Match.
+type BatchOperation struct {
+	Op        string `json:"op"`
+	Id        int64  `json:"id,omitempty"`
+	Version   int64  `json:"version,omitempty"`
+	Permanent bool   `json:"permanent,omitempty"`
+	Todo      *Todo  `json:"todo,omitempty"`
+}
+
+type BatchResult struct {
+	Mode      string            `json:"mode"`
+	Committed bool              `json:"committed"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Results   []BatchItemResult `json:"results"`
+}
+
+type BatchItemResult struct {
+	Index   int                    `json:"index"`
+	Op      string                 `json:"op"`
+	Status  int                    `json:"status"`
+	Todo    *Todo                  `json:"todo,omitempty"`
+	Deleted *DeleteResult          `json:"deleted,omitempty"`
+	Error   error_utils.MessageErr `json:"error,omitempty"`
+}
+
+// Validate checks the shape of the batch. The todos themselves are validated
+// when their operation runs, so per_item mode can report them per item.
+func (b *Batch) Validate() error_utils.MessageErr {
+	if b.Mode == "" {
+		b.Mode = BatchModeAllOrNothing
+	}
+
+	if b.Mode != BatchModeAllOrNothing && b.Mode != BatchModePerItem {
+		return error_utils.NewBadRequest("mode must be " + BatchModeAllOrNothing + " or " + BatchModePerItem)
+	}
+
+	if len(b.Operations) == 0 {
+		return error_utils.NewBadRequest("operations must not be empty")
+	}
+
+	if len(b.Operations) > MaxBatchOperations {
+		return error_utils.NewBadRequest("a batch holds at most " + strconv.Itoa(MaxBatchOperations) + " operations")
+	}
+
+	var fields []error_utils.FieldError
+
+	for i, op := range b.Operations {
+		prefix := "operations[" + strconv.Itoa(i) + "]."
+
+		switch op.Op {
+		case BatchOpCreate:
+			if op.Todo == nil {
+				fields = append(fields, error_utils.FieldError{Field: prefix + "todo", Rule: "required", Message: prefix + "todo is required for create"})
+			}
+			if op.Id != 0 {
+				fields = append(fields, error_utils.FieldError{Field: prefix + "id", Rule: "forbidden", Message: prefix + "id is assigned by the server for create"})
+			}
+		case BatchOpUpdate:
+			if op.Todo == nil {
+				fields = append(fields, error_utils.FieldError{Field: prefix + "todo", Rule: "required", Message: prefix + "todo is required for update"})
+			}
+			if op.Id <= 0 {
+				fields = append(fields, error_utils.FieldError{Field: prefix + "id", Rule: "required", Message: prefix + "id is required for update"})
+			}
+		case BatchOpDelete, BatchOpComplete:
+			if op.Todo != nil {
+				fields = append(fields, error_utils.FieldError{Field: prefix + "todo", Rule: "forbidden", Message: prefix + "todo is not allowed for " + op.Op})
+			}
+			if op.Id <= 0 {
+				fields = append(fields, error_utils.FieldError{Field: prefix + "id", Rule: "required", Message: prefix + "id is required for " + op.Op})
+			}
+		default:
+			fields = append(fields, error_utils.FieldError{Field: prefix + "op", Rule: "oneof", Message: prefix + "op must be one of create, update, delete, complete"})
+		}
+
+		if op.Permanent && op.Op != BatchOpDelete {
+			fields = append(fields, error_utils.FieldError{Field: prefix + "permanent", Rule: "forbidden", Message: prefix + "permanent is only allowed for delete"})
+		}
+	}
+
+	if len(fields) > 0 {
+		return error_utils.NewValidationError(fields)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package todo_domain
+
+import (
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/validation_utils"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	MaxChecklistItems      = 100
+	MaxChecklistItemLength = 500
+)
+
+// ChecklistItem is one step of a todo. Items are ordered by Position, which
+// only grows as items are added until the checklist is reordered.
+type ChecklistItem struct {
+	Id        int64     `json:"id" readonly:"true"`
+	TodoId    int64     `json:"todo_id" readonly:"true"`
+	Text      string    `json:"text" valid:"required~text is required,maxstringlength(500)~text must be at most 500 characters"`
+	Done      bool      `json:"done"`
+	Position  int       `json:"position" readonly:"true"`
+	CreatedAt time.Time `json:"created_at" readonly:"true"`
+	UpdatedAt time.Time `json:"updated_at" readonly:"true"`
+	OwnerId   int64     `json:"-"`
+}
+
+// ChecklistProgress counts the checklist items of a todo and how many of
+// them are done.
+type ChecklistProgress struct {
+	Done  int64 `json:"done"`
+	Total int64 `json:"total"`
+}
+
+type Checklist struct {
+	Items    []ChecklistItem   `json:"data"`
+	Progress ChecklistProgress `json:"progress"`
+}
+
+// ChecklistItemPatch changes the fields of an item that are set. Toggle
+// flips Done, whatever it currently is.
+type ChecklistItemPatch struct {
+	Text   *string `json:"text"`
+	Done   *bool   `json:"done"`
+	Toggle bool    `json:"-"`
+}
+
+// ChecklistOrder lists every item of a checklist in its new order.
+type ChecklistOrder struct {
+	ItemIds []int64 `json:"item_ids"`
+}
+
+// NewChecklistFullError is returned when an item is added to a todo that
+// already has MaxChecklistItems.
+func NewChecklistFullError() error_utils.MessageErr {
+	return error_utils.NewConflictError("a todo can have at most " + strconv.Itoa(MaxChecklistItems) + " checklist items")
+}
+
+func (i *ChecklistItem) Validate() error_utils.MessageErr {
+	i.Text = strings.TrimSpace(i.Text)
+
+	fields := validation_utils.ValidateStruct(i)
+
+	if len(fields) > 0 {
+		return error_utils.NewValidationError(fields)
+	}
+
+	return nil
+}
+
+func (p *ChecklistItemPatch) Validate() error_utils.MessageErr {
+	if p.Text == nil && p.Done == nil {
+		return error_utils.NewBadRequest("text or done is required")
+	}
+
+	if p.Text == nil {
+		return nil
+	}
+
+	text := strings.TrimSpace(*p.Text)
+	p.Text = &text
+
+	if text == "" {
+		return error_utils.NewValidationError([]error_utils.FieldError{{Field: "text", Rule: "required", Message: "text is required"}})
+	}
+
+	if utf8.RuneCountInString(text) > MaxChecklistItemLength {
+		return error_utils.NewValidationError([]error_utils.FieldError{{Field: "text", Rule: "maxstringlength", Message: "text must be at most " + strconv.Itoa(MaxChecklistItemLength) + " characters"}})
+	}
+
+	return nil
+}
+
+// apply changes item as the patch describes.
+func (p *ChecklistItemPatch) apply(item *ChecklistItem) {
+	if p.Text != nil {
+		item.Text = *p.Text
+	}
+
+	if p.Toggle {
+		item.Done = !item.Done
+	} else if p.Done != nil {
+		item.Done = *p.Done
+	}
+}
+
+func (o *ChecklistOrder) Validate() error_utils.MessageErr {
+	if len(o.ItemIds) == 0 {
+		return error_utils.NewBadRequest("item_ids must not be empty")
+	}
+
+	if len(o.ItemIds) > MaxChecklistItems {
+		return error_utils.NewBadRequest("item_ids holds at most " + strconv.Itoa(MaxChecklistItems) + " items")
+	}
+
+	return nil
+}
+
+// checkOrder makes sure itemIds names every item of the checklist exactly
+// once, so reordering never loses an item.
+func checkOrder(items []ChecklistItem, itemIds []int64) error_utils.MessageErr {
+	listed := map[int64]bool{}
+
+	for _, id := range itemIds {
+		listed[id] = true
+	}
+
+	if len(listed) != len(itemIds) || len(itemIds) != len(items) {
+		return newOrderError()
+	}
+
+	for _, item := range items {
+		if !listed[item.Id] {
+			return newOrderError()
+		}
+	}
+
+	return nil
+}
+
+func newOrderError() error_utils.MessageErr {
+	return error_utils.NewValidationError([]error_utils.FieldError{
+		{Field: "item_ids", Rule: "permutation", Message: "item_ids must list every checklist item exactly once"},
+	})
+}
+
+// Progress counts the done items of a checklist.
+func Progress(items []ChecklistItem) ChecklistProgress {
+	progress := ChecklistProgress{Total: int64(len(items))}
+
+	for _, item := range items {
+		if item.Done {
+			progress.Done++
+		}
+	}
+
+	return progress
+}
+
+func (i *ChecklistItem) GetChecklistItemIdParam(c *gin.Context) (int64, error_utils.MessageErr) {
+	itemId, err := strconv.ParseInt(c.Param("itemId"), 10, 64)
+
+	if err != nil || itemId <= 0 {
+		return 0, error_utils.NewBadRequest("invalid checklist item id params")
+	}
+
+	return itemId, nil
+}
@@ -4,56 +4,315 @@ import (
 	"assignment-4/db"
 	"assignment-4/utils/error_formats"
 	"assignment-4/utils/error_utils"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
 )
 
 const (
+	todoColumns = `id, title, description, completed, priority, position, owner_id, due_at, remind_at, list_id, recurrence, occurrence, next_occurrence_id, ` + todoTags + `, ` + todoProgress + `, completed_at, created_at, updated_at, version, deleted_at`
+	todoTags    = `ARRAY(
+			SELECT tags.name FROM todo_tags JOIN tags ON tags.id = todo_tags.tag_id
+			WHERE todo_tags.todo_id = todos.id ORDER BY tags.name COLLATE "C"
+		)`
+	todoProgress = `(SELECT COUNT(*) FILTER (WHERE done) FROM checklist_items WHERE checklist_items.todo_id = todos.id),
+		(SELECT COUNT(*) FROM checklist_items WHERE checklist_items.todo_id = todos.id)`
+	checklistColumns = `id, todo_id, text, done, position, created_at, updated_at`
+
 	queryCreateTodo = `
 		INSERT INTO todos 
-		(title, description, completed) 
-		VALUES ($1, $2, $3)
-		RETURNING id, title, description, completed
-	`
+		(title, description, completed, owner_id, due_at, remind_at, list_id, recurrence, occurrence, priority, position, completed_at) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, GREATEST($9::integer, 1), $10, $11, CASE WHEN $3 THEN NOW() END)
+		RETURNING ` + todoColumns
 	queryUpdateTodo = `
 		UPDATE todos
-		SET title = $2, description = $3, completed = $4
-		WHERE id = $1
-		RETURNING id, title, description, completed
+		SET title = $3, description = $4, completed = $5, due_at = $6, remind_at = $7, list_id = $9, recurrence = $10, priority = $11,
+			completed_at = CASE WHEN $5 THEN COALESCE(completed_at, NOW()) END,
+			updated_at = NOW(), version = version + 1
+		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL AND ($8::bigint = 0 OR version = $8)
+		RETURNING ` + todoColumns
+	queryPatchTodo = `
+		UPDATE todos
+		SET %s
+		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL AND ($3::bigint = 0 OR version = $3)
+		RETURNING ` + todoColumns
+	queryLinkNextOccurrence = `
+		UPDATE todos
+		SET next_occurrence_id = $3, updated_at = NOW(), version = version + 1
+		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
+		RETURNING ` + todoColumns
+	queryLockPositions = `
+		SELECT pg_advisory_xact_lock(` + positionLockKey + `, $1)
+	`
+	queryLastPosition = `
+		SELECT COALESCE(MAX(position), '')
+		FROM todos
+		WHERE owner_id = $1
 	`
+	queryGetPosition = `
+		SELECT position
+		FROM todos
+		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
+	`
+	queryNextPosition = `
+		SELECT COALESCE(MIN(position), '')
+		FROM todos
+		WHERE owner_id = $1 AND position > $2 AND id <> $3
+	`
+	queryPreviousPosition = `
+		SELECT COALESCE(MAX(position), '')
+		FROM todos
+		WHERE owner_id = $1 AND position < $2 AND id <> $3
+	`
+	queryMoveTodo = `
+		UPDATE todos
+		SET position = $4, updated_at = NOW(), version = version + 1
+		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL AND ($3::bigint = 0 OR version = $3)
+		RETURNING ` + todoColumns
 	queryGetTodoById = `
-		SELECT id, title, description, completed 
+		SELECT ` + todoColumns + ` 
 		FROM todos
-		WHERE id = $1
+		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
 	`
 	queryGetAllTodos = `
-		SELECT id, title, description, completed 
+		SELECT ` + todoColumns + ` 
 		FROM todos
 	`
+	queryCountTodos = `
+		SELECT COUNT(*)
+		FROM todos
+	`
+	querySearchTodos = `
+		SELECT ` + todoColumns + `, ts_rank_cd(search, search_query, 32),
+			ts_headline('simple', ` + escapedTitle + `, search_query, '` + titleHeadline + `'),
+			ts_headline('simple', ` + escapedDescription + `, search_query, '` + descriptionHeadline + `')
+		` + searchTodosFrom + `
+		ORDER BY ts_rank_cd(search, search_query, 32) DESC, id
+		LIMIT $3 OFFSET $4
+	`
+	queryCountSearchTodos = `
+		SELECT COUNT(*)
+		` + searchTodosFrom
+	searchTodosFrom = `
+		FROM todos, to_tsquery('simple', $2) AS search_query
+		WHERE owner_id = $1 AND deleted_at IS NULL AND search @@ search_query
+	`
+	// escapedTitle and escapedDescription escape the text the way
+	// html.EscapeString does before ts_headline adds the marks.
+	escapedTitle        = `replace(replace(replace(replace(replace(title, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '''', '&#39;')`
+	escapedDescription  = `replace(replace(replace(replace(replace(description, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '''', '&#39;')`
+	titleHeadline       = `StartSel=` + HighlightStart + `, StopSel=` + HighlightStop + `, HighlightAll=true`
+	descriptionHeadline = `StartSel=` + HighlightStart + `, StopSel=` + HighlightStop + `, MaxWords=20, MinWords=10, ShortWord=0`
 	queryDeleteTodoById = `
+		UPDATE todos
+		SET deleted_at = NOW(), version = version + 1
+		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL AND ($3::bigint = 0 OR version = $3)
+		RETURNING deleted_at
+	`
+	queryRestoreTodoById = `
+		UPDATE todos
+		SET deleted_at = NULL, updated_at = NOW(), version = version + 1
+		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NOT NULL
+		RETURNING ` + todoColumns
+	queryPurgeTodoById = `
+		DELETE
+		FROM todos
+		WHERE id = $1 AND owner_id = $2 AND ($3::bigint = 0 OR version = $3)
+	`
+	queryPurgeTrash = `
 		DELETE
 		FROM todos
-		WHERE id = $1
+		WHERE deleted_at < $1
+	`
+	queryMoveTodos = `
+		UPDATE todos
+		SET list_id = $3, updated_at = NOW(), version = version + 1
+		WHERE owner_id = $1 AND id = ANY($2) AND deleted_at IS NULL
+	`
+	queryUnassignList = `
+		UPDATE todos
+		SET list_id = NULL, updated_at = NOW(), version = version + 1
+		WHERE owner_id = $1 AND list_id = $2
+	`
+	queryCountTodosByList = `
+		SELECT list_id, COUNT(*), COUNT(*) FILTER (WHERE completed)
+		FROM todos
+		WHERE owner_id = $1 AND list_id IS NOT NULL AND deleted_at IS NULL
+		GROUP BY list_id
+	`
+	queryClearTodoTags = `
+		DELETE
+		FROM todo_tags
+		WHERE todo_id = $1
+	`
+	queryAddTodoTags = `
+		INSERT INTO todo_tags (todo_id, tag_id)
+		SELECT $1, id
+		FROM tags
+		WHERE owner_id = $2 AND name = ANY($3)
+	`
+	queryTaggedTodos = `
+		SELECT todo_tags.todo_id
+		FROM todo_tags JOIN tags ON tags.id = todo_tags.tag_id
+		WHERE tags.owner_id = %s AND tags.name = ANY(%s)
+	`
+	queryTaggedTodosByName = `
+		SELECT todo_tags.todo_id
+		FROM todo_tags JOIN tags ON tags.id = todo_tags.tag_id
+		WHERE tags.owner_id = $1 AND tags.name = $2
+	`
+	queryTouchTaggedTodos = `
+		UPDATE todos
+		SET updated_at = NOW(), version = version + 1
+		WHERE owner_id = $1 AND id IN (` + queryTaggedTodosByName + `)
+	`
+	queryRemoveTag = `
+		DELETE
+		FROM todo_tags
+		USING tags
+		WHERE tags.id = todo_tags.tag_id AND tags.owner_id = $1 AND tags.name = $2
+	`
+	queryCountTodosByTag = `
+		SELECT tags.name, COUNT(*)
+		FROM tags
+		JOIN todo_tags ON todo_tags.tag_id = tags.id
+		JOIN todos ON todos.id = todo_tags.todo_id
+		WHERE tags.owner_id = $1 AND todos.deleted_at IS NULL
+		GROUP BY tags.name
+	`
+	queryGetTodoVersion = `
+		SELECT version
+		FROM todos
+		WHERE id = $1 AND owner_id = $2 AND ($3 OR deleted_at IS NULL)
+	`
+	queryTouchTodo = `
+		UPDATE todos
+		SET updated_at = NOW(), version = version + 1
+		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
+		RETURNING id
+	`
+	queryGetChecklist = `
+		SELECT ` + checklistColumns + `
+		FROM checklist_items
+		WHERE todo_id = $1
+		ORDER BY position, id
+	`
+	queryCountChecklistItems = `
+		SELECT COUNT(*)
+		FROM checklist_items
+		WHERE todo_id = $1
+	`
+	queryAddChecklistItem = `
+		INSERT INTO checklist_items (todo_id, text, done, position)
+		SELECT $1, $2, $3, COALESCE(MAX(position) + 1, 0)
+		FROM checklist_items
+		WHERE todo_id = $1
+		RETURNING ` + checklistColumns
+	queryUpdateChecklistItem = `
+		UPDATE checklist_items
+		SET text = COALESCE($3, text), done = CASE WHEN $5 THEN NOT done ELSE COALESCE($4, done) END, updated_at = NOW()
+		WHERE id = $2 AND todo_id = $1
+		RETURNING ` + checklistColumns
+	queryDeleteChecklistItem = `
+		DELETE
+		FROM checklist_items
+		WHERE id = $2 AND todo_id = $1
+	`
+	queryReorderChecklist = `
+		UPDATE checklist_items
+		SET position = ordered.position - 1, updated_at = NOW()
+		FROM UNNEST($2::integer[]) WITH ORDINALITY AS ordered (id, position)
+		WHERE checklist_items.id = ordered.id AND checklist_items.todo_id = $1
+	`
+	queryCompleteChecklist = `
+		UPDATE checklist_items
+		SET done = TRUE, updated_at = NOW()
+		WHERE todo_id = $1 AND NOT done AND todo_id IN (SELECT id FROM todos WHERE id = $1 AND owner_id = $2)
 	`
 )
 
+// positionLockKey is the first key of the advisory lock that serializes
+// the position writes of one owner, the second being the owner id.
+const positionLockKey = "7301"
+
 var TodoDomain todoDomain = &todoRepo{}
 
 type todoDomain interface {
-	CreateTodo(*Todo) (*Todo, error_utils.MessageErr)
-	UpdateTodo(*Todo) (*Todo, error_utils.MessageErr)
-	GetTodoById(int64) (*Todo, error_utils.MessageErr)
-	GetAllTodos() (*[]Todo, error_utils.MessageErr)
-	DeleteTodoById(int64) (*map[string]interface{}, error_utils.MessageErr)
+	CreateTodo(context.Context, *Todo) (*Todo, error_utils.MessageErr)
+	UpdateTodo(context.Context, *Todo) (*Todo, error_utils.MessageErr)
+	PatchTodo(context.Context, *Todo, []string) (*Todo, error_utils.MessageErr)
+	LinkNextOccurrence(context.Context, int64, int64, int64) (*Todo, error_utils.MessageErr)
+	MoveTodo(context.Context, *TodoMove) (*Todo, error_utils.MessageErr)
+	GetTodoById(context.Context, int64, int64) (*Todo, error_utils.MessageErr)
+	GetAllTodos(context.Context, *TodoQuery) (*TodoPage, error_utils.MessageErr)
+	SearchTodos(context.Context, *TodoSearch) (*SearchPage, error_utils.MessageErr)
+	DeleteTodoById(context.Context, int64, int64, int64) (*DeleteResult, error_utils.MessageErr)
+	RestoreTodoById(context.Context, int64, int64) (*Todo, error_utils.MessageErr)
+	PurgeTodoById(context.Context, int64, int64, int64) (*DeleteResult, error_utils.MessageErr)
+	PurgeTrash(context.Context, time.Time) (int64, error_utils.MessageErr)
+	MoveTodos(context.Context, int64, []int64, *int64) (int64, error_utils.MessageErr)
+	UnassignList(context.Context, int64, int64) error_utils.MessageErr
+	CountTodosByList(context.Context, int64) (map[int64]TodoCounts, error_utils.MessageErr)
+	CountTodosByTag(context.Context, int64) (TagCounts, error_utils.MessageErr)
+	RenameTag(context.Context, int64, string, string) error_utils.MessageErr
+	RemoveTag(context.Context, int64, string) error_utils.MessageErr
+	GetChecklist(context.Context, int64, int64) ([]ChecklistItem, error_utils.MessageErr)
+	AddChecklistItem(context.Context, *ChecklistItem) (*ChecklistItem, error_utils.MessageErr)
+	UpdateChecklistItem(context.Context, int64, int64, int64, *ChecklistItemPatch) (*ChecklistItem, error_utils.MessageErr)
+	DeleteChecklistItem(context.Context, int64, int64, int64) error_utils.MessageErr
+	ReorderChecklist(context.Context, int64, int64, []int64) ([]ChecklistItem, error_utils.MessageErr)
+	CompleteChecklist(context.Context, int64, int64) error_utils.MessageErr
+	RunInTx(context.Context, TxFunc) error_utils.MessageErr
 }
 
 type todoRepo struct{}
 
-func (m *todoRepo) CreateTodo(todoReq *Todo) (*Todo, error_utils.MessageErr) {
-	db := db.GetDB()
+// CreateTodo inserts the todo after the other todos of its owner, and tags
+// it with those of todoReq.Tags that exist in the tags table.
+func (m *todoRepo) CreateTodo(ctx context.Context, todoReq *Todo) (*Todo, error_utils.MessageErr) {
+	var todo *Todo
+
+	err := m.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+		position, err := m.lastPosition(ctx, todoReq.OwnerId)
+
+		if err != nil {
+			return err
+		}
+
+		if todo, err = m.createTodo(ctx, todoReq, position); err != nil || len(todoReq.Tags) == 0 {
+			return err
+		}
 
-	row := db.QueryRow(queryCreateTodo, todoReq.Title, todoReq.Description, todoReq.Completed)
+		return m.setTags(ctx, todo, todoReq.Tags)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+func (m *todoRepo) createTodo(ctx context.Context, todoReq *Todo, after string) (*Todo, error_utils.MessageErr) {
+	position, positionErr := positionBetween(after, "")
+	if positionErr != nil {
+		return nil, error_utils.Wrap(error_utils.NewInternalServerError("something went wrong"), positionErr)
+	}
+
+	db := conn(ctx)
+
+	ctx, span := startQuery(ctx, "queryCreateTodo")
+	row := db.QueryRowContext(ctx, queryCreateTodo, todoReq.Title, todoReq.Description, todoReq.Completed, todoReq.OwnerId, todoReq.DueAt, todoReq.RemindAt, todoReq.ListId, todoReq.Recurrence, todoReq.Occurrence, PriorityLevel(todoReq.Priority), position)
 
 	var todo Todo
-	err := row.Scan(&todo.Id, &todo.Title, &todo.Description, &todo.Completed)
+	err := scanTodo(row, &todo)
+	endQuery(span, err)
 
 	if err != nil {
 		return nil, error_formats.ParseError(err)
@@ -61,12 +320,24 @@ func (m *todoRepo) CreateTodo(todoReq *Todo) (*Todo, error_utils.MessageErr) {
 	return &todo, nil
 }
 
-func (m *todoRepo) UpdateTodo(todoReq *Todo) (*Todo, error_utils.MessageErr) {
-	db := db.GetDB()
-	row := db.QueryRow(queryUpdateTodo, todoReq.Id, todoReq.Title, todoReq.Description, todoReq.Completed)
+func (m *todoRepo) UpdateTodo(ctx context.Context, todoReq *Todo) (*Todo, error_utils.MessageErr) {
+	return m.withTags(ctx, todoReq.Tags, func(ctx context.Context) (*Todo, error_utils.MessageErr) {
+		return m.updateTodo(ctx, todoReq)
+	})
+}
+
+func (m *todoRepo) updateTodo(ctx context.Context, todoReq *Todo) (*Todo, error_utils.MessageErr) {
+	db := conn(ctx)
+	ctx, span := startQuery(ctx, "queryUpdateTodo")
+	row := db.QueryRowContext(ctx, queryUpdateTodo, todoReq.Id, todoReq.OwnerId, todoReq.Title, todoReq.Description, todoReq.Completed, todoReq.DueAt, todoReq.RemindAt, todoReq.Version, todoReq.ListId, todoReq.Recurrence, PriorityLevel(todoReq.Priority))
 	//id, title, image_url, user_id
 	var todo Todo
-	err := row.Scan(&todo.Id, &todo.Title, &todo.Description, &todo.Completed)
+	err := scanTodo(row, &todo)
+	endQuery(span, err)
+
+	if errors.Is(err, sql.ErrNoRows) && todoReq.Version != 0 {
+		return nil, m.preconditionFailed(ctx, todoReq.Id, todoReq.OwnerId, false)
+	}
 
 	if err != nil {
 		return nil, error_formats.ParseError(err)
@@ -75,12 +346,57 @@ func (m *todoRepo) UpdateTodo(todoReq *Todo) (*Todo, error_utils.MessageErr) {
 	return &todo, nil
 }
 
-func (m *todoRepo) GetTodoById(todoId int64) (*Todo, error_utils.MessageErr) {
-	db := db.GetDB()
-	row := db.QueryRow(queryGetTodoById, todoId)
+func (m *todoRepo) PatchTodo(ctx context.Context, todoReq *Todo, columns []string) (*Todo, error_utils.MessageErr) {
+	for i, column := range columns {
+		if column != "tags" {
+			continue
+		}
+
+		columns = append(columns[:i:i], columns[i+1:]...)
+
+		return m.withTags(ctx, todoReq.Tags, func(ctx context.Context) (*Todo, error_utils.MessageErr) {
+			return m.patchTodo(ctx, todoReq, columns)
+		})
+	}
+
+	return m.patchTodo(ctx, todoReq, columns)
+}
+
+func (m *todoRepo) patchTodo(ctx context.Context, todoReq *Todo, columns []string) (*Todo, error_utils.MessageErr) {
+	db := conn(ctx)
+
+	set := &whereBuilder{}
+	set.arg(todoReq.Id)
+	set.arg(todoReq.OwnerId)
+	set.arg(todoReq.Version)
+
+	var assignments []string
+
+	for _, column := range columns {
+		value, ok := todoReq.columnValue(column)
+		if !ok {
+			return nil, error_utils.NewInternalServerError("something went wrong")
+		}
+		assignments = append(assignments, column+" = "+set.arg(value))
+
+		if column == "completed" {
+			assignments = append(assignments, "completed_at = CASE WHEN "+set.arg(value)+" THEN COALESCE(completed_at, NOW()) END")
+		}
+	}
+
+	assignments = append(assignments, "updated_at = NOW()", "version = version + 1")
+
+	ctx, span := startQuery(ctx, "queryPatchTodo")
+	row := db.QueryRowContext(ctx, fmt.Sprintf(queryPatchTodo, strings.Join(assignments, ", ")), set.args...)
 
 	var todo Todo
-	err := row.Scan(&todo.Id, &todo.Title, &todo.Description, &todo.Completed)
+	err := scanTodo(row, &todo)
+	endQuery(span, err)
+
+	if errors.Is(err, sql.ErrNoRows) && todoReq.Version != 0 {
+		return nil, m.preconditionFailed(ctx, todoReq.Id, todoReq.OwnerId, false)
+	}
+
 	if err != nil {
 		return nil, error_formats.ParseError(err)
 	}
@@ -88,30 +404,269 @@ func (m *todoRepo) GetTodoById(todoId int64) (*Todo, error_utils.MessageErr) {
 	return &todo, nil
 }
 
-func (m *todoRepo) GetAllTodos() (*[]Todo, error_utils.MessageErr) {
-	db := db.GetDB()
-	row, err := db.Query(queryGetAllTodos)
+// LinkNextOccurrence records nextId as the todo created when the recurring
+// todo was completed, so completing it again creates no other.
+func (m *todoRepo) LinkNextOccurrence(ctx context.Context, todoId int64, ownerId int64, nextId int64) (*Todo, error_utils.MessageErr) {
+	ctx, span := startQuery(ctx, "queryLinkNextOccurrence")
+	row := conn(ctx).QueryRowContext(ctx, queryLinkNextOccurrence, todoId, ownerId, nextId)
+
+	var todo Todo
+	err := scanTodo(row, &todo)
+	endQuery(span, err)
+
 	if err != nil {
 		return nil, error_formats.ParseError(err)
 	}
 
-	var todos []Todo
+	return &todo, nil
+}
+
+// MoveTodo gives the todo a position right before or right after the
+// anchor of move, next to which no other todo of the owner sits.
+func (m *todoRepo) MoveTodo(ctx context.Context, move *TodoMove) (*Todo, error_utils.MessageErr) {
+	var todo *Todo
+
+	err := m.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+		if err := m.lockPositions(ctx, move.OwnerId); err != nil {
+			return err
+		}
+
+		field, anchorId := move.anchor()
+
+		anchor, err := queryPosition(ctx, "queryGetPosition", queryGetPosition, anchorId, move.OwnerId)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return newAnchorNotFoundError(field)
+		}
 
-	for row.Next() {
-		var todo Todo
-		err := row.Scan(&todo.Id, &todo.Title, &todo.Description, &todo.Completed)
 		if err != nil {
-			return nil, error_formats.ParseError(err)
+			return error_formats.ParseError(err)
 		}
-		todos = append(todos, todo)
+
+		var lo, hi string
+
+		if move.After != nil {
+			lo = anchor
+			hi, err = queryPosition(ctx, "queryNextPosition", queryNextPosition, move.OwnerId, anchor, move.TodoId)
+		} else {
+			hi = anchor
+			lo, err = queryPosition(ctx, "queryPreviousPosition", queryPreviousPosition, move.OwnerId, anchor, move.TodoId)
+		}
+
+		if err != nil {
+			return error_formats.ParseError(err)
+		}
+
+		position, positionErr := positionBetween(lo, hi)
+		if positionErr != nil {
+			return error_utils.Wrap(error_utils.NewInternalServerError("something went wrong"), positionErr)
+		}
+
+		queryCtx, span := startQuery(ctx, "queryMoveTodo")
+		row := conn(ctx).QueryRowContext(queryCtx, queryMoveTodo, move.TodoId, move.OwnerId, move.Version, position)
+
+		var moved Todo
+		err = scanTodo(row, &moved)
+		endQuery(span, err)
+
+		if errors.Is(err, sql.ErrNoRows) && move.Version != 0 {
+			return m.preconditionFailed(ctx, move.TodoId, move.OwnerId, false)
+		}
+
+		if err != nil {
+			return error_formats.ParseError(err)
+		}
+
+		todo = &moved
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+func (m *todoRepo) GetTodoById(ctx context.Context, todoId int64, ownerId int64) (*Todo, error_utils.MessageErr) {
+	db := conn(ctx)
+	ctx, span := startQuery(ctx, "queryGetTodoById")
+	row := db.QueryRowContext(ctx, queryGetTodoById, todoId, ownerId)
+
+	var todo Todo
+	err := scanTodo(row, &todo)
+	endQuery(span, err)
+	if err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+
+	return &todo, nil
+}
+
+func (m *todoRepo) GetAllTodos(ctx context.Context, query *TodoQuery) (*TodoPage, error_utils.MessageErr) {
+	db := conn(ctx)
+
+	if messageErr := query.Validate(); messageErr != nil {
+		return nil, messageErr
+	}
+
+	cursor, messageErr := query.decodeCursor()
+	if messageErr != nil {
+		return nil, messageErr
+	}
+
+	filter := &whereBuilder{}
+	filter.add("owner_id = " + filter.arg(query.OwnerId))
+
+	if query.Trashed {
+		filter.add("deleted_at IS NOT NULL")
+	} else {
+		filter.add("deleted_at IS NULL")
+	}
+
+	if query.ListId != nil {
+		filter.add("list_id = " + filter.arg(*query.ListId))
+	}
+
+	if len(query.Tags) > 0 {
+		tagged := fmt.Sprintf(queryTaggedTodos, filter.arg(query.OwnerId), filter.arg(pq.Array(query.Tags)))
+		if query.TagMode == TagModeAll {
+			tagged += " GROUP BY todo_tags.todo_id HAVING COUNT(*) = " + filter.arg(len(query.Tags))
+		}
+		filter.add("id IN (" + tagged + ")")
+	}
+
+	if query.Completed != nil {
+		filter.add("completed = " + filter.arg(*query.Completed))
+	}
+
+	if query.Priority != "" {
+		filter.add("priority = " + filter.arg(PriorityLevel(query.Priority)))
+	}
+
+	if query.DueBefore != nil {
+		filter.add("due_at < " + filter.arg(*query.DueBefore))
+	}
+
+	if query.Overdue != nil {
+		if *query.Overdue {
+			filter.add("(NOT completed AND due_at < NOW())")
+		} else {
+			filter.add("(completed OR due_at IS NULL OR due_at >= NOW())")
+		}
+	}
+
+	if query.Search != "" {
+		pattern := filter.arg("%" + escapeLike(query.Search) + "%")
+		filter.add("(title ILIKE " + pattern + " OR description ILIKE " + pattern + ")")
+	}
+
+	var total int64
+	countCtx, span := startQuery(ctx, "queryCountTodos")
+	err := db.QueryRowContext(countCtx, queryCountTodos+filter.String(), filter.args...).Scan(&total)
+	endQuery(span, err)
+	if err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+
+	if cursor != nil {
+		switch query.Sort {
+		case SortByIdDesc:
+			filter.add("id < " + filter.arg(cursor.Id))
+		case SortByTitle:
+			filter.add("(title, id) > (" + filter.arg(cursor.Title) + ", " + filter.arg(cursor.Id) + ")")
+		case SortByPosition:
+			filter.add("(position, id) > (" + filter.arg(cursor.Position) + ", " + filter.arg(cursor.Id) + ")")
+		case SortByPriority:
+			priority := filter.arg(cursor.Priority)
+			filter.add("(priority < " + priority + " OR (priority = " + priority + " AND (position, id) > (" + filter.arg(cursor.Position) + ", " + filter.arg(cursor.Id) + ")))")
+		default:
+			filter.add("id > " + filter.arg(cursor.Id))
+		}
+	}
+
+	statement := queryGetAllTodos + filter.String() + todoOrderBy(query.Sort) + " LIMIT " + filter.arg(query.Limit+1)
+	if query.Offset > 0 {
+		statement += " OFFSET " + filter.arg(query.Offset)
+	}
+
+	ctx, span = startQuery(ctx, "queryGetAllTodos")
+	todos, err := queryTodos(ctx, statement, filter.args...)
+	endQuery(span, err)
+	if err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+
+	return newTodoPage(query, todos, total), nil
+}
+
+// SearchTodos matches the search against the generated search column,
+// best ranked first.
+func (m *todoRepo) SearchTodos(ctx context.Context, search *TodoSearch) (*SearchPage, error_utils.MessageErr) {
+	db := conn(ctx)
+	tsquery := search.tsquery()
+
+	var total int64
+	countCtx, span := startQuery(ctx, "queryCountSearchTodos")
+	err := db.QueryRowContext(countCtx, queryCountSearchTodos, search.OwnerId, tsquery).Scan(&total)
+	endQuery(span, err)
+	if err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+
+	ctx, span = startQuery(ctx, "querySearchTodos")
+	results, err := querySearchResults(ctx, querySearchTodos, search.OwnerId, tsquery, search.Limit, search.Offset)
+	endQuery(span, err)
+	if err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+
+	return &SearchPage{Results: results, Total: total, Limit: search.Limit, Offset: search.Offset}, nil
+}
+
+// DeleteTodoById moves a todo to the trash. It stays there, hidden from
+// every other query, until it is restored or purged.
+func (m *todoRepo) DeleteTodoById(ctx context.Context, todoId int64, ownerId int64, version int64) (*DeleteResult, error_utils.MessageErr) {
+	db := conn(ctx)
+	ctx, span := startQuery(ctx, "queryDeleteTodoById")
+	var deletedAt time.Time
+	err := db.QueryRowContext(ctx, queryDeleteTodoById, todoId, ownerId, version).Scan(&deletedAt)
+	endQuery(span, err)
+
+	if errors.Is(err, sql.ErrNoRows) && version != 0 {
+		return nil, m.preconditionFailed(ctx, todoId, ownerId, false)
+	}
+
+	if err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+
+	return &DeleteResult{Id: todoId, DeletedAt: &deletedAt}, nil
+}
+
+func (m *todoRepo) RestoreTodoById(ctx context.Context, todoId int64, ownerId int64) (*Todo, error_utils.MessageErr) {
+	db := conn(ctx)
+	ctx, span := startQuery(ctx, "queryRestoreTodoById")
+	row := db.QueryRowContext(ctx, queryRestoreTodoById, todoId, ownerId)
+
+	var todo Todo
+	err := scanTodo(row, &todo)
+	endQuery(span, err)
+
+	if err != nil {
+		return nil, error_formats.ParseError(err)
 	}
 
-	return &todos, nil
+	return &todo, nil
 }
 
-func (m *todoRepo) DeleteTodoById(todoId int64) (*map[string]interface{}, error_utils.MessageErr) {
-	db := db.GetDB()
-	res, err := db.Exec(queryDeleteTodoById, todoId)
+// PurgeTodoById deletes a todo for good, whether it is in the trash or not.
+func (m *todoRepo) PurgeTodoById(ctx context.Context, todoId int64, ownerId int64, version int64) (*DeleteResult, error_utils.MessageErr) {
+	db := conn(ctx)
+	ctx, span := startQuery(ctx, "queryPurgeTodoById")
+	res, err := db.ExecContext(ctx, queryPurgeTodoById, todoId, ownerId, version)
+	endQuery(span, err)
 	if err != nil {
 		return nil, error_formats.ParseError(err)
 	}
@@ -121,10 +676,577 @@ func (m *todoRepo) DeleteTodoById(todoId int64) (*map[string]interface{}, error_
 		return nil, error_formats.ParseError(err)
 	}
 
-	deleteResult := map[string]interface{}{
-		"StatusDelete": "Success",
-		"AffectedRow":  count,
+	if count == 0 {
+		if version != 0 {
+			return nil, m.preconditionFailed(ctx, todoId, ownerId, true)
+		}
+		return nil, error_formats.ParseError(sql.ErrNoRows)
+	}
+
+	return &DeleteResult{Id: todoId, Permanent: true}, nil
+}
+
+// PurgeTrash deletes every todo, of any owner, that was moved to the trash
+// before deletedBefore.
+func (m *todoRepo) PurgeTrash(ctx context.Context, deletedBefore time.Time) (int64, error_utils.MessageErr) {
+	db := conn(ctx)
+	ctx, span := startQuery(ctx, "queryPurgeTrash")
+	res, err := db.ExecContext(ctx, queryPurgeTrash, deletedBefore)
+	endQuery(span, err)
+	if err != nil {
+		return 0, error_formats.ParseError(err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, error_formats.ParseError(err)
+	}
+
+	return count, nil
+}
+
+// MoveTodos puts the given todos of ownerId into listId, or into no list
+// when listId is nil, and reports how many it found.
+func (m *todoRepo) MoveTodos(ctx context.Context, ownerId int64, todoIds []int64, listId *int64) (int64, error_utils.MessageErr) {
+	db := conn(ctx)
+	ctx, span := startQuery(ctx, "queryMoveTodos")
+	res, err := db.ExecContext(ctx, queryMoveTodos, ownerId, pq.Array(todoIds), listId)
+	endQuery(span, err)
+	if err != nil {
+		return 0, error_formats.ParseError(err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, error_formats.ParseError(err)
+	}
+
+	return count, nil
+}
+
+// UnassignList takes every todo of ownerId, trashed ones included, out of
+// listId before the list is deleted.
+func (m *todoRepo) UnassignList(ctx context.Context, ownerId int64, listId int64) error_utils.MessageErr {
+	db := conn(ctx)
+	ctx, span := startQuery(ctx, "queryUnassignList")
+	_, err := db.ExecContext(ctx, queryUnassignList, ownerId, listId)
+	endQuery(span, err)
+	if err != nil {
+		return error_formats.ParseError(err)
 	}
 
-	return &deleteResult, nil
+	return nil
+}
+
+func (m *todoRepo) CountTodosByList(ctx context.Context, ownerId int64) (map[int64]TodoCounts, error_utils.MessageErr) {
+	db := conn(ctx)
+	ctx, span := startQuery(ctx, "queryCountTodosByList")
+	counts, err := countTodosByList(ctx, db, ownerId)
+	endQuery(span, err)
+	if err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+
+	return counts, nil
+}
+
+func countTodosByList(ctx context.Context, db db.Queryer, ownerId int64) (map[int64]TodoCounts, error) {
+	rows, err := db.QueryContext(ctx, queryCountTodosByList, ownerId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[int64]TodoCounts{}
+
+	for rows.Next() {
+		var listId int64
+		var listCounts TodoCounts
+		if err := rows.Scan(&listId, &listCounts.Total, &listCounts.Completed); err != nil {
+			return nil, err
+		}
+		counts[listId] = listCounts
+	}
+
+	return counts, rows.Err()
+}
+
+func (m *todoRepo) CountTodosByTag(ctx context.Context, ownerId int64) (TagCounts, error_utils.MessageErr) {
+	db := conn(ctx)
+	ctx, span := startQuery(ctx, "queryCountTodosByTag")
+	counts, err := countTodosByTag(ctx, db, ownerId)
+	endQuery(span, err)
+	if err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+
+	return counts, nil
+}
+
+// RenameTag gives the todos of ownerId tagged with from a new version, as
+// they are returned with tag to from now on. The caller renames the tag in
+// the tags table first, in the same transaction, so the todos are found by
+// the new name.
+func (m *todoRepo) RenameTag(ctx context.Context, ownerId int64, from string, to string) error_utils.MessageErr {
+	db := conn(ctx)
+	ctx, span := startQuery(ctx, "queryTouchTaggedTodos")
+	_, err := db.ExecContext(ctx, queryTouchTaggedTodos, ownerId, to)
+	endQuery(span, err)
+	if err != nil {
+		return error_formats.ParseError(err)
+	}
+
+	return nil
+}
+
+// RemoveTag takes the tag off every todo of ownerId before it is deleted.
+func (m *todoRepo) RemoveTag(ctx context.Context, ownerId int64, name string) error_utils.MessageErr {
+	return m.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+		db := conn(ctx)
+
+		spanCtx, span := startQuery(ctx, "queryTouchTaggedTodos")
+		_, err := db.ExecContext(spanCtx, queryTouchTaggedTodos, ownerId, name)
+		endQuery(span, err)
+		if err != nil {
+			return error_formats.ParseError(err)
+		}
+
+		spanCtx, span = startQuery(ctx, "queryRemoveTag")
+		_, err = db.ExecContext(spanCtx, queryRemoveTag, ownerId, name)
+		endQuery(span, err)
+		if err != nil {
+			return error_formats.ParseError(err)
+		}
+
+		return nil
+	})
+}
+
+// withTags runs write and replaces the tags of the todo it wrote with tags,
+// in one transaction.
+func (m *todoRepo) withTags(ctx context.Context, tags []string, write func(context.Context) (*Todo, error_utils.MessageErr)) (*Todo, error_utils.MessageErr) {
+	var todo *Todo
+
+	err := m.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+		var err error_utils.MessageErr
+
+		if todo, err = write(ctx); err != nil {
+			return err
+		}
+
+		return m.setTags(ctx, todo, tags)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+func (m *todoRepo) setTags(ctx context.Context, todo *Todo, tags []string) error_utils.MessageErr {
+	db := conn(ctx)
+	ctx, span := startQuery(ctx, "querySetTodoTags")
+	_, err := db.ExecContext(ctx, queryClearTodoTags, todo.Id)
+	if err == nil && len(tags) > 0 {
+		_, err = db.ExecContext(ctx, queryAddTodoTags, todo.Id, todo.OwnerId, pq.Array(tags))
+	}
+	endQuery(span, err)
+	if err != nil {
+		return error_formats.ParseError(err)
+	}
+
+	todo.Tags = append([]string{}, tags...)
+
+	return nil
+}
+
+func countTodosByTag(ctx context.Context, db db.Queryer, ownerId int64) (TagCounts, error) {
+	rows, err := db.QueryContext(ctx, queryCountTodosByTag, ownerId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := TagCounts{}
+
+	for rows.Next() {
+		var name string
+		var count int64
+		if err := rows.Scan(&name, &count); err != nil {
+			return nil, err
+		}
+		counts[name] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// GetChecklist returns the checklist of a todo of ownerId that is not in
+// the trash, in order.
+func (m *todoRepo) GetChecklist(ctx context.Context, todoId int64, ownerId int64) ([]ChecklistItem, error_utils.MessageErr) {
+	if _, err := m.todoVersion(ctx, todoId, ownerId, false); err != nil {
+		return nil, err
+	}
+
+	ctx, span := startQuery(ctx, "queryGetChecklist")
+	items, err := queryChecklist(ctx, todoId)
+	endQuery(span, err)
+	if err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+
+	return items, nil
+}
+
+// AddChecklistItem appends an item to the checklist of a todo, which gets a
+// new version as its checklist is part of it.
+func (m *todoRepo) AddChecklistItem(ctx context.Context, itemReq *ChecklistItem) (*ChecklistItem, error_utils.MessageErr) {
+	var item ChecklistItem
+
+	err := m.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+		if err := m.touchTodo(ctx, itemReq.TodoId, itemReq.OwnerId); err != nil {
+			return err
+		}
+
+		db := conn(ctx)
+
+		var count int
+		spanCtx, span := startQuery(ctx, "queryCountChecklistItems")
+		err := db.QueryRowContext(spanCtx, queryCountChecklistItems, itemReq.TodoId).Scan(&count)
+		endQuery(span, err)
+		if err != nil {
+			return error_formats.ParseError(err)
+		}
+
+		if count >= MaxChecklistItems {
+			return NewChecklistFullError()
+		}
+
+		spanCtx, span = startQuery(ctx, "queryAddChecklistItem")
+		err = scanChecklistItem(db.QueryRowContext(spanCtx, queryAddChecklistItem, itemReq.TodoId, itemReq.Text, itemReq.Done), &item)
+		endQuery(span, err)
+		if err != nil {
+			return error_formats.ParseError(err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	item.OwnerId = itemReq.OwnerId
+
+	return &item, nil
+}
+
+func (m *todoRepo) UpdateChecklistItem(ctx context.Context, todoId int64, ownerId int64, itemId int64, patch *ChecklistItemPatch) (*ChecklistItem, error_utils.MessageErr) {
+	var item ChecklistItem
+
+	err := m.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+		if err := m.touchTodo(ctx, todoId, ownerId); err != nil {
+			return err
+		}
+
+		ctx, span := startQuery(ctx, "queryUpdateChecklistItem")
+		err := scanChecklistItem(conn(ctx).QueryRowContext(ctx, queryUpdateChecklistItem, todoId, itemId, patch.Text, patch.Done, patch.Toggle), &item)
+		endQuery(span, err)
+		if err != nil {
+			return error_formats.ParseError(err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	item.OwnerId = ownerId
+
+	return &item, nil
+}
+
+func (m *todoRepo) DeleteChecklistItem(ctx context.Context, todoId int64, ownerId int64, itemId int64) error_utils.MessageErr {
+	return m.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+		if err := m.touchTodo(ctx, todoId, ownerId); err != nil {
+			return err
+		}
+
+		ctx, span := startQuery(ctx, "queryDeleteChecklistItem")
+		res, err := conn(ctx).ExecContext(ctx, queryDeleteChecklistItem, todoId, itemId)
+		endQuery(span, err)
+		if err != nil {
+			return error_formats.ParseError(err)
+		}
+
+		count, err := res.RowsAffected()
+		if err != nil {
+			return error_formats.ParseError(err)
+		}
+
+		if count == 0 {
+			return error_formats.ParseError(sql.ErrNoRows)
+		}
+
+		return nil
+	})
+}
+
+// ReorderChecklist puts the items of a checklist in the order of itemIds,
+// which has to name each of them once.
+func (m *todoRepo) ReorderChecklist(ctx context.Context, todoId int64, ownerId int64, itemIds []int64) ([]ChecklistItem, error_utils.MessageErr) {
+	var items []ChecklistItem
+
+	err := m.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+		if err := m.touchTodo(ctx, todoId, ownerId); err != nil {
+			return err
+		}
+
+		spanCtx, span := startQuery(ctx, "queryGetChecklist")
+		current, err := queryChecklist(spanCtx, todoId)
+		endQuery(span, err)
+		if err != nil {
+			return error_formats.ParseError(err)
+		}
+
+		if messageErr := checkOrder(current, itemIds); messageErr != nil {
+			return messageErr
+		}
+
+		spanCtx, span = startQuery(ctx, "queryReorderChecklist")
+		_, err = conn(ctx).ExecContext(spanCtx, queryReorderChecklist, todoId, pq.Array(itemIds))
+		endQuery(span, err)
+		if err != nil {
+			return error_formats.ParseError(err)
+		}
+
+		spanCtx, span = startQuery(ctx, "queryGetChecklist")
+		items, err = queryChecklist(spanCtx, todoId)
+		endQuery(span, err)
+		if err != nil {
+			return error_formats.ParseError(err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// CompleteChecklist marks every item of a todo of ownerId done. Unlike the
+// other checklist writes it leaves the version of the todo alone, it runs
+// right after a write of the todo itself.
+func (m *todoRepo) CompleteChecklist(ctx context.Context, todoId int64, ownerId int64) error_utils.MessageErr {
+	ctx, span := startQuery(ctx, "queryCompleteChecklist")
+	_, err := conn(ctx).ExecContext(ctx, queryCompleteChecklist, todoId, ownerId)
+	endQuery(span, err)
+	if err != nil {
+		return error_formats.ParseError(err)
+	}
+
+	return nil
+}
+
+// lastPosition locks the positions of the owner until the transaction ends
+// and returns the last one, empty when the owner has no todos.
+func (m *todoRepo) lastPosition(ctx context.Context, ownerId int64) (string, error_utils.MessageErr) {
+	if err := m.lockPositions(ctx, ownerId); err != nil {
+		return "", err
+	}
+
+	position, err := queryPosition(ctx, "queryLastPosition", queryLastPosition, ownerId)
+	if err != nil {
+		return "", error_formats.ParseError(err)
+	}
+
+	return position, nil
+}
+
+func queryPosition(ctx context.Context, name string, statement string, args ...interface{}) (string, error) {
+	ctx, span := startQuery(ctx, name)
+	var position string
+	err := conn(ctx).QueryRowContext(ctx, statement, args...).Scan(&position)
+	endQuery(span, err)
+
+	return position, err
+}
+
+func (m *todoRepo) lockPositions(ctx context.Context, ownerId int64) error_utils.MessageErr {
+	ctx, span := startQuery(ctx, "queryLockPositions")
+	_, err := conn(ctx).ExecContext(ctx, queryLockPositions, ownerId)
+	endQuery(span, err)
+	if err != nil {
+		return error_formats.ParseError(err)
+	}
+
+	return nil
+}
+
+// touchTodo gives a todo of ownerId that is not in the trash a new version,
+// and locks it until the transaction ends.
+func (m *todoRepo) touchTodo(ctx context.Context, todoId int64, ownerId int64) error_utils.MessageErr {
+	ctx, span := startQuery(ctx, "queryTouchTodo")
+	err := conn(ctx).QueryRowContext(ctx, queryTouchTodo, todoId, ownerId).Scan(&todoId)
+	endQuery(span, err)
+	if err != nil {
+		return error_formats.ParseError(err)
+	}
+
+	return nil
+}
+
+func queryChecklist(ctx context.Context, todoId int64) ([]ChecklistItem, error) {
+	rows, err := conn(ctx).QueryContext(ctx, queryGetChecklist, todoId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []ChecklistItem{}
+
+	for rows.Next() {
+		var item ChecklistItem
+		if err := scanChecklistItem(rows, &item); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func scanChecklistItem(row rowScanner, item *ChecklistItem) error {
+	return row.Scan(&item.Id, &item.TodoId, &item.Text, &item.Done, &item.Position, &item.CreatedAt, &item.UpdatedAt)
+}
+
+func queryTodos(ctx context.Context, statement string, args ...interface{}) ([]Todo, error) {
+	row, err := conn(ctx).QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer row.Close()
+
+	todos := []Todo{}
+
+	for row.Next() {
+		var todo Todo
+		if err := scanTodo(row, &todo); err != nil {
+			return nil, err
+		}
+		todos = append(todos, todo)
+	}
+
+	return todos, row.Err()
+}
+
+func querySearchResults(ctx context.Context, statement string, args ...interface{}) ([]SearchResult, error) {
+	row, err := conn(ctx).QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer row.Close()
+
+	results := []SearchResult{}
+
+	for row.Next() {
+		var result SearchResult
+		if err := scanTodo(row, &result.Todo, &result.Rank, &result.Highlight.Title, &result.Highlight.Description); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, row.Err()
+}
+
+// preconditionFailed tells a version mismatch apart from a missing todo
+// after a conditional statement matched no row. Trashed todos only count as
+// existing when withTrashed is set.
+func (m *todoRepo) preconditionFailed(ctx context.Context, todoId int64, ownerId int64, withTrashed bool) error_utils.MessageErr {
+	if _, err := m.todoVersion(ctx, todoId, ownerId, withTrashed); err != nil {
+		return err
+	}
+
+	return NewVersionMismatchError()
+}
+
+func (m *todoRepo) todoVersion(ctx context.Context, todoId int64, ownerId int64, withTrashed bool) (int64, error_utils.MessageErr) {
+	ctx, span := startQuery(ctx, "queryGetTodoVersion")
+	var version int64
+	err := conn(ctx).QueryRowContext(ctx, queryGetTodoVersion, todoId, ownerId, withTrashed).Scan(&version)
+	endQuery(span, err)
+
+	if err != nil {
+		return 0, error_formats.ParseError(err)
+	}
+
+	return version, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanTodo scans the todoColumns of a row, followed by the extra columns
+// of the statement, if any.
+func scanTodo(row rowScanner, todo *Todo, extra ...interface{}) error {
+	var priority int
+
+	dest := []interface{}{
+		&todo.Id, &todo.Title, &todo.Description, &todo.Completed, &priority, &todo.Position, &todo.OwnerId,
+		&todo.DueAt, &todo.RemindAt, &todo.ListId, &todo.Recurrence, &todo.Occurrence, &todo.NextOccurrenceId, pq.Array(&todo.Tags), &todo.Progress.Done, &todo.Progress.Total, &todo.CompletedAt, &todo.CreatedAt, &todo.UpdatedAt, &todo.Version, &todo.DeletedAt,
+	}
+
+	err := row.Scan(append(dest, extra...)...)
+	todo.Priority = priorityName(priority)
+
+	return err
+}
+
+type whereBuilder struct {
+	clauses []string
+	args    []interface{}
+}
+
+func (b *whereBuilder) arg(value interface{}) string {
+	b.args = append(b.args, value)
+	return "$" + strconv.Itoa(len(b.args))
+}
+
+func (b *whereBuilder) add(clause string) {
+	b.clauses = append(b.clauses, clause)
+}
+
+func (b *whereBuilder) String() string {
+	if len(b.clauses) == 0 {
+		return ""
+	}
+
+	return " WHERE " + strings.Join(b.clauses, " AND ")
+}
+
+func todoOrderBy(sort string) string {
+	switch sort {
+	case SortByIdDesc:
+		return " ORDER BY id DESC"
+	case SortByTitle:
+		return " ORDER BY title, id"
+	case SortByPosition:
+		return " ORDER BY position, id"
+	case SortByPriority:
+		return " ORDER BY priority DESC, position, id"
+	default:
+		return " ORDER BY id"
+	}
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
 }
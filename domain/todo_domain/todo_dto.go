@@ -2,29 +2,173 @@ package todo_domain
 
 import (
 	"assignment-4/utils/error_utils"
+	"assignment-4/utils/validation_utils"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
-	"github.com/asaskevich/govalidator"
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	MaxTodoTags  = 20
+	MaxTagLength = 50
+
+	PriorityNone   = "none"
+	PriorityLow    = "low"
+	PriorityMedium = "medium"
+	PriorityHigh   = "high"
+	PriorityUrgent = "urgent"
+)
+
+// priorities are stored as their index, so todos sort by urgency.
+var priorities = []string{PriorityNone, PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent}
+
 type Todo struct {
-	Id          int64  `json:"id"`
-	Title       string `json:"title" valid:"required~title is required"`
-	Description string `json:"description" valid:"required~description is required"`
-	Completed   bool   `json:"completed"`
+	Id               int64             `json:"id" readonly:"true"`
+	Title            string            `json:"title" valid:"required~title is required,maxstringlength(255)~title must be at most 255 characters"`
+	Description      string            `json:"description" valid:"required~description is required,maxstringlength(2000)~description must be at most 2000 characters"`
+	Completed        bool              `json:"completed"`
+	Priority         string            `json:"priority"`
+	Position         string            `json:"position" readonly:"true"`
+	DueAt            *time.Time        `json:"due_at"`
+	RemindAt         *time.Time        `json:"remind_at"`
+	ListId           *int64            `json:"list_id"`
+	Tags             []string          `json:"tags"`
+	Recurrence       *string           `json:"recurrence"`
+	Occurrence       int               `json:"occurrence" readonly:"true"`
+	NextOccurrenceId *int64            `json:"next_occurrence_id" readonly:"true"`
+	Progress         ChecklistProgress `json:"progress" readonly:"true"`
+	Checklist        []ChecklistItem   `json:"checklist,omitempty" valid:"-" readonly:"true"`
+	CompletedAt      *time.Time        `json:"completed_at" readonly:"true"`
+	CreatedAt        time.Time         `json:"created_at" readonly:"true"`
+	UpdatedAt        time.Time         `json:"updated_at" readonly:"true"`
+	Version          int64             `json:"version" readonly:"true"`
+	DeletedAt        *time.Time        `json:"deleted_at,omitempty" readonly:"true"`
+	OwnerId          int64             `json:"-"`
+}
+
+// DeleteResult describes a deleted todo. Unless Permanent, the todo is in the
+// trash and can be restored until it is purged.
+type DeleteResult struct {
+	Id        int64      `json:"id" readonly:"true"`
+	Permanent bool       `json:"permanent"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" readonly:"true"`
+}
+
+// TagCounts are the todos carrying each tag of one owner, by tag name,
+// leaving out the ones in the trash.
+type TagCounts map[string]int64
+
+// TodoCounts are the todos of one list that are not in the trash.
+type TodoCounts struct {
+	Total     int64
+	Completed int64
+}
+
+// NewVersionMismatchError is returned when a conditional write expected a
+// version the todo no longer has.
+func NewVersionMismatchError() error_utils.MessageErr {
+	return error_utils.NewPreconditionFailedError("todo was modified by another request, fetch it again and retry")
 }
 
 func (t *Todo) Validate() error_utils.MessageErr {
-	_, err := govalidator.ValidateStruct(t)
+	if t.Priority == "" {
+		t.Priority = PriorityNone
+	}
 
-	if err != nil {
-		return error_utils.NewBadRequest(err.Error())
+	fields := validation_utils.ValidateStruct(t)
+
+	if priorityName(PriorityLevel(t.Priority)) != t.Priority {
+		fields = append(fields, error_utils.FieldError{
+			Field:   "priority",
+			Rule:    "in",
+			Message: "priority must be one of none, low, medium, high, urgent",
+		})
+	}
+
+	if t.RemindAt != nil && t.DueAt != nil && !t.RemindAt.Before(*t.DueAt) {
+		fields = append(fields, error_utils.FieldError{
+			Field:   "remind_at",
+			Rule:    "before_due_at",
+			Message: "remind_at must be before due_at",
+		})
+	}
+
+	fields = append(fields, t.normalizeTags()...)
+	fields = append(fields, t.normalizeRecurrence()...)
+
+	if len(fields) > 0 {
+		return error_utils.NewValidationError(fields)
 	}
 
 	return nil
 }
 
+// normalizeTags trims the tag names and sorts them without duplicates, the
+// order todos are returned with.
+func (t *Todo) normalizeTags() []error_utils.FieldError {
+	var fields []error_utils.FieldError
+
+	seen := map[string]bool{}
+	tags := []string{}
+
+	for i, tag := range t.Tags {
+		tag = strings.TrimSpace(tag)
+		field := "tags[" + strconv.Itoa(i) + "]"
+
+		if tag == "" {
+			fields = append(fields, error_utils.FieldError{Field: field, Rule: "required", Message: field + " must not be empty"})
+			continue
+		}
+
+		if utf8.RuneCountInString(tag) > MaxTagLength {
+			fields = append(fields, error_utils.FieldError{Field: field, Rule: "maxstringlength", Message: field + " must be at most " + strconv.Itoa(MaxTagLength) + " characters"})
+			continue
+		}
+
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+
+	if len(tags) > MaxTodoTags {
+		fields = append(fields, error_utils.FieldError{Field: "tags", Rule: "maxitems", Message: "a todo can have at most " + strconv.Itoa(MaxTodoTags) + " tags"})
+	}
+
+	sort.Strings(tags)
+	t.Tags = tags
+
+	return fields
+}
+
+// PriorityLevel ranks priority from 0 for none to 4 for urgent.
+func PriorityLevel(priority string) int {
+	for level, name := range priorities {
+		if name == priority {
+			return level
+		}
+	}
+
+	return 0
+}
+
+func priorityName(level int) string {
+	if level < 0 || level >= len(priorities) {
+		return PriorityNone
+	}
+
+	return priorities[level]
+}
+
+// IsOverdue reports whether the todo is still open after its due date.
+func (t *Todo) IsOverdue(now time.Time) bool {
+	return !t.Completed && t.DueAt != nil && t.DueAt.Before(now)
+}
+
 func (t *Todo) GetTodoIdParam(c *gin.Context) (int64, error_utils.MessageErr) {
 	paramId := c.Param("todoId")
 	todoId, err := strconv.Atoi(paramId)
@@ -0,0 +1,927 @@
+package todo_domain
+
+import (
+	"assignment-4/utils/error_formats"
+	"assignment-4/utils/error_utils"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type todoMemoryRepo struct {
+	mu         sync.RWMutex
+	lastId     int64
+	lastItemId int64
+	todos      map[int64]Todo
+	items      map[int64]ChecklistItem
+}
+
+func NewTodoMemoryRepo() todoDomain {
+	return &todoMemoryRepo{
+		todos: map[int64]Todo{},
+		items: map[int64]ChecklistItem{},
+	}
+}
+
+func (m *todoMemoryRepo) CreateTodo(ctx context.Context, todoReq *Todo) (*Todo, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	defer m.lock(ctx)()
+
+	position, err := positionBetween(m.lastPosition(todoReq.OwnerId), "")
+	if err != nil {
+		return nil, error_utils.Wrap(error_utils.NewInternalServerError("something went wrong"), err)
+	}
+
+	m.lastId++
+	now := time.Now()
+
+	todo := Todo{
+		Id:          m.lastId,
+		Title:       todoReq.Title,
+		Description: todoReq.Description,
+		Completed:   todoReq.Completed,
+		Priority:    priorityName(PriorityLevel(todoReq.Priority)),
+		Position:    position,
+		DueAt:       copyTime(todoReq.DueAt),
+		RemindAt:    copyTime(todoReq.RemindAt),
+		ListId:      copyId(todoReq.ListId),
+		Tags:        copyTags(todoReq.Tags),
+		Recurrence:  copyString(todoReq.Recurrence),
+		Occurrence:  todoReq.Occurrence,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Version:     1,
+		OwnerId:     todoReq.OwnerId,
+	}
+	if todo.Occurrence < 1 {
+		todo.Occurrence = 1
+	}
+	todo.setCompleted(todoReq.Completed, now)
+	m.todos[todo.Id] = todo
+
+	return &todo, nil
+}
+
+func (m *todoMemoryRepo) UpdateTodo(ctx context.Context, todoReq *Todo) (*Todo, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	defer m.lock(ctx)()
+
+	todo, ok := m.todo(todoReq.Id, todoReq.OwnerId)
+	if !ok {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	if todoReq.Version != 0 && todoReq.Version != todo.Version {
+		return nil, NewVersionMismatchError()
+	}
+
+	now := time.Now()
+
+	todo.Title = todoReq.Title
+	todo.Description = todoReq.Description
+	todo.Priority = priorityName(PriorityLevel(todoReq.Priority))
+	todo.DueAt = copyTime(todoReq.DueAt)
+	todo.RemindAt = copyTime(todoReq.RemindAt)
+	todo.ListId = copyId(todoReq.ListId)
+	todo.Tags = copyTags(todoReq.Tags)
+	todo.Recurrence = copyString(todoReq.Recurrence)
+	todo.UpdatedAt = now
+	todo.Version++
+	todo.setCompleted(todoReq.Completed, now)
+	m.todos[todo.Id] = todo
+
+	return &todo, nil
+}
+
+func (m *todoMemoryRepo) PatchTodo(ctx context.Context, todoReq *Todo, columns []string) (*Todo, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	defer m.lock(ctx)()
+
+	todo, ok := m.todo(todoReq.Id, todoReq.OwnerId)
+	if !ok {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	if todoReq.Version != 0 && todoReq.Version != todo.Version {
+		return nil, NewVersionMismatchError()
+	}
+
+	now := time.Now()
+
+	for _, column := range columns {
+		switch column {
+		case "title":
+			todo.Title = todoReq.Title
+		case "description":
+			todo.Description = todoReq.Description
+		case "completed":
+			todo.setCompleted(todoReq.Completed, now)
+		case "priority":
+			todo.Priority = priorityName(PriorityLevel(todoReq.Priority))
+		case "due_at":
+			todo.DueAt = copyTime(todoReq.DueAt)
+		case "remind_at":
+			todo.RemindAt = copyTime(todoReq.RemindAt)
+		case "list_id":
+			todo.ListId = copyId(todoReq.ListId)
+		case "tags":
+			todo.Tags = copyTags(todoReq.Tags)
+		case "recurrence":
+			todo.Recurrence = copyString(todoReq.Recurrence)
+		default:
+			return nil, error_utils.NewInternalServerError("something went wrong")
+		}
+	}
+	todo.UpdatedAt = now
+	todo.Version++
+	m.todos[todo.Id] = todo
+
+	return &todo, nil
+}
+
+func (m *todoMemoryRepo) LinkNextOccurrence(ctx context.Context, todoId int64, ownerId int64, nextId int64) (*Todo, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	defer m.lock(ctx)()
+
+	todo, ok := m.todo(todoId, ownerId)
+	if !ok {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	todo.NextOccurrenceId = &nextId
+	todo.UpdatedAt = time.Now()
+	todo.Version++
+	m.todos[todo.Id] = todo
+
+	return &todo, nil
+}
+
+func (m *todoMemoryRepo) MoveTodo(ctx context.Context, move *TodoMove) (*Todo, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	defer m.lock(ctx)()
+
+	field, anchorId := move.anchor()
+
+	anchor, ok := m.todo(anchorId, move.OwnerId)
+	if !ok {
+		return nil, newAnchorNotFoundError(field)
+	}
+
+	todo, ok := m.todo(move.TodoId, move.OwnerId)
+	if !ok {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	if move.Version != 0 && move.Version != todo.Version {
+		return nil, NewVersionMismatchError()
+	}
+
+	lo, hi := anchor.Position, ""
+	if move.Before != nil {
+		lo, hi = "", anchor.Position
+	}
+
+	for _, other := range m.todos {
+		if other.OwnerId != move.OwnerId || other.Id == todo.Id {
+			continue
+		}
+
+		if move.After != nil && other.Position > lo && (hi == "" || other.Position < hi) {
+			hi = other.Position
+		}
+
+		if move.Before != nil && other.Position < hi && other.Position > lo {
+			lo = other.Position
+		}
+	}
+
+	position, err := positionBetween(lo, hi)
+	if err != nil {
+		return nil, error_utils.Wrap(error_utils.NewInternalServerError("something went wrong"), err)
+	}
+
+	todo.Position = position
+	todo.UpdatedAt = time.Now()
+	todo.Version++
+	m.todos[todo.Id] = todo
+
+	return &todo, nil
+}
+
+func (m *todoMemoryRepo) GetTodoById(ctx context.Context, todoId int64, ownerId int64) (*Todo, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	defer m.rlock(ctx)()
+
+	todo, ok := m.todo(todoId, ownerId)
+	if !ok {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	return &todo, nil
+}
+
+func (m *todoMemoryRepo) GetAllTodos(ctx context.Context, query *TodoQuery) (*TodoPage, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	if messageErr := query.Validate(); messageErr != nil {
+		return nil, messageErr
+	}
+
+	cursor, messageErr := query.decodeCursor()
+	if messageErr != nil {
+		return nil, messageErr
+	}
+
+	defer m.rlock(ctx)()
+
+	todos := []Todo{}
+	now := time.Now()
+
+	for _, todo := range m.todos {
+		if todo.OwnerId != query.OwnerId || (todo.DeletedAt != nil) != query.Trashed {
+			continue
+		}
+
+		if query.ListId != nil && !equalId(todo.ListId, query.ListId) {
+			continue
+		}
+
+		if len(query.Tags) > 0 && !hasTags(todo.Tags, query.Tags, query.TagMode) {
+			continue
+		}
+
+		if query.Completed != nil && todo.Completed != *query.Completed {
+			continue
+		}
+
+		if query.Priority != "" && todo.Priority != query.Priority {
+			continue
+		}
+
+		if query.DueBefore != nil && (todo.DueAt == nil || !todo.DueAt.Before(*query.DueBefore)) {
+			continue
+		}
+
+		if query.Overdue != nil && todo.IsOverdue(now) != *query.Overdue {
+			continue
+		}
+
+		if query.Search != "" && !containsFold(todo.Title, query.Search) && !containsFold(todo.Description, query.Search) {
+			continue
+		}
+
+		todos = append(todos, todo)
+	}
+
+	total := int64(len(todos))
+
+	sort.Slice(todos, func(i, j int) bool {
+		return todoLess(query.Sort, &todos[i], &todos[j])
+	})
+
+	if cursor != nil {
+		after := &Todo{Id: cursor.Id, Title: cursor.Title, Position: cursor.Position, Priority: priorityName(cursor.Priority)}
+		start := sort.Search(len(todos), func(i int) bool {
+			return todoLess(query.Sort, after, &todos[i])
+		})
+		todos = todos[start:]
+	}
+
+	if query.Offset >= len(todos) {
+		todos = todos[:0]
+	} else {
+		todos = todos[query.Offset:]
+	}
+
+	if len(todos) > query.Limit+1 {
+		todos = todos[:query.Limit+1]
+	}
+
+	return newTodoPage(query, append([]Todo{}, todos...), total), nil
+}
+
+// SearchTodos tokenises titles and descriptions the way the search column
+// of the todos table does. Ranks only order the results, they do not match
+// the ones Postgres computes.
+func (m *todoMemoryRepo) SearchTodos(ctx context.Context, search *TodoSearch) (*SearchPage, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	defer m.rlock(ctx)()
+
+	results := []SearchResult{}
+
+	for _, todo := range m.todos {
+		if todo.OwnerId != search.OwnerId || todo.DeletedAt != nil {
+			continue
+		}
+
+		rank, ok := search.match(&todo)
+		if !ok {
+			continue
+		}
+
+		results = append(results, SearchResult{Todo: todo, Rank: rank, Highlight: search.highlight(&todo)})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+
+		return results[i].Id < results[j].Id
+	})
+
+	total := int64(len(results))
+
+	if search.Offset >= len(results) {
+		results = results[:0]
+	} else {
+		results = results[search.Offset:]
+	}
+
+	if len(results) > search.Limit {
+		results = results[:search.Limit]
+	}
+
+	return &SearchPage{Results: results, Total: total, Limit: search.Limit, Offset: search.Offset}, nil
+}
+
+func (m *todoMemoryRepo) DeleteTodoById(ctx context.Context, todoId int64, ownerId int64, version int64) (*DeleteResult, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	defer m.lock(ctx)()
+
+	todo, ok := m.todo(todoId, ownerId)
+	if !ok {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	if version != 0 && version != todo.Version {
+		return nil, NewVersionMismatchError()
+	}
+
+	now := time.Now()
+	todo.DeletedAt = &now
+	todo.Version++
+	m.todos[todo.Id] = todo
+
+	return &DeleteResult{Id: todo.Id, DeletedAt: copyTime(todo.DeletedAt)}, nil
+}
+
+func (m *todoMemoryRepo) RestoreTodoById(ctx context.Context, todoId int64, ownerId int64) (*Todo, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	defer m.lock(ctx)()
+
+	todo, ok := m.todos[todoId]
+	if !ok || todo.OwnerId != ownerId || todo.DeletedAt == nil {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	todo.DeletedAt = nil
+	todo.UpdatedAt = time.Now()
+	todo.Version++
+	m.todos[todo.Id] = todo
+
+	return &todo, nil
+}
+
+func (m *todoMemoryRepo) PurgeTodoById(ctx context.Context, todoId int64, ownerId int64, version int64) (*DeleteResult, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	defer m.lock(ctx)()
+
+	todo, ok := m.todos[todoId]
+	if !ok || todo.OwnerId != ownerId {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	if version != 0 && version != todo.Version {
+		return nil, NewVersionMismatchError()
+	}
+
+	m.purge(todoId)
+
+	return &DeleteResult{Id: todoId, Permanent: true}, nil
+}
+
+func (m *todoMemoryRepo) PurgeTrash(ctx context.Context, deletedBefore time.Time) (int64, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return 0, err
+	}
+
+	defer m.lock(ctx)()
+
+	var count int64
+	for id, todo := range m.todos {
+		if todo.DeletedAt != nil && todo.DeletedAt.Before(deletedBefore) {
+			m.purge(id)
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (m *todoMemoryRepo) MoveTodos(ctx context.Context, ownerId int64, todoIds []int64, listId *int64) (int64, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return 0, err
+	}
+
+	defer m.lock(ctx)()
+
+	now := time.Now()
+	moved := map[int64]bool{}
+
+	for _, id := range todoIds {
+		todo, ok := m.todo(id, ownerId)
+		if !ok || moved[id] {
+			continue
+		}
+
+		todo.ListId = copyId(listId)
+		todo.UpdatedAt = now
+		todo.Version++
+		m.todos[id] = todo
+		moved[id] = true
+	}
+
+	return int64(len(moved)), nil
+}
+
+func (m *todoMemoryRepo) UnassignList(ctx context.Context, ownerId int64, listId int64) error_utils.MessageErr {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	defer m.lock(ctx)()
+
+	now := time.Now()
+
+	for id, todo := range m.todos {
+		if todo.OwnerId != ownerId || todo.ListId == nil || *todo.ListId != listId {
+			continue
+		}
+
+		todo.ListId = nil
+		todo.UpdatedAt = now
+		todo.Version++
+		m.todos[id] = todo
+	}
+
+	return nil
+}
+
+func (m *todoMemoryRepo) CountTodosByList(ctx context.Context, ownerId int64) (map[int64]TodoCounts, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	defer m.rlock(ctx)()
+
+	counts := map[int64]TodoCounts{}
+
+	for _, todo := range m.todos {
+		if todo.OwnerId != ownerId || todo.ListId == nil || todo.DeletedAt != nil {
+			continue
+		}
+
+		listCounts := counts[*todo.ListId]
+		listCounts.Total++
+		if todo.Completed {
+			listCounts.Completed++
+		}
+		counts[*todo.ListId] = listCounts
+	}
+
+	return counts, nil
+}
+
+func (m *todoMemoryRepo) GetChecklist(ctx context.Context, todoId int64, ownerId int64) ([]ChecklistItem, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	defer m.rlock(ctx)()
+
+	if _, ok := m.todo(todoId, ownerId); !ok {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	return m.checklist(todoId), nil
+}
+
+func (m *todoMemoryRepo) AddChecklistItem(ctx context.Context, itemReq *ChecklistItem) (*ChecklistItem, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	defer m.lock(ctx)()
+
+	todo, ok := m.todo(itemReq.TodoId, itemReq.OwnerId)
+	if !ok {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	items := m.checklist(todo.Id)
+	if len(items) >= MaxChecklistItems {
+		return nil, NewChecklistFullError()
+	}
+
+	position := 0
+	if len(items) > 0 {
+		position = items[len(items)-1].Position + 1
+	}
+
+	m.lastItemId++
+	now := time.Now()
+
+	item := ChecklistItem{
+		Id:        m.lastItemId,
+		TodoId:    todo.Id,
+		Text:      itemReq.Text,
+		Done:      itemReq.Done,
+		Position:  position,
+		CreatedAt: now,
+		UpdatedAt: now,
+		OwnerId:   todo.OwnerId,
+	}
+	m.items[item.Id] = item
+	m.touch(todo, now)
+
+	return &item, nil
+}
+
+func (m *todoMemoryRepo) UpdateChecklistItem(ctx context.Context, todoId int64, ownerId int64, itemId int64, patch *ChecklistItemPatch) (*ChecklistItem, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	defer m.lock(ctx)()
+
+	todo, ok := m.todo(todoId, ownerId)
+	if !ok {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	item, ok := m.items[itemId]
+	if !ok || item.TodoId != todoId {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	now := time.Now()
+
+	patch.apply(&item)
+	item.UpdatedAt = now
+	m.items[item.Id] = item
+	m.touch(todo, now)
+
+	return &item, nil
+}
+
+func (m *todoMemoryRepo) DeleteChecklistItem(ctx context.Context, todoId int64, ownerId int64, itemId int64) error_utils.MessageErr {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	defer m.lock(ctx)()
+
+	todo, ok := m.todo(todoId, ownerId)
+	if !ok {
+		return error_utils.NewNotFoundError("no record found")
+	}
+
+	item, ok := m.items[itemId]
+	if !ok || item.TodoId != todoId {
+		return error_utils.NewNotFoundError("no record found")
+	}
+
+	delete(m.items, itemId)
+	m.touch(todo, time.Now())
+
+	return nil
+}
+
+func (m *todoMemoryRepo) ReorderChecklist(ctx context.Context, todoId int64, ownerId int64, itemIds []int64) ([]ChecklistItem, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	defer m.lock(ctx)()
+
+	todo, ok := m.todo(todoId, ownerId)
+	if !ok {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	if err := checkOrder(m.checklist(todoId), itemIds); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	for position, id := range itemIds {
+		item := m.items[id]
+		item.Position = position
+		item.UpdatedAt = now
+		m.items[id] = item
+	}
+	m.touch(todo, now)
+
+	return m.checklist(todoId), nil
+}
+
+func (m *todoMemoryRepo) CompleteChecklist(ctx context.Context, todoId int64, ownerId int64) error_utils.MessageErr {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	defer m.lock(ctx)()
+
+	todo, ok := m.todos[todoId]
+	if !ok || todo.OwnerId != ownerId {
+		return nil
+	}
+
+	now := time.Now()
+
+	for id, item := range m.items {
+		if item.TodoId == todoId && !item.Done {
+			item.Done = true
+			item.UpdatedAt = now
+			m.items[id] = item
+		}
+	}
+
+	todo.Progress = Progress(m.checklist(todoId))
+	m.todos[todoId] = todo
+
+	return nil
+}
+
+// checklist returns the items of a todo in order. The caller holds m.mu.
+func (m *todoMemoryRepo) checklist(todoId int64) []ChecklistItem {
+	items := []ChecklistItem{}
+
+	for _, item := range m.items {
+		if item.TodoId == todoId {
+			items = append(items, item)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Position != items[j].Position {
+			return items[i].Position < items[j].Position
+		}
+		return items[i].Id < items[j].Id
+	})
+
+	return items
+}
+
+// touch stores todo with a new version and the progress of its changed
+// checklist. The caller holds m.mu.
+func (m *todoMemoryRepo) touch(todo Todo, now time.Time) {
+	todo.Progress = Progress(m.checklist(todo.Id))
+	todo.UpdatedAt = now
+	todo.Version++
+	m.todos[todo.Id] = todo
+}
+
+// purge deletes a todo for good, dropping its checklist items and the links
+// of other occurrences to it as the foreign keys do in postgres. The caller
+// holds m.mu.
+func (m *todoMemoryRepo) purge(todoId int64) {
+	delete(m.todos, todoId)
+
+	for id, item := range m.items {
+		if item.TodoId == todoId {
+			delete(m.items, id)
+		}
+	}
+
+	for id, todo := range m.todos {
+		if todo.NextOccurrenceId != nil && *todo.NextOccurrenceId == todoId {
+			todo.NextOccurrenceId = nil
+			m.todos[id] = todo
+		}
+	}
+}
+
+// lastPosition returns the last position of the todos of ownerId, trashed
+// ones included. The caller holds m.mu.
+func (m *todoMemoryRepo) lastPosition(ownerId int64) string {
+	last := ""
+
+	for _, todo := range m.todos {
+		if todo.OwnerId == ownerId && todo.Position > last {
+			last = todo.Position
+		}
+	}
+
+	return last
+}
+
+// todo looks up a todo of ownerId that is not in the trash. The caller
+// holds m.mu.
+func (m *todoMemoryRepo) todo(todoId int64, ownerId int64) (Todo, bool) {
+	todo, ok := m.todos[todoId]
+	if !ok || todo.OwnerId != ownerId || todo.DeletedAt != nil {
+		return Todo{}, false
+	}
+
+	return todo, true
+}
+
+func todoLess(sort string, a, b *Todo) bool {
+	switch sort {
+	case SortByIdDesc:
+		return a.Id > b.Id
+	case SortByTitle:
+		if a.Title != b.Title {
+			return a.Title < b.Title
+		}
+		return a.Id < b.Id
+	case SortByPriority:
+		if level := PriorityLevel(a.Priority); level != PriorityLevel(b.Priority) {
+			return level > PriorityLevel(b.Priority)
+		}
+		return todoLess(SortByPosition, a, b)
+	case SortByPosition:
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		return a.Id < b.Id
+	default:
+		return a.Id < b.Id
+	}
+}
+
+func containsFold(value, substr string) bool {
+	return strings.Contains(strings.ToLower(value), strings.ToLower(substr))
+}
+
+func (t *Todo) setCompleted(completed bool, now time.Time) {
+	t.Completed = completed
+
+	if !completed {
+		t.CompletedAt = nil
+	} else if t.CompletedAt == nil {
+		t.CompletedAt = &now
+	}
+}
+
+func (m *todoMemoryRepo) CountTodosByTag(ctx context.Context, ownerId int64) (TagCounts, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	defer m.rlock(ctx)()
+
+	counts := TagCounts{}
+
+	for _, todo := range m.todos {
+		if todo.OwnerId != ownerId || todo.DeletedAt != nil {
+			continue
+		}
+
+		for _, tag := range todo.Tags {
+			counts[tag]++
+		}
+	}
+
+	return counts, nil
+}
+
+func (m *todoMemoryRepo) RenameTag(ctx context.Context, ownerId int64, from string, to string) error_utils.MessageErr {
+	return m.retag(ctx, ownerId, from, to)
+}
+
+func (m *todoMemoryRepo) RemoveTag(ctx context.Context, ownerId int64, name string) error_utils.MessageErr {
+	return m.retag(ctx, ownerId, name, "")
+}
+
+// retag replaces tag from with to on every todo of ownerId, or removes it
+// when to is empty.
+func (m *todoMemoryRepo) retag(ctx context.Context, ownerId int64, from string, to string) error_utils.MessageErr {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	defer m.lock(ctx)()
+
+	now := time.Now()
+
+	for id, todo := range m.todos {
+		if todo.OwnerId != ownerId || !hasTags(todo.Tags, []string{from}, TagModeAny) {
+			continue
+		}
+
+		tags := []string{}
+		for _, tag := range todo.Tags {
+			if tag != from {
+				tags = append(tags, tag)
+			}
+		}
+		if to != "" {
+			tags = append(tags, to)
+			sort.Strings(tags)
+		}
+
+		todo.Tags = tags
+		todo.UpdatedAt = now
+		todo.Version++
+		m.todos[id] = todo
+	}
+
+	return nil
+}
+
+// hasTags reports whether tags holds any or, for TagModeAll, all of wanted.
+func hasTags(tags []string, wanted []string, mode string) bool {
+	found := 0
+
+	for _, want := range wanted {
+		for _, tag := range tags {
+			if tag == want {
+				found++
+				break
+			}
+		}
+	}
+
+	if mode == TagModeAll {
+		return found == len(wanted)
+	}
+
+	return found > 0
+}
+
+func copyTags(tags []string) []string {
+	return append([]string{}, tags...)
+}
+
+func copyId(value *int64) *int64 {
+	if value == nil {
+		return nil
+	}
+
+	copied := *value
+	return &copied
+}
+
+func copyString(value *string) *string {
+	if value == nil {
+		return nil
+	}
+
+	copied := *value
+	return &copied
+}
+
+func copyTime(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+
+	copied := *value
+	return &copied
+}
+
+func checkContext(ctx context.Context) error_utils.MessageErr {
+	if err := ctx.Err(); err != nil {
+		return error_formats.ParseError(err)
+	}
+
+	return nil
+}
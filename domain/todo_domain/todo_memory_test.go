@@ -0,0 +1,628 @@
+package todo_domain
+
+import (
+	"assignment-4/domain/list_domain"
+	"assignment-4/domain/tag_domain"
+	"assignment-4/utils/error_utils"
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ownerId int64 = 1
+
+var ctx = context.Background()
+
+func TestTodoMemoryRepo_CreateTodo_IncreasingIds(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	first, err := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Homework", Description: "Deadline: January 19, 2022"})
+	require.Nil(t, err)
+
+	second, err := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Groceries", Description: "Eggs and milk"})
+	require.Nil(t, err)
+
+	assert.EqualValues(t, 1, first.Id)
+	assert.EqualValues(t, 2, second.Id)
+}
+
+func TestTodoMemoryRepo_UpdateTodo_Success(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	created, _ := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Homework", Description: "Deadline: January 19, 2022"})
+
+	updated, err := repo.UpdateTodo(ctx, &Todo{
+		OwnerId:     ownerId,
+		Id:          created.Id,
+		Title:       "Homework",
+		Description: "Submitted",
+		Completed:   true,
+	})
+
+	require.Nil(t, err)
+	assert.EqualValues(t, "Submitted", updated.Description)
+	assert.True(t, updated.Completed)
+
+	todo, err := repo.GetTodoById(ctx, created.Id, ownerId)
+
+	require.Nil(t, err)
+	assert.EqualValues(t, *updated, *todo)
+}
+
+func TestTodoMemoryRepo_NotFoundError(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	todo, err := repo.GetTodoById(ctx, 999, ownerId)
+
+	assert.Nil(t, todo)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusNotFound, err.Status())
+	assert.EqualValues(t, "not_found", err.Error())
+	assert.EqualValues(t, "no record found", err.Message())
+
+	todo, err = repo.UpdateTodo(ctx, &Todo{OwnerId: ownerId, Id: 999, Title: "Homework", Description: "Deadline"})
+
+	assert.Nil(t, todo)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusNotFound, err.Status())
+}
+
+func TestTodoMemoryRepo_GetAllTodos_OrderedById(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	for i := 0; i < 5; i++ {
+		repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Homework", Description: "Deadline"})
+	}
+
+	page, err := repo.GetAllTodos(ctx, &TodoQuery{OwnerId: ownerId})
+
+	require.Nil(t, err)
+	require.Len(t, page.Todos, 5)
+	assert.EqualValues(t, 5, page.Total)
+	assert.Empty(t, page.NextCursor)
+
+	for i, todo := range page.Todos {
+		assert.EqualValues(t, i+1, todo.Id)
+	}
+}
+
+func TestTodoMemoryRepo_GetAllTodos_CursorPagination(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	titles := []string{"delta", "alpha", "charlie", "bravo", "alpha"}
+	for _, title := range titles {
+		repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: title, Description: "Deadline"})
+	}
+
+	var ids []int64
+	query := &TodoQuery{OwnerId: ownerId, Limit: 2, Sort: SortByTitle}
+
+	for {
+		page, err := repo.GetAllTodos(ctx, query)
+		require.Nil(t, err)
+		assert.EqualValues(t, 5, page.Total)
+
+		for _, todo := range page.Todos {
+			ids = append(ids, todo.Id)
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	assert.EqualValues(t, []int64{2, 5, 4, 3, 1}, ids)
+}
+
+func TestTodoMemoryRepo_GetAllTodos_Filters(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Homework", Description: "Math chapter 3", Completed: true})
+	repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Groceries", Description: "Eggs and milk"})
+	repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Laundry", Description: "Before homework"})
+
+	completed := false
+	page, err := repo.GetAllTodos(ctx, &TodoQuery{OwnerId: ownerId, Search: "HOMEWORK", Completed: &completed})
+
+	require.Nil(t, err)
+	require.Len(t, page.Todos, 1)
+	assert.EqualValues(t, 1, page.Total)
+	assert.EqualValues(t, "Laundry", page.Todos[0].Title)
+
+	page, err = repo.GetAllTodos(ctx, &TodoQuery{OwnerId: ownerId, Sort: SortByIdDesc, Offset: 1, Limit: 1})
+
+	require.Nil(t, err)
+	require.Len(t, page.Todos, 1)
+	assert.EqualValues(t, 3, page.Total)
+	assert.EqualValues(t, 2, page.Todos[0].Id)
+	assert.NotEmpty(t, page.NextCursor)
+}
+
+func TestTodoMemoryRepo_DeleteTodoById(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	created, _ := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Homework", Description: "Deadline"})
+
+	res, err := repo.DeleteTodoById(ctx, created.Id, ownerId, 0)
+
+	require.Nil(t, err)
+	assert.EqualValues(t, created.Id, res.Id)
+	assert.False(t, res.Permanent)
+	assert.NotNil(t, res.DeletedAt)
+
+	res, err = repo.DeleteTodoById(ctx, created.Id, ownerId, 0)
+
+	assert.Nil(t, res)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusNotFound, err.Status())
+
+	_, err = repo.GetTodoById(ctx, created.Id, ownerId)
+
+	assert.NotNil(t, err)
+}
+
+func TestTodoMemoryRepo_Trash(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	kept, _ := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Homework", Description: "Deadline"})
+	trashed, _ := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Groceries", Description: "Eggs and milk"})
+
+	_, err := repo.DeleteTodoById(ctx, trashed.Id, ownerId, 0)
+	require.Nil(t, err)
+
+	page, err := repo.GetAllTodos(ctx, &TodoQuery{OwnerId: ownerId})
+	require.Nil(t, err)
+	require.Len(t, page.Todos, 1)
+	assert.EqualValues(t, kept.Id, page.Todos[0].Id)
+
+	trash, err := repo.GetAllTodos(ctx, &TodoQuery{OwnerId: ownerId, Trashed: true})
+	require.Nil(t, err)
+	require.Len(t, trash.Todos, 1)
+	assert.EqualValues(t, trashed.Id, trash.Todos[0].Id)
+	assert.NotNil(t, trash.Todos[0].DeletedAt)
+
+	_, err = repo.UpdateTodo(ctx, &Todo{OwnerId: ownerId, Id: trashed.Id, Title: "Groceries", Description: "Bread"})
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusNotFound, err.Status())
+
+	_, err = repo.RestoreTodoById(ctx, kept.Id, ownerId)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusNotFound, err.Status())
+
+	restored, err := repo.RestoreTodoById(ctx, trashed.Id, ownerId)
+	require.Nil(t, err)
+	assert.Nil(t, restored.DeletedAt)
+	assert.EqualValues(t, 3, restored.Version)
+
+	todo, err := repo.GetTodoById(ctx, trashed.Id, ownerId)
+	require.Nil(t, err)
+	assert.EqualValues(t, "Groceries", todo.Title)
+}
+
+func TestTodoMemoryRepo_PurgeTodoById(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	active, _ := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Homework", Description: "Deadline"})
+	trashed, _ := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Groceries", Description: "Eggs and milk"})
+	repo.DeleteTodoById(ctx, trashed.Id, ownerId, 0)
+
+	for _, id := range []int64{active.Id, trashed.Id} {
+		res, err := repo.PurgeTodoById(ctx, id, ownerId, 0)
+
+		require.Nil(t, err)
+		assert.True(t, res.Permanent)
+
+		_, err = repo.RestoreTodoById(ctx, id, ownerId)
+		require.NotNil(t, err)
+		assert.EqualValues(t, http.StatusNotFound, err.Status())
+	}
+
+	_, err := repo.PurgeTodoById(ctx, active.Id, ownerId, 0)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusNotFound, err.Status())
+}
+
+func TestTodoMemoryRepo_PurgeTrash(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	active, _ := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Homework", Description: "Deadline"})
+	expired, _ := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Groceries", Description: "Eggs and milk"})
+	repo.DeleteTodoById(ctx, expired.Id, ownerId, 0)
+
+	cutoff := time.Now().Add(time.Millisecond)
+	time.Sleep(2 * time.Millisecond)
+
+	recent, _ := repo.CreateTodo(ctx, &Todo{OwnerId: 2, Title: "Laundry", Description: "Whites"})
+	repo.DeleteTodoById(ctx, recent.Id, 2, 0)
+
+	purged, err := repo.PurgeTrash(ctx, cutoff)
+
+	require.Nil(t, err)
+	assert.EqualValues(t, 1, purged)
+
+	_, err = repo.GetTodoById(ctx, active.Id, ownerId)
+	assert.Nil(t, err)
+
+	trash, err := repo.GetAllTodos(ctx, &TodoQuery{OwnerId: 2, Trashed: true})
+	require.Nil(t, err)
+	require.Len(t, trash.Todos, 1)
+	assert.EqualValues(t, recent.Id, trash.Todos[0].Id)
+}
+
+func TestTodoMemoryRepo_ConcurrentAccess(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			todo, err := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Homework", Description: "Deadline"})
+			if err != nil {
+				return
+			}
+			repo.GetTodoById(ctx, todo.Id, ownerId)
+			repo.GetAllTodos(ctx, &TodoQuery{OwnerId: ownerId})
+		}()
+	}
+
+	wg.Wait()
+
+	page, err := repo.GetAllTodos(ctx, &TodoQuery{OwnerId: ownerId, Limit: MaxTodoLimit})
+
+	require.Nil(t, err)
+	assert.Len(t, page.Todos, 50)
+	assert.EqualValues(t, 50, page.Total)
+}
+
+func TestTodoMemoryRepo_PatchTodo_OnlyChangedColumns(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	created, _ := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Homework", Description: "Deadline"})
+
+	patched, err := repo.PatchTodo(ctx, &Todo{OwnerId: ownerId, Id: created.Id, Completed: true}, []string{"completed"})
+
+	require.Nil(t, err)
+	assert.True(t, patched.Completed)
+	assert.EqualValues(t, "Homework", patched.Title)
+	assert.EqualValues(t, "Deadline", patched.Description)
+}
+
+func TestTodoMemoryRepo_Version(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	created, _ := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Homework", Description: "Deadline"})
+	assert.EqualValues(t, 1, created.Version)
+
+	updated, err := repo.UpdateTodo(ctx, &Todo{OwnerId: ownerId, Id: created.Id, Title: "Homework", Description: "Submitted", Version: 1})
+	require.Nil(t, err)
+	assert.EqualValues(t, 2, updated.Version)
+
+	patched, err := repo.PatchTodo(ctx, &Todo{OwnerId: ownerId, Id: created.Id, Completed: true}, []string{"completed"})
+	require.Nil(t, err)
+	assert.EqualValues(t, 3, patched.Version)
+
+	_, err = repo.UpdateTodo(ctx, &Todo{OwnerId: ownerId, Id: created.Id, Title: "Stale", Description: "Stale", Version: 2})
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusPreconditionFailed, err.Status())
+
+	_, err = repo.PatchTodo(ctx, &Todo{OwnerId: ownerId, Id: created.Id, Title: "Stale", Version: 1}, []string{"title"})
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusPreconditionFailed, err.Status())
+
+	_, err = repo.DeleteTodoById(ctx, created.Id, ownerId, 2)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusPreconditionFailed, err.Status())
+
+	_, err = repo.DeleteTodoById(ctx, created.Id, ownerId, 3)
+	assert.Nil(t, err)
+}
+
+func TestTodoMemoryRepo_ScopedToOwner(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	created, _ := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Homework", Description: "Deadline"})
+	repo.CreateTodo(ctx, &Todo{OwnerId: 2, Title: "Groceries", Description: "Eggs and milk"})
+
+	todo, err := repo.GetTodoById(ctx, created.Id, 2)
+
+	assert.Nil(t, todo)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusNotFound, err.Status())
+
+	todo, err = repo.UpdateTodo(ctx, &Todo{OwnerId: 2, Id: created.Id, Title: "Stolen", Description: "Stolen"})
+
+	assert.Nil(t, todo)
+	assert.NotNil(t, err)
+
+	res, err := repo.DeleteTodoById(ctx, created.Id, 2, 0)
+
+	assert.Nil(t, res)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusNotFound, err.Status())
+
+	page, err := repo.GetAllTodos(ctx, &TodoQuery{OwnerId: 2})
+
+	require.Nil(t, err)
+	require.Len(t, page.Todos, 1)
+	assert.EqualValues(t, "Groceries", page.Todos[0].Title)
+}
+
+func TestTodoMemoryRepo_Timestamps(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	created, err := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Homework", Description: "Deadline"})
+
+	require.Nil(t, err)
+	assert.False(t, created.CreatedAt.IsZero())
+	assert.EqualValues(t, created.CreatedAt, created.UpdatedAt)
+	assert.Nil(t, created.CompletedAt)
+
+	completed, err := repo.PatchTodo(ctx, &Todo{OwnerId: ownerId, Id: created.Id, Completed: true}, []string{"completed"})
+
+	require.Nil(t, err)
+	require.NotNil(t, completed.CompletedAt)
+	assert.False(t, completed.UpdatedAt.Before(created.UpdatedAt))
+
+	reopened, err := repo.UpdateTodo(ctx, &Todo{OwnerId: ownerId, Id: created.Id, Title: "Homework", Description: "Deadline"})
+
+	require.Nil(t, err)
+	assert.Nil(t, reopened.CompletedAt)
+}
+
+func TestTodoMemoryRepo_GetAllTodos_DueFilters(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	yesterday := time.Now().Add(-24 * time.Hour)
+	tomorrow := time.Now().Add(24 * time.Hour)
+
+	repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Overdue", Description: "Deadline", DueAt: &yesterday})
+	repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Done", Description: "Deadline", DueAt: &yesterday, Completed: true})
+	repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Upcoming", Description: "Deadline", DueAt: &tomorrow})
+	repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Someday", Description: "Deadline"})
+
+	overdue := true
+	page, err := repo.GetAllTodos(ctx, &TodoQuery{OwnerId: ownerId, Overdue: &overdue})
+
+	require.Nil(t, err)
+	require.Len(t, page.Todos, 1)
+	assert.EqualValues(t, "Overdue", page.Todos[0].Title)
+
+	notOverdue := false
+	page, err = repo.GetAllTodos(ctx, &TodoQuery{OwnerId: ownerId, Overdue: &notOverdue})
+
+	require.Nil(t, err)
+	assert.EqualValues(t, 3, page.Total)
+
+	dueBefore := time.Now()
+	page, err = repo.GetAllTodos(ctx, &TodoQuery{OwnerId: ownerId, DueBefore: &dueBefore})
+
+	require.Nil(t, err)
+	require.Len(t, page.Todos, 2)
+	assert.EqualValues(t, "Overdue", page.Todos[0].Title)
+	assert.EqualValues(t, "Done", page.Todos[1].Title)
+}
+
+func TestTodoMemoryRepo_CanceledContext(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	todo, err := repo.CreateTodo(canceled, &Todo{OwnerId: ownerId, Title: "Homework", Description: "Deadline"})
+
+	assert.Nil(t, todo)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusGatewayTimeout, err.Status())
+	assert.EqualValues(t, "gateway_timeout", err.Error())
+}
+
+func TestTodoMemoryRepo_RunInTx(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	kept, _ := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Homework", Description: "Deadline"})
+
+	err := repo.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+		repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Groceries", Description: "Eggs and milk"})
+		repo.DeleteTodoById(ctx, kept.Id, ownerId, 0)
+
+		return error_utils.NewNotFoundError("no record found")
+	})
+
+	require.NotNil(t, err)
+
+	page, _ := repo.GetAllTodos(ctx, &TodoQuery{OwnerId: ownerId})
+	require.Len(t, page.Todos, 1)
+	assert.EqualValues(t, kept.Id, page.Todos[0].Id)
+
+	err = repo.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+		repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Groceries", Description: "Eggs and milk"})
+
+		inner := repo.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+			repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Laundry", Description: "Whites"})
+			return error_utils.NewBadRequest("invalid")
+		})
+		assert.NotNil(t, inner)
+
+		return nil
+	})
+
+	require.Nil(t, err)
+
+	page, _ = repo.GetAllTodos(ctx, &TodoQuery{OwnerId: ownerId})
+	require.Len(t, page.Todos, 2)
+	assert.EqualValues(t, "Groceries", page.Todos[1].Title)
+	assert.EqualValues(t, 3, page.Todos[1].Id, "ids of rolled back todos are not reused")
+}
+
+func TestTodoMemoryRepo_RunInTx_Panic(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	assert.Panics(t, func() {
+		repo.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+			repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Groceries", Description: "Eggs and milk"})
+			panic("boom")
+		})
+	})
+
+	// the lock is released and the partial work undone
+	page, err := repo.GetAllTodos(ctx, &TodoQuery{OwnerId: ownerId})
+
+	require.Nil(t, err)
+	assert.Empty(t, page.Todos)
+}
+
+func TestTodoMemoryRepo_RunInTx_RollsBackTagsAndLists(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+	tags := tag_domain.NewTagMemoryRepo()
+	lists := list_domain.NewListMemoryRepo()
+
+	list, _ := lists.CreateList(ctx, &list_domain.List{OwnerId: ownerId, Name: "School"})
+
+	school, _ := tags.CreateTag(ctx, &tag_domain.Tag{OwnerId: ownerId, Name: "school", Color: tag_domain.DefaultColor})
+	urgent, _ := tags.CreateTag(ctx, &tag_domain.Tag{OwnerId: ownerId, Name: "urgent", Color: tag_domain.DefaultColor})
+
+	err := repo.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+		tags.UpdateTag(ctx, &tag_domain.Tag{Id: school.Id, OwnerId: ownerId, Name: "class", Color: "#1e90ff"})
+		tags.DeleteTagById(ctx, urgent.Id, ownerId)
+		tags.EnsureTags(ctx, ownerId, []string{"errands"})
+		lists.DeleteListById(ctx, list.Id, ownerId)
+
+		return error_utils.NewInternalServerError("something went wrong")
+	})
+
+	require.NotNil(t, err)
+
+	restored, err := lists.GetListById(ctx, list.Id, ownerId)
+	require.Nil(t, err)
+	assert.EqualValues(t, *list, *restored)
+
+	all, _ := tags.GetAllTags(ctx, ownerId)
+	require.Len(t, all, 2)
+	assert.EqualValues(t, *school, all[0])
+	assert.EqualValues(t, *urgent, all[1])
+}
+
+func TestTodoMemoryRepo_Lists(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	listId := int64(7)
+	first, _ := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Homework", Description: "Math", ListId: &listId})
+	second, _ := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Essay", Description: "History", Completed: true})
+	third, _ := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Laundry", Description: "Whites"})
+	repo.DeleteTodoById(ctx, third.Id, ownerId, 0)
+
+	moved, err := repo.MoveTodos(ctx, ownerId, []int64{second.Id, third.Id, 99}, &listId)
+	require.Nil(t, err)
+	assert.EqualValues(t, 1, moved, "trashed and unknown todos are not moved")
+
+	counts, err := repo.CountTodosByList(ctx, ownerId)
+	require.Nil(t, err)
+	assert.EqualValues(t, map[int64]TodoCounts{listId: {Total: 2, Completed: 1}}, counts)
+
+	page, _ := repo.GetAllTodos(ctx, &TodoQuery{OwnerId: ownerId, ListId: &listId})
+	require.Len(t, page.Todos, 2)
+
+	err = repo.UnassignList(ctx, ownerId, listId)
+	require.Nil(t, err)
+
+	todo, _ := repo.GetTodoById(ctx, first.Id, ownerId)
+	assert.Nil(t, todo.ListId)
+	assert.EqualValues(t, 2, todo.Version)
+
+	counts, _ = repo.CountTodosByList(ctx, ownerId)
+	assert.Empty(t, counts)
+}
+
+func TestTodoMemoryRepo_Tags(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Homework", Description: "Math", Tags: []string{"school", "urgent"}})
+	repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Essay", Description: "History", Tags: []string{"school"}})
+	repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Groceries", Description: "Milk"})
+	repo.CreateTodo(ctx, &Todo{OwnerId: 2, Title: "Laundry", Description: "Whites", Tags: []string{"school"}})
+
+	titles := func(query *TodoQuery) []string {
+		query.OwnerId = ownerId
+		page, err := repo.GetAllTodos(ctx, query)
+		require.Nil(t, err)
+
+		titles := []string{}
+		for _, todo := range page.Todos {
+			titles = append(titles, todo.Title)
+		}
+		return titles
+	}
+
+	assert.EqualValues(t, []string{"Homework", "Essay"}, titles(&TodoQuery{Tags: []string{"school", "urgent"}}))
+	assert.EqualValues(t, []string{"Homework"}, titles(&TodoQuery{Tags: []string{"school", "urgent", "school"}, TagMode: TagModeAll}))
+	assert.EqualValues(t, []string{}, titles(&TodoQuery{Tags: []string{"home"}}))
+
+	counts, err := repo.CountTodosByTag(ctx, ownerId)
+	require.Nil(t, err)
+	assert.EqualValues(t, TagCounts{"school": 2, "urgent": 1}, counts)
+
+	require.Nil(t, repo.RenameTag(ctx, ownerId, "urgent", "asap"))
+	require.Nil(t, repo.RemoveTag(ctx, ownerId, "school"))
+
+	todo, _ := repo.GetTodoById(ctx, 1, ownerId)
+	assert.EqualValues(t, []string{"asap"}, todo.Tags)
+	assert.EqualValues(t, 3, todo.Version)
+
+	other, _ := repo.GetTodoById(ctx, 4, 2)
+	assert.EqualValues(t, []string{"school"}, other.Tags, "tags of other owners are left alone")
+}
+
+func TestTodoMemoryRepo_Checklist(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	todo, _ := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Dinner", Description: "Fried rice"})
+
+	eggs, err := repo.AddChecklistItem(ctx, &ChecklistItem{TodoId: todo.Id, OwnerId: ownerId, Text: "Buy eggs"})
+	require.Nil(t, err)
+	rice, _ := repo.AddChecklistItem(ctx, &ChecklistItem{TodoId: todo.Id, OwnerId: ownerId, Text: "Cook rice", Done: true})
+	assert.EqualValues(t, 0, eggs.Position)
+	assert.EqualValues(t, 1, rice.Position)
+
+	_, err = repo.AddChecklistItem(ctx, &ChecklistItem{TodoId: todo.Id, OwnerId: 2, Text: "Buy eggs"})
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusNotFound, err.Status())
+
+	toggled, err := repo.UpdateChecklistItem(ctx, todo.Id, ownerId, eggs.Id, &ChecklistItemPatch{Toggle: true})
+	require.Nil(t, err)
+	assert.True(t, toggled.Done)
+
+	current, _ := repo.GetTodoById(ctx, todo.Id, ownerId)
+	assert.EqualValues(t, ChecklistProgress{Done: 2, Total: 2}, current.Progress)
+	assert.EqualValues(t, 4, current.Version, "every checklist write bumps the todo version")
+
+	_, err = repo.ReorderChecklist(ctx, todo.Id, ownerId, []int64{rice.Id, rice.Id})
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusBadRequest, err.Status())
+
+	items, err := repo.ReorderChecklist(ctx, todo.Id, ownerId, []int64{rice.Id, eggs.Id})
+	require.Nil(t, err)
+	require.Len(t, items, 2)
+	assert.EqualValues(t, rice.Id, items[0].Id)
+	assert.EqualValues(t, 1, items[1].Position)
+
+	require.Nil(t, repo.DeleteChecklistItem(ctx, todo.Id, ownerId, rice.Id))
+	err = repo.DeleteChecklistItem(ctx, todo.Id, ownerId, rice.Id)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusNotFound, err.Status())
+
+	repo.PurgeTodoById(ctx, todo.Id, ownerId, 0)
+	assert.Empty(t, repo.(*todoMemoryRepo).items, "items are deleted with their todo")
+
+	_, err = repo.GetChecklist(ctx, todo.Id, ownerId)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusNotFound, err.Status())
+}
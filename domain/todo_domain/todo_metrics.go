@@ -0,0 +1,237 @@
+package todo_domain
+
+import (
+	"assignment-4/metrics"
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/logger_utils"
+	"context"
+	"time"
+)
+
+const outcomeOk = "ok"
+
+// todoMetrics times and debug logs every call of the todoDomain it wraps,
+// whichever repository that is.
+type todoMetrics struct {
+	next todoDomain
+}
+
+func WithMetrics(next todoDomain) todoDomain {
+	return &todoMetrics{next: next}
+}
+
+func observe(ctx context.Context, operation string, start time.Time, err error_utils.MessageErr) {
+	outcome := outcomeOk
+	if err != nil {
+		outcome = err.Error()
+	}
+
+	elapsed := time.Since(start)
+	metrics.QueryDuration.WithLabelValues(operation, outcome).Observe(elapsed.Seconds())
+
+	logger_utils.Ctx(ctx).Debug().
+		Str("operation", operation).
+		Str("outcome", outcome).
+		Dur("duration", elapsed).
+		Msg("todo repository call")
+}
+
+func (m *todoMetrics) CreateTodo(ctx context.Context, todo *Todo) (*Todo, error_utils.MessageErr) {
+	start := time.Now()
+	res, err := m.next.CreateTodo(ctx, todo)
+	observe(ctx, "CreateTodo", start, err)
+
+	return res, err
+}
+
+func (m *todoMetrics) UpdateTodo(ctx context.Context, todo *Todo) (*Todo, error_utils.MessageErr) {
+	start := time.Now()
+	res, err := m.next.UpdateTodo(ctx, todo)
+	observe(ctx, "UpdateTodo", start, err)
+
+	return res, err
+}
+
+func (m *todoMetrics) PatchTodo(ctx context.Context, todo *Todo, columns []string) (*Todo, error_utils.MessageErr) {
+	start := time.Now()
+	res, err := m.next.PatchTodo(ctx, todo, columns)
+	observe(ctx, "PatchTodo", start, err)
+
+	return res, err
+}
+
+func (m *todoMetrics) LinkNextOccurrence(ctx context.Context, todoId int64, ownerId int64, nextId int64) (*Todo, error_utils.MessageErr) {
+	start := time.Now()
+	res, err := m.next.LinkNextOccurrence(ctx, todoId, ownerId, nextId)
+	observe(ctx, "LinkNextOccurrence", start, err)
+
+	return res, err
+}
+
+func (m *todoMetrics) MoveTodo(ctx context.Context, move *TodoMove) (*Todo, error_utils.MessageErr) {
+	start := time.Now()
+	res, err := m.next.MoveTodo(ctx, move)
+	observe(ctx, "MoveTodo", start, err)
+
+	return res, err
+}
+
+func (m *todoMetrics) GetTodoById(ctx context.Context, todoId int64, ownerId int64) (*Todo, error_utils.MessageErr) {
+	start := time.Now()
+	res, err := m.next.GetTodoById(ctx, todoId, ownerId)
+	observe(ctx, "GetTodoById", start, err)
+
+	return res, err
+}
+
+func (m *todoMetrics) GetAllTodos(ctx context.Context, query *TodoQuery) (*TodoPage, error_utils.MessageErr) {
+	start := time.Now()
+	res, err := m.next.GetAllTodos(ctx, query)
+	observe(ctx, "GetAllTodos", start, err)
+
+	return res, err
+}
+
+func (m *todoMetrics) SearchTodos(ctx context.Context, search *TodoSearch) (*SearchPage, error_utils.MessageErr) {
+	start := time.Now()
+	res, err := m.next.SearchTodos(ctx, search)
+	observe(ctx, "SearchTodos", start, err)
+
+	return res, err
+}
+
+func (m *todoMetrics) DeleteTodoById(ctx context.Context, todoId int64, ownerId int64, version int64) (*DeleteResult, error_utils.MessageErr) {
+	start := time.Now()
+	res, err := m.next.DeleteTodoById(ctx, todoId, ownerId, version)
+	observe(ctx, "DeleteTodoById", start, err)
+
+	return res, err
+}
+
+func (m *todoMetrics) RestoreTodoById(ctx context.Context, todoId int64, ownerId int64) (*Todo, error_utils.MessageErr) {
+	start := time.Now()
+	res, err := m.next.RestoreTodoById(ctx, todoId, ownerId)
+	observe(ctx, "RestoreTodoById", start, err)
+
+	return res, err
+}
+
+func (m *todoMetrics) PurgeTodoById(ctx context.Context, todoId int64, ownerId int64, version int64) (*DeleteResult, error_utils.MessageErr) {
+	start := time.Now()
+	res, err := m.next.PurgeTodoById(ctx, todoId, ownerId, version)
+	observe(ctx, "PurgeTodoById", start, err)
+
+	return res, err
+}
+
+func (m *todoMetrics) PurgeTrash(ctx context.Context, deletedBefore time.Time) (int64, error_utils.MessageErr) {
+	start := time.Now()
+	res, err := m.next.PurgeTrash(ctx, deletedBefore)
+	observe(ctx, "PurgeTrash", start, err)
+
+	return res, err
+}
+
+func (m *todoMetrics) RunInTx(ctx context.Context, fn TxFunc) error_utils.MessageErr {
+	start := time.Now()
+	err := m.next.RunInTx(ctx, fn)
+	observe(ctx, "RunInTx", start, err)
+
+	return err
+}
+
+func (m *todoMetrics) MoveTodos(ctx context.Context, ownerId int64, todoIds []int64, listId *int64) (int64, error_utils.MessageErr) {
+	start := time.Now()
+	res, err := m.next.MoveTodos(ctx, ownerId, todoIds, listId)
+	observe(ctx, "MoveTodos", start, err)
+
+	return res, err
+}
+
+func (m *todoMetrics) UnassignList(ctx context.Context, ownerId int64, listId int64) error_utils.MessageErr {
+	start := time.Now()
+	err := m.next.UnassignList(ctx, ownerId, listId)
+	observe(ctx, "UnassignList", start, err)
+
+	return err
+}
+
+func (m *todoMetrics) CountTodosByList(ctx context.Context, ownerId int64) (map[int64]TodoCounts, error_utils.MessageErr) {
+	start := time.Now()
+	res, err := m.next.CountTodosByList(ctx, ownerId)
+	observe(ctx, "CountTodosByList", start, err)
+
+	return res, err
+}
+
+func (m *todoMetrics) CountTodosByTag(ctx context.Context, ownerId int64) (TagCounts, error_utils.MessageErr) {
+	start := time.Now()
+	res, err := m.next.CountTodosByTag(ctx, ownerId)
+	observe(ctx, "CountTodosByTag", start, err)
+
+	return res, err
+}
+
+func (m *todoMetrics) RenameTag(ctx context.Context, ownerId int64, from string, to string) error_utils.MessageErr {
+	start := time.Now()
+	err := m.next.RenameTag(ctx, ownerId, from, to)
+	observe(ctx, "RenameTag", start, err)
+
+	return err
+}
+
+func (m *todoMetrics) RemoveTag(ctx context.Context, ownerId int64, name string) error_utils.MessageErr {
+	start := time.Now()
+	err := m.next.RemoveTag(ctx, ownerId, name)
+	observe(ctx, "RemoveTag", start, err)
+
+	return err
+}
+
+func (m *todoMetrics) GetChecklist(ctx context.Context, todoId int64, ownerId int64) ([]ChecklistItem, error_utils.MessageErr) {
+	start := time.Now()
+	res, err := m.next.GetChecklist(ctx, todoId, ownerId)
+	observe(ctx, "GetChecklist", start, err)
+
+	return res, err
+}
+
+func (m *todoMetrics) AddChecklistItem(ctx context.Context, item *ChecklistItem) (*ChecklistItem, error_utils.MessageErr) {
+	start := time.Now()
+	res, err := m.next.AddChecklistItem(ctx, item)
+	observe(ctx, "AddChecklistItem", start, err)
+
+	return res, err
+}
+
+func (m *todoMetrics) UpdateChecklistItem(ctx context.Context, todoId int64, ownerId int64, itemId int64, patch *ChecklistItemPatch) (*ChecklistItem, error_utils.MessageErr) {
+	start := time.Now()
+	res, err := m.next.UpdateChecklistItem(ctx, todoId, ownerId, itemId, patch)
+	observe(ctx, "UpdateChecklistItem", start, err)
+
+	return res, err
+}
+
+func (m *todoMetrics) DeleteChecklistItem(ctx context.Context, todoId int64, ownerId int64, itemId int64) error_utils.MessageErr {
+	start := time.Now()
+	err := m.next.DeleteChecklistItem(ctx, todoId, ownerId, itemId)
+	observe(ctx, "DeleteChecklistItem", start, err)
+
+	return err
+}
+
+func (m *todoMetrics) ReorderChecklist(ctx context.Context, todoId int64, ownerId int64, itemIds []int64) ([]ChecklistItem, error_utils.MessageErr) {
+	start := time.Now()
+	res, err := m.next.ReorderChecklist(ctx, todoId, ownerId, itemIds)
+	observe(ctx, "ReorderChecklist", start, err)
+
+	return res, err
+}
+
+func (m *todoMetrics) CompleteChecklist(ctx context.Context, todoId int64, ownerId int64) error_utils.MessageErr {
+	start := time.Now()
+	err := m.next.CompleteChecklist(ctx, todoId, ownerId)
+	observe(ctx, "CompleteChecklist", start, err)
+
+	return err
+}
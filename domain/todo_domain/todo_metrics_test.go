@@ -0,0 +1,34 @@
+package todo_domain
+
+import (
+	"assignment-4/metrics"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func observations(t *testing.T, operation string, outcome string) uint64 {
+	var m dto.Metric
+	require.Nil(t, metrics.QueryDuration.WithLabelValues(operation, outcome).(prometheus.Histogram).Write(&m))
+
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestWithMetrics_ObservesOutcome(t *testing.T) {
+	repo := WithMetrics(NewTodoMemoryRepo())
+
+	created := observations(t, "CreateTodo", outcomeOk)
+	notFound := observations(t, "GetTodoById", "not_found")
+
+	todo, err := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Homework", Description: "Deadline"})
+	require.Nil(t, err)
+
+	_, err = repo.GetTodoById(ctx, todo.Id+1, ownerId)
+	require.NotNil(t, err)
+
+	assert.EqualValues(t, created+1, observations(t, "CreateTodo", outcomeOk))
+	assert.EqualValues(t, notFound+1, observations(t, "GetTodoById", "not_found"))
+}
@@ -0,0 +1,198 @@
+package todo_domain
+
+import (
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/validation_utils"
+	"bytes"
+	"encoding/json"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+const (
+	MergePatchContentType = "application/merge-patch+json"
+	JSONPatchContentType  = "application/json-patch+json"
+)
+
+// ApplyPatch returns a copy of t with an RFC 7396 merge patch or an RFC 6902
+// JSON patch applied. Plain application/json bodies are treated as merge patches.
+// Fields owned by the server are left out of the document the patch applies
+// to, so a patch setting them is rejected like a PUT body would be.
+func (t *Todo) ApplyPatch(patch []byte, contentType string) (*Todo, error_utils.MessageErr) {
+	original, err := t.patchDocument()
+	if err != nil {
+		return nil, error_utils.NewInternalServerError("something went wrong")
+	}
+
+	var patched []byte
+
+	switch contentType {
+	case MergePatchContentType, "application/json":
+		if !json.Valid(patch) || bytes.TrimSpace(patch)[0] != '{' {
+			return nil, error_utils.NewBadRequest("invalid merge patch body")
+		}
+
+		patched, err = jsonpatch.MergePatch(original, patch)
+		if err != nil {
+			return nil, error_utils.NewBadRequest("invalid merge patch body")
+		}
+	case JSONPatchContentType:
+		operations, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return nil, error_utils.NewBadRequest("invalid json patch body")
+		}
+
+		patched, err = operations.Apply(original)
+		if err != nil {
+			return nil, error_utils.NewUnprocessibleEntityError("json patch could not be applied: " + err.Error())
+		}
+	default:
+		return nil, error_utils.NewUnsupportedMediaTypeError("content type must be " + MergePatchContentType + " or " + JSONPatchContentType)
+	}
+
+	var todo Todo
+
+	if err := validation_utils.DecodeJSON(patched, &todo); err != nil {
+		return nil, err
+	}
+
+	todo.Id = t.Id
+	todo.OwnerId = t.OwnerId
+	todo.CompletedAt = t.CompletedAt
+	todo.CreatedAt = t.CreatedAt
+	todo.UpdatedAt = t.UpdatedAt
+	todo.Version = t.Version
+	todo.DeletedAt = t.DeletedAt
+	todo.Position = t.Position
+	todo.Occurrence = t.Occurrence
+	todo.NextOccurrenceId = t.NextOccurrenceId
+	todo.Progress = t.Progress
+	todo.Checklist = nil
+
+	return &todo, nil
+}
+
+// patchDocument is t as JSON without the fields the server owns.
+func (t *Todo) patchDocument() ([]byte, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+
+	var document map[string]json.RawMessage
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	for _, name := range validation_utils.ReadOnlyFields(t) {
+		delete(document, name)
+	}
+
+	return json.Marshal(document)
+}
+
+// ChangedColumns lists the todos columns whose value differs between t and
+// other. Tags are listed as "tags" although they live in todo_tags.
+func (t *Todo) ChangedColumns(other *Todo) []string {
+	var columns []string
+
+	if t.Title != other.Title {
+		columns = append(columns, "title")
+	}
+
+	if t.Description != other.Description {
+		columns = append(columns, "description")
+	}
+
+	if t.Completed != other.Completed {
+		columns = append(columns, "completed")
+	}
+
+	if PriorityLevel(t.Priority) != PriorityLevel(other.Priority) {
+		columns = append(columns, "priority")
+	}
+
+	if !equalTime(t.DueAt, other.DueAt) {
+		columns = append(columns, "due_at")
+	}
+
+	if !equalTime(t.RemindAt, other.RemindAt) {
+		columns = append(columns, "remind_at")
+	}
+
+	if !equalId(t.ListId, other.ListId) {
+		columns = append(columns, "list_id")
+	}
+
+	if !equalString(t.Recurrence, other.Recurrence) {
+		columns = append(columns, "recurrence")
+	}
+
+	if !equalTags(t.Tags, other.Tags) {
+		columns = append(columns, "tags")
+	}
+
+	return columns
+}
+
+func (t *Todo) columnValue(column string) (interface{}, bool) {
+	switch column {
+	case "title":
+		return t.Title, true
+	case "description":
+		return t.Description, true
+	case "completed":
+		return t.Completed, true
+	case "priority":
+		return PriorityLevel(t.Priority), true
+	case "due_at":
+		return t.DueAt, true
+	case "remind_at":
+		return t.RemindAt, true
+	case "list_id":
+		return t.ListId, true
+	case "recurrence":
+		return t.Recurrence, true
+	}
+
+	return nil, false
+}
+
+func equalTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func equalId(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func equalString(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
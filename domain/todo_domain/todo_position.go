@@ -0,0 +1,241 @@
+package todo_domain
+
+import (
+	"assignment-4/utils/error_utils"
+	"errors"
+	"strings"
+)
+
+// Positions are strings that sort byte by byte, so a todo moves between two
+// others by taking a position between theirs without touching any other
+// row. A position is an integer part, whose first character gives its
+// length, followed by an optional fraction that never ends in '0':
+// appending keeps positions short and there is always room between two.
+const positionDigits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+var smallestPositionInteger = "A" + strings.Repeat("0", 26)
+
+// TodoMove places a todo right before or right after another todo of its
+// owner.
+type TodoMove struct {
+	Before  *int64 `json:"before"`
+	After   *int64 `json:"after"`
+	TodoId  int64  `json:"-"`
+	OwnerId int64  `json:"-"`
+	Version int64  `json:"-"`
+}
+
+func (m *TodoMove) Validate() error_utils.MessageErr {
+	if (m.Before == nil) == (m.After == nil) {
+		return error_utils.NewBadRequest("exactly one of before or after is required")
+	}
+
+	field, anchorId := m.anchor()
+
+	if anchorId == m.TodoId {
+		return error_utils.NewValidationError([]error_utils.FieldError{
+			{Field: field, Rule: "not_self", Message: field + " must be another todo"},
+		})
+	}
+
+	return nil
+}
+
+// anchor returns the field naming the todo to move next to, and its id.
+func (m *TodoMove) anchor() (string, int64) {
+	if m.Before != nil {
+		return "before", *m.Before
+	}
+
+	return "after", *m.After
+}
+
+func newAnchorNotFoundError(field string) error_utils.MessageErr {
+	return error_utils.NewValidationError([]error_utils.FieldError{
+		{Field: field, Rule: "exists", Message: field + " must refer to one of your todos"},
+	})
+}
+
+// positionBetween returns a position that sorts strictly between a and b.
+// An empty a stands for the start of the list, an empty b for its end.
+func positionBetween(a, b string) (string, error) {
+	if a != "" && b != "" && a >= b {
+		return "", errors.New("position " + a + " is not before " + b)
+	}
+
+	if a == "" && b == "" {
+		return "a0", nil
+	}
+
+	if a == "" {
+		ib, err := positionInteger(b)
+		if err != nil {
+			return "", err
+		}
+
+		if ib == smallestPositionInteger {
+			return ib + positionMidpoint("", b[len(ib):]), nil
+		}
+
+		if ib < b {
+			return ib, nil
+		}
+
+		if res, ok := decrementPositionInteger(ib); ok {
+			return res, nil
+		}
+
+		return "", errors.New("no position before " + b)
+	}
+
+	ia, err := positionInteger(a)
+	if err != nil {
+		return "", err
+	}
+
+	if b == "" {
+		if res, ok := incrementPositionInteger(ia); ok {
+			return res, nil
+		}
+
+		return ia + positionMidpoint(a[len(ia):], ""), nil
+	}
+
+	ib, err := positionInteger(b)
+	if err != nil {
+		return "", err
+	}
+
+	if ia == ib {
+		return ia + positionMidpoint(a[len(ia):], b[len(ib):]), nil
+	}
+
+	if res, ok := incrementPositionInteger(ia); ok && res < b {
+		return res, nil
+	}
+
+	return ia + positionMidpoint(a[len(ia):], ""), nil
+}
+
+// positionInteger returns the integer part of a position.
+func positionInteger(position string) (string, error) {
+	length := 0
+
+	switch head := position[0]; {
+	case head >= 'a' && head <= 'z':
+		length = int(head-'a') + 2
+	case head >= 'A' && head <= 'Z':
+		length = int('Z'-head) + 2
+	}
+
+	if length == 0 || length > len(position) {
+		return "", errors.New("invalid position " + position)
+	}
+
+	return position[:length], nil
+}
+
+// positionMidpoint returns a fraction between the fractions a and b, where
+// an empty b has no upper bound.
+func positionMidpoint(a, b string) string {
+	if b != "" {
+		n := 0
+		for n < len(b) && positionDigit(a, n) == b[n] {
+			n++
+		}
+
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+
+			return b[:n] + positionMidpoint(rest, b[n:])
+		}
+	}
+
+	lo, hi := 0, len(positionDigits)
+
+	if a != "" {
+		lo = strings.IndexByte(positionDigits, a[0])
+	}
+
+	if b != "" {
+		hi = strings.IndexByte(positionDigits, b[0])
+	}
+
+	if hi-lo > 1 {
+		return string(positionDigits[(lo+hi+1)/2])
+	}
+
+	if len(b) > 1 {
+		return b[:1]
+	}
+
+	rest := ""
+	if a != "" {
+		rest = a[1:]
+	}
+
+	return string(positionDigits[lo]) + positionMidpoint(rest, "")
+}
+
+func positionDigit(fraction string, i int) byte {
+	if i < len(fraction) {
+		return fraction[i]
+	}
+
+	return '0'
+}
+
+func incrementPositionInteger(integer string) (string, bool) {
+	head, digits := integer[0], []byte(integer[1:])
+
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digit := strings.IndexByte(positionDigits, digits[i]) + 1; digit < len(positionDigits) {
+			digits[i] = positionDigits[digit]
+			return string(head) + string(digits), true
+		}
+
+		digits[i] = '0'
+	}
+
+	switch {
+	case head == 'Z':
+		return "a0", true
+	case head == 'z':
+		return "", false
+	case head+1 > 'a':
+		digits = append(digits, '0')
+	default:
+		digits = digits[:len(digits)-1]
+	}
+
+	return string(head+1) + string(digits), true
+}
+
+func decrementPositionInteger(integer string) (string, bool) {
+	head, digits := integer[0], []byte(integer[1:])
+
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digit := strings.IndexByte(positionDigits, digits[i]) - 1; digit >= 0 {
+			digits[i] = positionDigits[digit]
+			return string(head) + string(digits), true
+		}
+
+		digits[i] = 'z'
+	}
+
+	switch {
+	case head == 'a':
+		return "Zz", true
+	case head == 'A':
+		return "", false
+	case head-1 < 'Z':
+		digits = append(digits, 'z')
+	default:
+		digits = digits[:len(digits)-1]
+	}
+
+	return string(head-1) + string(digits), true
+}
@@ -0,0 +1,102 @@
+package todo_domain
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPositionBetween(t *testing.T) {
+	for _, tc := range []struct{ a, b, want string }{
+		{"", "", "a0"},
+		{"a0", "", "a1"},
+		{"", "a0", "Zz"},
+		{"a0", "a1", "a0V"},
+		{"a0V", "a1", "a0l"},
+		{"a0", "a0V", "a0G"},
+		{"az", "", "b00"},
+		{"Zz", "a0", "ZzV"},
+		{"a0", "a0G", "a08"},
+		{"a01", "a02", "a01V"},
+	} {
+		got, err := positionBetween(tc.a, tc.b)
+
+		require.Nil(t, err, tc)
+		assert.EqualValues(t, tc.want, got, tc)
+		assert.True(t, tc.a == "" || tc.a < got, tc)
+		assert.True(t, tc.b == "" || got < tc.b, tc)
+	}
+
+	_, err := positionBetween("a1", "a0")
+	assert.NotNil(t, err)
+}
+
+func TestPositionBetween_RepeatedInserts(t *testing.T) {
+	lo, hi := "a0", "a1"
+
+	// always moving into the same gap only ever grows the position by a
+	// digit at a time
+	for i := 0; i < 200; i++ {
+		mid, err := positionBetween(lo, hi)
+
+		require.Nil(t, err)
+		require.True(t, lo < mid && mid < hi, "%s < %s < %s", lo, mid, hi)
+
+		if i%2 == 0 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	first := ""
+	for i := 0; i < 100; i++ {
+		next, err := positionBetween("", first)
+
+		require.Nil(t, err)
+		require.True(t, first == "" || next < first)
+		first = next
+	}
+}
+
+func TestTodoMemoryRepo_MoveTodo(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	var ids []int64
+	for _, title := range []string{"Homework", "Groceries", "Laundry"} {
+		todo, err := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: title, Description: "Today"})
+		require.Nil(t, err)
+		ids = append(ids, todo.Id)
+	}
+
+	moved, err := repo.MoveTodo(ctx, &TodoMove{TodoId: ids[2], OwnerId: ownerId, Before: &ids[0]})
+
+	require.Nil(t, err)
+	assert.EqualValues(t, 2, moved.Version)
+
+	moved, err = repo.MoveTodo(ctx, &TodoMove{TodoId: ids[0], OwnerId: ownerId, After: &ids[1], Version: 1})
+
+	require.Nil(t, err)
+	assert.EqualValues(t, 2, moved.Version)
+
+	page, _ := repo.GetAllTodos(ctx, &TodoQuery{OwnerId: ownerId, Sort: SortByPosition, Limit: 10})
+	titles := []string{}
+	for _, todo := range page.Todos {
+		titles = append(titles, todo.Title)
+	}
+
+	assert.EqualValues(t, []string{"Laundry", "Groceries", "Homework"}, titles)
+
+	_, err = repo.MoveTodo(ctx, &TodoMove{TodoId: ids[0], OwnerId: ownerId, After: &ids[1], Version: 1})
+
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusPreconditionFailed, err.Status())
+
+	missing := int64(99)
+	_, err = repo.MoveTodo(ctx, &TodoMove{TodoId: ids[0], OwnerId: ownerId, After: &missing})
+
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusBadRequest, err.Status())
+}
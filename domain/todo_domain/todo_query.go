@@ -0,0 +1,232 @@
+package todo_domain
+
+import (
+	"assignment-4/utils/error_utils"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	DefaultTodoLimit = 20
+	MaxTodoLimit     = 100
+
+	SortById     = "id"
+	SortByIdDesc = "-id"
+	SortByTitle  = "title"
+	// SortByPosition is the manual order todos are moved into.
+	SortByPosition = "position"
+	// SortByPriority puts the most urgent todos first, each priority in
+	// manual order.
+	SortByPriority = "-priority"
+
+	TagModeAny = "any"
+	TagModeAll = "all"
+)
+
+type TodoQuery struct {
+	OwnerId   int64
+	Limit     int
+	Offset    int
+	Cursor    string
+	Completed *bool
+	DueBefore *time.Time
+	Overdue   *bool
+	Search    string
+	Priority  string
+	Sort      string
+	Trashed   bool
+	ListId    *int64
+	Tags      []string
+	TagMode   string
+}
+
+type TodoPage struct {
+	Todos      []Todo `json:"data"`
+	Total      int64  `json:"total"`
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type todoCursor struct {
+	Sort     string `json:"s"`
+	Id       int64  `json:"i"`
+	Title    string `json:"t,omitempty"`
+	Position string `json:"p,omitempty"`
+	Priority int    `json:"r,omitempty"`
+}
+
+func (q *TodoQuery) ParseQueryParams(c *gin.Context) error_utils.MessageErr {
+	if limit := c.Query("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			return error_utils.NewBadRequest("invalid limit query param")
+		}
+		q.Limit = value
+	}
+
+	if offset := c.Query("offset"); offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil {
+			return error_utils.NewBadRequest("invalid offset query param")
+		}
+		q.Offset = value
+	}
+
+	if completed := c.Query("completed"); completed != "" {
+		value, err := strconv.ParseBool(completed)
+		if err != nil {
+			return error_utils.NewBadRequest("invalid completed query param")
+		}
+		q.Completed = &value
+	}
+
+	if dueBefore := c.Query("due_before"); dueBefore != "" {
+		value, err := time.Parse(time.RFC3339, dueBefore)
+		if err != nil {
+			return error_utils.NewBadRequest("invalid due_before query param, expected RFC 3339 timestamp")
+		}
+		q.DueBefore = &value
+	}
+
+	if overdue := c.Query("overdue"); overdue != "" {
+		value, err := strconv.ParseBool(overdue)
+		if err != nil {
+			return error_utils.NewBadRequest("invalid overdue query param")
+		}
+		q.Overdue = &value
+	}
+
+	if listId := c.Query("list_id"); listId != "" {
+		value, err := strconv.ParseInt(listId, 10, 64)
+		if err != nil {
+			return error_utils.NewBadRequest("invalid list_id query param")
+		}
+		q.ListId = &value
+	}
+
+	for _, tag := range c.QueryArray("tag") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			q.Tags = append(q.Tags, tag)
+		}
+	}
+
+	q.Priority = c.Query("priority")
+	q.TagMode = c.Query("tag_mode")
+	q.Cursor = c.Query("cursor")
+	q.Search = strings.TrimSpace(c.Query("q"))
+	q.Sort = c.Query("sort")
+
+	return nil
+}
+
+func (q *TodoQuery) Validate() error_utils.MessageErr {
+	if q.Limit == 0 {
+		q.Limit = DefaultTodoLimit
+	}
+
+	if q.Limit < 0 || q.Limit > MaxTodoLimit {
+		return error_utils.NewBadRequest("limit must be between 1 and " + strconv.Itoa(MaxTodoLimit))
+	}
+
+	if q.Offset < 0 {
+		return error_utils.NewBadRequest("offset must not be negative")
+	}
+
+	if q.Sort == "" {
+		q.Sort = SortById
+	}
+
+	switch q.Sort {
+	case SortById, SortByIdDesc, SortByTitle, SortByPosition, SortByPriority:
+	default:
+		return error_utils.NewBadRequest("sort must be one of id, -id, title, position, -priority")
+	}
+
+	if q.Priority != "" && priorityName(PriorityLevel(q.Priority)) != q.Priority {
+		return error_utils.NewBadRequest("priority must be one of none, low, medium, high, urgent")
+	}
+
+	if q.TagMode == "" {
+		q.TagMode = TagModeAny
+	}
+
+	if q.TagMode != TagModeAny && q.TagMode != TagModeAll {
+		return error_utils.NewBadRequest("tag_mode must be any or all")
+	}
+
+	seen := map[string]bool{}
+	tags := q.Tags[:0]
+	for _, tag := range q.Tags {
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	q.Tags = tags
+
+	if q.Cursor != "" && q.Offset != 0 {
+		return error_utils.NewBadRequest("cursor and offset cannot be used together")
+	}
+
+	if _, err := q.decodeCursor(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (q *TodoQuery) decodeCursor() (*todoCursor, error_utils.MessageErr) {
+	if q.Cursor == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+	if err != nil {
+		return nil, error_utils.NewBadRequest("invalid cursor")
+	}
+
+	var cursor todoCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Sort != q.Sort {
+		return nil, error_utils.NewBadRequest("invalid cursor")
+	}
+
+	return &cursor, nil
+}
+
+func (q *TodoQuery) encodeCursor(todo *Todo) string {
+	cursor := todoCursor{Sort: q.Sort, Id: todo.Id}
+
+	switch q.Sort {
+	case SortByTitle:
+		cursor.Title = todo.Title
+	case SortByPosition:
+		cursor.Position = todo.Position
+	case SortByPriority:
+		cursor.Position = todo.Position
+		cursor.Priority = PriorityLevel(todo.Priority)
+	}
+
+	data, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func newTodoPage(query *TodoQuery, todos []Todo, total int64) *TodoPage {
+	page := &TodoPage{
+		Todos: todos,
+		Total: total,
+		Limit: query.Limit,
+	}
+
+	if len(todos) > query.Limit {
+		page.Todos = todos[:query.Limit]
+		page.NextCursor = query.encodeCursor(&page.Todos[query.Limit-1])
+	}
+
+	return page
+}
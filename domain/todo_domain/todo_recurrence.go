@@ -0,0 +1,429 @@
+package todo_domain
+
+import (
+	"assignment-4/utils/error_utils"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+
+	DefaultOccurrencePreview = 5
+	MaxOccurrencePreview     = 50
+
+	// maxRecurrencePeriods bounds the search for the next occurrence of a
+	// rule that rarely or never matches, like FREQ=DAILY;INTERVAL=7;BYDAY=TU
+	// starting on a Monday.
+	maxRecurrencePeriods = 1000
+)
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Occurrence is one upcoming due date of a recurring todo.
+type Occurrence struct {
+	Occurrence int       `json:"occurrence"`
+	DueAt      time.Time `json:"due_at"`
+}
+
+type Occurrences struct {
+	Recurrence string       `json:"recurrence"`
+	Items      []Occurrence `json:"data"`
+}
+
+// recurrence is the subset of an RFC 5545 RRULE todos support: FREQ of
+// DAILY, WEEKLY or MONTHLY with INTERVAL, BYDAY, COUNT and UNTIL.
+type recurrence struct {
+	freq     string
+	interval int
+	byDay    []weekdayNum
+	count    int
+	until    *time.Time
+}
+
+// weekdayNum is one BYDAY entry. nth picks the nth weekday of the month,
+// counting from the end when negative, and is only set for monthly rules.
+type weekdayNum struct {
+	nth     int
+	weekday time.Weekday
+}
+
+func parseRecurrence(value string) (*recurrence, error) {
+	value = strings.TrimPrefix(strings.ToUpper(value), "RRULE:")
+
+	r := &recurrence{interval: 1}
+	seen := map[string]bool{}
+
+	for _, part := range strings.Split(value, ";") {
+		name, arg, ok := strings.Cut(strings.TrimSpace(part), "=")
+
+		if !ok || name == "" || arg == "" {
+			return nil, errors.New("recurrence part " + strconv.Quote(part) + " must look like NAME=VALUE")
+		}
+
+		if seen[name] {
+			return nil, errors.New("recurrence has " + name + " more than once")
+		}
+		seen[name] = true
+
+		var err error
+
+		switch name {
+		case "FREQ":
+			if arg != FreqDaily && arg != FreqWeekly && arg != FreqMonthly {
+				return nil, errors.New("recurrence FREQ must be DAILY, WEEKLY or MONTHLY")
+			}
+			r.freq = arg
+		case "INTERVAL":
+			r.interval, err = parsePositive(name, arg)
+		case "COUNT":
+			r.count, err = parsePositive(name, arg)
+		case "UNTIL":
+			r.until, err = parseUntil(arg)
+		case "BYDAY":
+			r.byDay, err = parseByDay(arg)
+		default:
+			return nil, errors.New("recurrence " + name + " is not supported")
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.freq == "" {
+		return nil, errors.New("recurrence FREQ is required")
+	}
+
+	if r.count > 0 && r.until != nil {
+		return nil, errors.New("recurrence cannot have both COUNT and UNTIL")
+	}
+
+	for _, day := range r.byDay {
+		if day.nth != 0 && r.freq != FreqMonthly {
+			return nil, errors.New("recurrence BYDAY can only number weekdays, like 1MO, with FREQ=MONTHLY")
+		}
+	}
+
+	return r, nil
+}
+
+func parsePositive(name, arg string) (int, error) {
+	value, err := strconv.Atoi(arg)
+
+	if err != nil || value < 1 || value > 1000 {
+		return 0, errors.New("recurrence " + name + " must be a number from 1 to 1000")
+	}
+
+	return value, nil
+}
+
+// parseUntil reads an UNTIL date or UTC date-time. A date includes the whole
+// day.
+func parseUntil(arg string) (*time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405"} {
+		if until, err := time.Parse(layout, arg); err == nil {
+			return &until, nil
+		}
+	}
+
+	until, err := time.Parse("20060102", arg)
+
+	if err != nil {
+		return nil, errors.New("recurrence UNTIL must be a date like 20261231 or a UTC time like 20261231T170000Z")
+	}
+
+	until = until.Add(24*time.Hour - time.Second)
+
+	return &until, nil
+}
+
+func parseByDay(arg string) ([]weekdayNum, error) {
+	var days []weekdayNum
+	seen := map[weekdayNum]bool{}
+
+	for _, entry := range strings.Split(arg, ",") {
+		entry = strings.TrimSpace(entry)
+
+		if len(entry) < 2 {
+			return nil, errors.New("recurrence BYDAY " + strconv.Quote(entry) + " is not a weekday")
+		}
+
+		weekday, ok := weekdays[entry[len(entry)-2:]]
+
+		if !ok {
+			return nil, errors.New("recurrence BYDAY " + strconv.Quote(entry) + " is not a weekday")
+		}
+
+		day := weekdayNum{weekday: weekday}
+
+		if prefix := entry[:len(entry)-2]; prefix != "" {
+			nth, err := strconv.Atoi(prefix)
+
+			if err != nil || nth == 0 || nth < -5 || nth > 5 {
+				return nil, errors.New("recurrence BYDAY " + strconv.Quote(entry) + " must number the weekday from 1 to 5 or -1 to -5")
+			}
+
+			day.nth = nth
+		}
+
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+
+	return days, nil
+}
+
+// String writes the rule the way todos store it, so equal rules read the
+// same.
+func (r *recurrence) String() string {
+	parts := []string{"FREQ=" + r.freq}
+
+	if r.interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.interval))
+	}
+
+	if len(r.byDay) > 0 {
+		days := make([]string, len(r.byDay))
+
+		for i, day := range r.byDay {
+			days[i] = strings.ToUpper(day.weekday.String()[:2])
+
+			if day.nth != 0 {
+				days[i] = strconv.Itoa(day.nth) + days[i]
+			}
+		}
+
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if r.count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.count))
+	}
+
+	if r.until != nil {
+		parts = append(parts, "UNTIL="+r.until.UTC().Format("20060102T150405Z"))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// after returns up to n occurrences following start, which is occurrence
+// number occurrence of the series. start anchors the rule: occurrences
+// share its time of day, and it gives the weekday of weekly and the day of
+// monthly rules without BYDAY.
+func (r *recurrence) after(start time.Time, occurrence int, n int) []time.Time {
+	var res []time.Time
+
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		for _, at := range r.candidates(start, period) {
+			if !at.After(start) {
+				continue
+			}
+
+			if (r.count > 0 && occurrence+len(res) >= r.count) || (r.until != nil && at.After(*r.until)) {
+				return res
+			}
+
+			res = append(res, at)
+
+			if len(res) == n {
+				return res
+			}
+		}
+	}
+
+	return res
+}
+
+// candidates lists the times in order that the rule matches in the period
+// that is period intervals after the one of start.
+func (r *recurrence) candidates(start time.Time, period int) []time.Time {
+	year, month, day := start.Date()
+	step := period * r.interval
+
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+	}
+
+	var res []time.Time
+
+	switch r.freq {
+	case FreqDaily:
+		if date := at(year, month, day+step); r.matches(date, start) {
+			res = append(res, date)
+		}
+	case FreqWeekly:
+		monday := day - (int(start.Weekday())+6)%7 + 7*step
+
+		for offset := 0; offset < 7; offset++ {
+			if date := at(year, month, monday+offset); r.matches(date, start) {
+				res = append(res, date)
+			}
+		}
+	case FreqMonthly:
+		first := at(year, month+time.Month(step), 1)
+
+		if len(r.byDay) == 0 {
+			// months without the day of start are skipped, as RFC 5545 does
+			if date := at(first.Year(), first.Month(), day); date.Month() == first.Month() {
+				res = append(res, date)
+			}
+			break
+		}
+
+		for date := first; date.Month() == first.Month(); date = at(date.Year(), date.Month(), date.Day()+1) {
+			if r.matches(date, start) {
+				res = append(res, date)
+			}
+		}
+	}
+
+	return res
+}
+
+// matches reports whether date is on one of the BYDAY weekdays. Weekly
+// rules without BYDAY fall on the weekday of start.
+func (r *recurrence) matches(date time.Time, start time.Time) bool {
+	if len(r.byDay) == 0 {
+		return r.freq != FreqWeekly || date.Weekday() == start.Weekday()
+	}
+
+	lastDay := time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	for _, day := range r.byDay {
+		if day.weekday != date.Weekday() {
+			continue
+		}
+
+		switch {
+		case day.nth == 0:
+			return true
+		case day.nth > 0 && (date.Day()-1)/7+1 == day.nth:
+			return true
+		case day.nth < 0 && (lastDay-date.Day())/7+1 == -day.nth:
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeRecurrence checks the recurrence rule of the todo and stores it
+// in its canonical form. An empty rule removes the recurrence.
+func (t *Todo) normalizeRecurrence() []error_utils.FieldError {
+	if t.Recurrence == nil {
+		return nil
+	}
+
+	value := strings.TrimSpace(*t.Recurrence)
+
+	if value == "" {
+		t.Recurrence = nil
+		return nil
+	}
+
+	rule, err := parseRecurrence(value)
+
+	if err != nil {
+		return []error_utils.FieldError{{Field: "recurrence", Rule: "rrule", Message: err.Error()}}
+	}
+
+	value = rule.String()
+	t.Recurrence = &value
+
+	if t.DueAt == nil {
+		return []error_utils.FieldError{{Field: "due_at", Rule: "required_with", Message: "due_at is required for a recurring todo"}}
+	}
+
+	return nil
+}
+
+// NewNotRecurringError is returned when the occurrences of a todo without a
+// recurrence rule are asked for.
+func NewNotRecurringError() error_utils.MessageErr {
+	return error_utils.NewConflictError("todo has no recurrence")
+}
+
+// Occurrences lists up to n occurrences that follow the todo in its series,
+// ending early when the rule does.
+func (t *Todo) Occurrences(n int) (*Occurrences, error_utils.MessageErr) {
+	if t.Recurrence == nil || t.DueAt == nil {
+		return nil, NewNotRecurringError()
+	}
+
+	rule, err := parseRecurrence(*t.Recurrence)
+
+	if err != nil {
+		return nil, error_utils.Wrap(error_utils.NewInternalServerError("something went wrong"), err)
+	}
+
+	res := &Occurrences{Recurrence: *t.Recurrence, Items: []Occurrence{}}
+
+	for i, dueAt := range rule.after(*t.DueAt, t.Occurrence, n) {
+		res.Items = append(res.Items, Occurrence{Occurrence: t.Occurrence + i + 1, DueAt: dueAt})
+	}
+
+	return res, nil
+}
+
+// NextOccurrence returns the todo to create once t is completed, due at the
+// next occurrence of the rule with the reminder as far ahead of it as the
+// one of t. It returns nil when the series has ended.
+func (t *Todo) NextOccurrence() (*Todo, error_utils.MessageErr) {
+	occurrences, err := t.Occurrences(1)
+
+	if err != nil || len(occurrences.Items) == 0 {
+		return nil, err
+	}
+
+	next := occurrences.Items[0]
+	todo := &Todo{
+		Title:       t.Title,
+		Description: t.Description,
+		Priority:    t.Priority,
+		DueAt:       &next.DueAt,
+		ListId:      copyId(t.ListId),
+		Tags:        copyTags(t.Tags),
+		Recurrence:  t.Recurrence,
+		Occurrence:  next.Occurrence,
+		OwnerId:     t.OwnerId,
+	}
+
+	if t.RemindAt != nil {
+		remindAt := next.DueAt.Add(t.RemindAt.Sub(*t.DueAt))
+		todo.RemindAt = &remindAt
+	}
+
+	return todo, nil
+}
+
+// ParseOccurrenceCount reads the count query param of the occurrence
+// preview.
+func ParseOccurrenceCount(value string) (int, error_utils.MessageErr) {
+	if value == "" {
+		return DefaultOccurrencePreview, nil
+	}
+
+	count, err := strconv.Atoi(value)
+
+	if err != nil || count < 1 || count > MaxOccurrencePreview {
+		return 0, error_utils.NewBadRequest("count must be a number from 1 to " + strconv.Itoa(MaxOccurrencePreview))
+	}
+
+	return count, nil
+}
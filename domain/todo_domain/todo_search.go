@@ -0,0 +1,357 @@
+package todo_domain
+
+import (
+	"assignment-4/utils/error_utils"
+	"html"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	MaxSearchLength = 200
+	MaxSearchTerms  = 16
+
+	HighlightStart = "<mark>"
+	HighlightStop  = "</mark>"
+
+	// snippetWords is how many words of the description a snippet shows,
+	// starting a few words before the first match.
+	snippetWords   = 20
+	snippetContext = 5
+)
+
+// TodoSearch is a full text search over the titles and descriptions of the
+// todos of one owner. Words are matched whole and case insensitively, a
+// word ending in * matches as a prefix and words in double quotes have to
+// follow each other. Every term has to match.
+type TodoSearch struct {
+	OwnerId int64
+	Query   string
+	Limit   int
+	Offset  int
+	terms   []searchTerm
+}
+
+// searchTerm is a word, or a phrase when it has more than one. With prefix
+// set its last word also matches longer words.
+type searchTerm struct {
+	words  []string
+	prefix bool
+}
+
+type SearchResult struct {
+	Todo
+	Rank      float64         `json:"rank"`
+	Highlight SearchHighlight `json:"highlight"`
+}
+
+// SearchHighlight has the title and a snippet of the description with the
+// matching words wrapped in <mark> tags. The text is HTML escaped, so the
+// highlights are safe to render as HTML.
+type SearchHighlight struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type SearchPage struct {
+	Results []SearchResult `json:"data"`
+	Total   int64          `json:"total"`
+	Limit   int            `json:"limit"`
+	Offset  int            `json:"offset"`
+}
+
+func (s *TodoSearch) ParseQueryParams(c *gin.Context) error_utils.MessageErr {
+	if limit := c.Query("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			return error_utils.NewBadRequest("invalid limit query param")
+		}
+		s.Limit = value
+	}
+
+	if offset := c.Query("offset"); offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil {
+			return error_utils.NewBadRequest("invalid offset query param")
+		}
+		s.Offset = value
+	}
+
+	s.Query = c.Query("q")
+
+	return nil
+}
+
+func (s *TodoSearch) Validate() error_utils.MessageErr {
+	if s.Limit == 0 {
+		s.Limit = DefaultTodoLimit
+	}
+
+	if s.Limit < 0 || s.Limit > MaxTodoLimit {
+		return error_utils.NewBadRequest("limit must be between 1 and " + strconv.Itoa(MaxTodoLimit))
+	}
+
+	if s.Offset < 0 {
+		return error_utils.NewBadRequest("offset must not be negative")
+	}
+
+	s.Query = strings.TrimSpace(s.Query)
+
+	if s.Query == "" {
+		return error_utils.NewBadRequest("q is required")
+	}
+
+	if utf8.RuneCountInString(s.Query) > MaxSearchLength {
+		return error_utils.NewBadRequest("q must be at most " + strconv.Itoa(MaxSearchLength) + " characters")
+	}
+
+	s.terms = parseSearchTerms(s.Query)
+
+	if len(s.terms) == 0 {
+		return error_utils.NewBadRequest("q must contain a word to search for")
+	}
+
+	if len(s.terms) > MaxSearchTerms {
+		return error_utils.NewBadRequest("q can have at most " + strconv.Itoa(MaxSearchTerms) + " terms")
+	}
+
+	return nil
+}
+
+func parseSearchTerms(query string) []searchTerm {
+	var terms []searchTerm
+
+	for query != "" {
+		var term searchTerm
+
+		if strings.HasPrefix(query, `"`) {
+			phrase, after, _ := strings.Cut(query[1:], `"`)
+			term.words = searchWords(phrase)
+			query = after
+		} else {
+			end := strings.IndexFunc(query, func(r rune) bool { return unicode.IsSpace(r) || r == '"' })
+			if end < 0 {
+				end = len(query)
+			}
+
+			chunk := query[:end]
+			term.words = searchWords(chunk)
+			term.prefix = strings.HasSuffix(chunk, "*")
+			query = query[end:]
+		}
+
+		query = strings.TrimLeftFunc(query, unicode.IsSpace)
+
+		if len(term.words) > 0 {
+			terms = append(terms, term)
+		}
+	}
+
+	return terms
+}
+
+// tsquery writes the terms for to_tsquery. Words only have letters and
+// digits, so quoting them is enough.
+func (s *TodoSearch) tsquery() string {
+	parts := make([]string, len(s.terms))
+
+	for i, term := range s.terms {
+		words := make([]string, len(term.words))
+
+		for j, word := range term.words {
+			words[j] = "'" + word + "'"
+		}
+
+		if term.prefix {
+			words[len(words)-1] += ":*"
+		}
+
+		parts[i] = strings.Join(words, " <-> ")
+
+		if len(words) > 1 {
+			parts[i] = "(" + parts[i] + ")"
+		}
+	}
+
+	return strings.Join(parts, " & ")
+}
+
+// searchToken is a word of a text, lower cased, with where it starts and
+// ends in the text.
+type searchToken struct {
+	word       string
+	start, end int
+}
+
+func searchTokens(text string) []searchToken {
+	var tokens []searchToken
+
+	start := -1
+
+	for i, r := range text + " " {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+
+		if isWord && start < 0 {
+			start = i
+		}
+
+		if !isWord && start >= 0 {
+			tokens = append(tokens, searchToken{word: strings.ToLower(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+
+	return tokens
+}
+
+func searchWords(text string) []string {
+	var words []string
+
+	for _, token := range searchTokens(text) {
+		words = append(words, token.word)
+	}
+
+	return words
+}
+
+// match ranks the todo the way the generated search column does: title
+// words first, then description words, with a title match weighing more.
+// ok is false when a term does not match.
+//
+// Words are only split on letters and digits, while the parser behind
+// to_tsvector('simple') keeps some compounds whole. Hyphenated words like
+// e-mail are indexed whole and in parts, so they match the same here, but
+// email addresses, host names and versions like v1.2 are one lexeme on
+// Postgres: "example" finds bob@example.com here and not on Postgres.
+func (s *TodoSearch) match(todo *Todo) (rank float64, ok bool) {
+	title := searchTokens(todo.Title)
+	doc := append(title, searchTokens(todo.Description)...)
+
+	var score float64
+
+	for _, term := range s.terms {
+		found := false
+
+		for i := 0; i+len(term.words) <= len(doc); i++ {
+			if !term.matchesAt(doc, i) {
+				continue
+			}
+
+			found = true
+
+			if i < len(title) {
+				score += 1
+			} else {
+				score += 0.4
+			}
+		}
+
+		if !found {
+			return 0, false
+		}
+	}
+
+	return score / (score + 1), true
+}
+
+func (t searchTerm) matchesAt(doc []searchToken, i int) bool {
+	for j := range t.words {
+		if !t.matchesWord(j, doc[i+j].word) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t searchTerm) matchesWord(j int, word string) bool {
+	if t.prefix && j == len(t.words)-1 {
+		return strings.HasPrefix(word, t.words[j])
+	}
+
+	return word == t.words[j]
+}
+
+// highlight marks the words of the todo that match a word of the search,
+// like ts_headline does.
+func (s *TodoSearch) highlight(todo *Todo) SearchHighlight {
+	matches := func(word string) bool {
+		for _, term := range s.terms {
+			for j := range term.words {
+				if term.matchesWord(j, word) {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+
+	title := searchTokens(todo.Title)
+	description := searchTokens(todo.Description)
+
+	start, end := 0, len(description)
+
+	if len(description) > snippetWords {
+		for i, token := range description {
+			if matches(token.word) {
+				start = i - snippetContext
+				break
+			}
+		}
+
+		if start > len(description)-snippetWords {
+			start = len(description) - snippetWords
+		}
+
+		if start < 0 {
+			start = 0
+		}
+
+		end = start + snippetWords
+	}
+
+	return SearchHighlight{
+		Title:       markWords(todo.Title, title, 0, len(title), matches),
+		Description: markWords(todo.Description, description, start, end, matches),
+	}
+}
+
+// markWords returns the text from tokens[start] to tokens[end-1], or to the
+// edges of text when the range reaches them, HTML escaped and with the
+// matching words marked.
+func markWords(text string, tokens []searchToken, start, end int, matches func(string) bool) string {
+	from, to := 0, len(text)
+
+	if start > 0 {
+		from = tokens[start].start
+	}
+
+	if end < len(tokens) {
+		to = tokens[end-1].end
+	}
+
+	var b strings.Builder
+
+	last := from
+
+	for _, token := range tokens[start:end] {
+		if !matches(token.word) {
+			continue
+		}
+
+		b.WriteString(html.EscapeString(text[last:token.start]))
+		b.WriteString(HighlightStart)
+		b.WriteString(html.EscapeString(text[token.start:token.end]))
+		b.WriteString(HighlightStop)
+		last = token.end
+	}
+
+	b.WriteString(html.EscapeString(text[last:to]))
+
+	return b.String()
+}
@@ -0,0 +1,148 @@
+package todo_domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoSearch_Tsquery(t *testing.T) {
+	for query, want := range map[string]string{
+		"Dinner":                  "'dinner'",
+		"  fried   RICE ":         "'fried' & 'rice'",
+		"hom*":                    "'hom':*",
+		`"fried rice" egg*`:       "('fried' <-> 'rice') & 'egg':*",
+		`e-mail`:                  "('e' <-> 'mail')",
+		`"unterminated phrase`:    "('unterminated' <-> 'phrase')",
+		`it's "" ! café`:          "('it' <-> 's') & 'café'",
+		`x' | 'y' & !z <-> (w):*`: "'x' & 'y' & 'z' & 'w':*",
+		`"a b"c`:                  "('a' <-> 'b') & 'c'",
+		"\"report\"\t2026 q4* ":   "'report' & '2026' & 'q4':*",
+	} {
+		search := &TodoSearch{Query: query}
+
+		require.Nil(t, search.Validate(), query)
+		assert.EqualValues(t, want, search.tsquery(), query)
+	}
+}
+
+func TestTodoSearch_Validate(t *testing.T) {
+	for query, message := range map[string]string{
+		"   ":                         "q is required",
+		`"" * -`:                      "q must contain a word to search for",
+		strings.Repeat("a", 201):      "q must be at most 200 characters",
+		strings.Repeat("a ", 17)[:33]: "q can have at most 16 terms",
+	} {
+		err := (&TodoSearch{Query: query}).Validate()
+
+		require.NotNil(t, err, query)
+		assert.EqualValues(t, message, err.Message(), query)
+	}
+
+	err := (&TodoSearch{Query: "a", Limit: 101}).Validate()
+
+	require.NotNil(t, err)
+	assert.EqualValues(t, "limit must be between 1 and 100", err.Message())
+}
+
+func TestTodoSearch_Highlight(t *testing.T) {
+	search := &TodoSearch{Query: `"fried rice" chick*`}
+	require.Nil(t, search.Validate())
+
+	todo := &Todo{Title: "Fried rice!", Description: "Cook fried rice with egg and Chicken."}
+
+	rank, ok := search.match(todo)
+
+	assert.True(t, ok)
+	assert.True(t, rank > 0 && rank < 1)
+	assert.EqualValues(t, SearchHighlight{
+		Title:       "<mark>Fried</mark> <mark>rice</mark>!",
+		Description: "Cook <mark>fried</mark> <mark>rice</mark> with egg and <mark>Chicken</mark>.",
+	}, search.highlight(todo))
+
+	// a long description is cut down to a snippet around the first match
+	todo.Description = strings.Repeat("lorem ", 30) + "chicken " + strings.Repeat("ipsum ", 30)
+
+	assert.EqualValues(t, strings.Repeat("lorem ", 5)+"<mark>chicken</mark>"+strings.Repeat(" ipsum", 14), search.highlight(todo).Description)
+
+	_, ok = search.match(&Todo{Title: "Rice", Description: "fried chicken"})
+	assert.False(t, ok)
+}
+
+func TestTodoSearch_HighlightEscapesHTML(t *testing.T) {
+	search := &TodoSearch{Query: "script"}
+	require.Nil(t, search.Validate())
+
+	todo := &Todo{Title: `<script>alert("hi")</script>`, Description: `Tom & Jerry's <b>script</b>`}
+
+	_, ok := search.match(todo)
+
+	assert.True(t, ok)
+	assert.EqualValues(t, SearchHighlight{
+		Title:       `&lt;<mark>script</mark>&gt;alert(&#34;hi&#34;)&lt;/<mark>script</mark>&gt;`,
+		Description: `Tom &amp; Jerry&#39;s &lt;b&gt;<mark>script</mark>&lt;/b&gt;`,
+	}, search.highlight(todo))
+}
+
+func TestTodoSearch_MatchCompounds(t *testing.T) {
+	todo := &Todo{Title: "Send the e-mail", Description: "to bob@example.com about v1.2"}
+
+	for query, want := range map[string]bool{
+		"e-mail":   true,
+		"mail":     true,
+		`"e mail"`: true,
+		"email":    false,
+		// one lexeme on postgres, so these only match in memory
+		"example": true,
+		"v1":      true,
+	} {
+		search := &TodoSearch{Query: query}
+		require.Nil(t, search.Validate(), query)
+
+		_, ok := search.match(todo)
+		assert.EqualValues(t, want, ok, query)
+	}
+}
+
+func TestTodoMemoryRepo_SearchTodos(t *testing.T) {
+	repo := NewTodoMemoryRepo()
+
+	for _, todo := range []*Todo{
+		{Title: "Groceries", Description: "Buy chicken and rice"},
+		{Title: "Cook chicken", Description: "Fried chicken for dinner"},
+		{Title: "Homework", Description: "Chemistry chapter 4"},
+	} {
+		todo.OwnerId = ownerId
+		_, err := repo.CreateTodo(ctx, todo)
+		require.Nil(t, err)
+	}
+
+	other, _ := repo.CreateTodo(ctx, &Todo{OwnerId: 2, Title: "Chicken", Description: "Not mine"})
+	trashed, _ := repo.CreateTodo(ctx, &Todo{OwnerId: ownerId, Title: "Chicken soup", Description: "Trashed"})
+	_, err := repo.DeleteTodoById(ctx, trashed.Id, ownerId, 0)
+	require.Nil(t, err)
+
+	search := &TodoSearch{OwnerId: ownerId, Query: "CHICKEN"}
+	require.Nil(t, search.Validate())
+
+	page, err := repo.SearchTodos(ctx, search)
+
+	require.Nil(t, err)
+	assert.EqualValues(t, 2, page.Total)
+	require.Len(t, page.Results, 2)
+	assert.EqualValues(t, "Cook chicken", page.Results[0].Title)
+	assert.EqualValues(t, "Groceries", page.Results[1].Title)
+	assert.True(t, page.Results[0].Rank > page.Results[1].Rank)
+	assert.NotEqualValues(t, other.Id, page.Results[0].Id)
+
+	search = &TodoSearch{OwnerId: ownerId, Query: "che*", Offset: 1}
+	require.Nil(t, search.Validate())
+
+	page, err = repo.SearchTodos(ctx, search)
+
+	require.Nil(t, err)
+	assert.EqualValues(t, 1, page.Total)
+	assert.Empty(t, page.Results)
+}
@@ -0,0 +1,34 @@
+package todo_domain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("assignment-4/domain/todo_domain")
+
+// startQuery starts a client span for one todoRepo statement, named after
+// its query constant.
+func startQuery(ctx context.Context, statement string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "todoRepo."+statement,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL, attribute.String("db.statement.name", statement)),
+	)
+}
+
+// endQuery ends span, marking it failed unless err is nil or just "no rows".
+func endQuery(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
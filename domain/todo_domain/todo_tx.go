@@ -0,0 +1,160 @@
+package todo_domain
+
+import (
+	"assignment-4/db"
+	"assignment-4/utils/error_formats"
+	"assignment-4/utils/error_utils"
+	"context"
+	"database/sql"
+	"strconv"
+)
+
+// TxFunc is run by RunInTx. Every todoDomain call made with the ctx it
+// receives joins the transaction.
+type TxFunc func(ctx context.Context) error_utils.MessageErr
+
+type sqlTxKey struct{}
+
+type sqlTx struct {
+	tx         *sql.Tx
+	savepoints int
+}
+
+// conn returns the transaction ctx belongs to, or the pool outside of one.
+func conn(ctx context.Context) db.Queryer {
+	return db.Conn(ctx)
+}
+
+// RunInTx runs fn in a transaction that is committed when fn returns nil and
+// rolled back otherwise, including when fn panics. Nested calls use a
+// savepoint, so only the work of the inner fn is undone when it fails. Other
+// repositories join the transaction through db.Conn.
+func (m *todoRepo) RunInTx(ctx context.Context, fn TxFunc) error_utils.MessageErr {
+	if current, ok := ctx.Value(sqlTxKey{}).(*sqlTx); ok {
+		return current.savepoint(ctx, fn)
+	}
+
+	spanCtx, span := startQuery(ctx, "beginTx")
+	tx, err := db.GetDB().BeginTx(spanCtx, nil)
+	endQuery(span, err)
+	if err != nil {
+		return error_formats.ParseError(err)
+	}
+
+	// rolls back when fn fails or panics, and does nothing once committed
+	defer tx.Rollback()
+
+	txCtx := db.WithTx(context.WithValue(ctx, sqlTxKey{}, &sqlTx{tx: tx}), tx)
+
+	if messageErr := fn(txCtx); messageErr != nil {
+		return messageErr
+	}
+
+	_, span = startQuery(ctx, "commitTx")
+	err = tx.Commit()
+	endQuery(span, err)
+	if err != nil {
+		return error_formats.ParseError(err)
+	}
+
+	return nil
+}
+
+func (t *sqlTx) savepoint(ctx context.Context, fn TxFunc) error_utils.MessageErr {
+	t.savepoints++
+	name := "todo_sp_" + strconv.Itoa(t.savepoints)
+
+	if _, err := t.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return error_formats.ParseError(err)
+	}
+
+	if messageErr := fn(ctx); messageErr != nil {
+		if _, err := t.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); err != nil {
+			return error_formats.ParseError(err)
+		}
+		return messageErr
+	}
+
+	if _, err := t.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return error_formats.ParseError(err)
+	}
+
+	return nil
+}
+
+type memoryTxKey struct{}
+
+// RunInTx holds the write lock for the whole of fn, so other callers never
+// see its partial work, and restores a snapshot of the todos and their
+// checklists when fn fails or panics, along with the writes other memory
+// repositories logged with db.OnRollback. Nested calls snapshot again, like a
+// savepoint. As with a postgres sequence, ids handed out in a rolled back
+// transaction are not reused.
+func (m *todoMemoryRepo) RunInTx(ctx context.Context, fn TxFunc) error_utils.MessageErr {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	if !m.inTx(ctx) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		ctx = context.WithValue(ctx, memoryTxKey{}, m)
+	}
+
+	todos := make(map[int64]Todo, len(m.todos))
+	for id, todo := range m.todos {
+		todos[id] = todo
+	}
+
+	items := make(map[int64]ChecklistItem, len(m.items))
+	for id, item := range m.items {
+		items[id] = item
+	}
+
+	txCtx, undo := db.WithUndoLog(ctx)
+
+	committed := false
+
+	// rolls back when fn fails or panics, before the lock is released
+	defer func() {
+		if !committed {
+			m.todos = todos
+			m.items = items
+			undo.Rollback()
+		}
+	}()
+
+	if err := fn(txCtx); err != nil {
+		return err
+	}
+
+	undo.Commit()
+	committed = true
+
+	return nil
+}
+
+func (m *todoMemoryRepo) inTx(ctx context.Context) bool {
+	return ctx.Value(memoryTxKey{}) == m
+}
+
+// lock takes the write lock unless ctx is in a transaction of m, which
+// already holds it. The returned func releases it.
+func (m *todoMemoryRepo) lock(ctx context.Context) func() {
+	if m.inTx(ctx) {
+		return func() {}
+	}
+
+	m.mu.Lock()
+	return m.mu.Unlock
+}
+
+func (m *todoMemoryRepo) rlock(ctx context.Context) func() {
+	if m.inTx(ctx) {
+		return func() {}
+	}
+
+	m.mu.RLock()
+	return m.mu.RUnlock
+}
@@ -0,0 +1,82 @@
+package user_domain
+
+import (
+	"assignment-4/db"
+	"assignment-4/utils/error_formats"
+	"assignment-4/utils/error_utils"
+	"context"
+)
+
+const (
+	queryCreateUser = `
+		INSERT INTO users
+		(email, password)
+		VALUES ($1, $2)
+		RETURNING id, email, password
+	`
+	queryGetUserByEmail = `
+		SELECT id, email, password
+		FROM users
+		WHERE email = $1
+	`
+	queryGetUserById = `
+		SELECT id, email, password
+		FROM users
+		WHERE id = $1
+	`
+)
+
+var UserDomain userDomain = &userRepo{}
+
+type userDomain interface {
+	CreateUser(context.Context, *User) (*User, error_utils.MessageErr)
+	GetUserByEmail(context.Context, string) (*User, error_utils.MessageErr)
+	GetUserById(context.Context, int64) (*User, error_utils.MessageErr)
+}
+
+type userRepo struct{}
+
+func (m *userRepo) CreateUser(ctx context.Context, userReq *User) (*User, error_utils.MessageErr) {
+	db := db.GetDB()
+
+	row := db.QueryRowContext(ctx, queryCreateUser, userReq.Email, userReq.Password)
+
+	var user User
+	err := row.Scan(&user.Id, &user.Email, &user.Password)
+
+	if err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+
+	return &user, nil
+}
+
+func (m *userRepo) GetUserByEmail(ctx context.Context, email string) (*User, error_utils.MessageErr) {
+	db := db.GetDB()
+
+	row := db.QueryRowContext(ctx, queryGetUserByEmail, email)
+
+	var user User
+	err := row.Scan(&user.Id, &user.Email, &user.Password)
+
+	if err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+
+	return &user, nil
+}
+
+func (m *userRepo) GetUserById(ctx context.Context, userId int64) (*User, error_utils.MessageErr) {
+	db := db.GetDB()
+
+	row := db.QueryRowContext(ctx, queryGetUserById, userId)
+
+	var user User
+	err := row.Scan(&user.Id, &user.Email, &user.Password)
+
+	if err != nil {
+		return nil, error_formats.ParseError(err)
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,69 @@
+package user_domain
+
+import (
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/validation_utils"
+	"strconv"
+	"strings"
+)
+
+// MaxPasswordLength is the most bytes bcrypt hashes; longer passwords are
+// rejected by bcrypt.GenerateFromPassword.
+const MaxPasswordLength = 72
+
+type User struct {
+	Id       int64  `json:"id"`
+	Email    string `json:"email" valid:"required~email is required,email~invalid email format"`
+	Password string `json:"password,omitempty" valid:"required~password is required,minstringlength(6)~password has to have a minimum length of 6 characters"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email" valid:"required~email is required"`
+	Password string `json:"password" valid:"required~password is required"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" valid:"required~refresh_token is required"`
+}
+
+func (u *User) Validate() error_utils.MessageErr {
+	u.Email = strings.ToLower(strings.TrimSpace(u.Email))
+
+	fields := validation_utils.ValidateStruct(u)
+
+	if len(u.Password) > MaxPasswordLength {
+		fields = append(fields, error_utils.FieldError{
+			Field:   "password",
+			Rule:    "maxbytelength",
+			Message: "password has to have a maximum length of " + strconv.Itoa(MaxPasswordLength) + " bytes",
+		})
+	}
+
+	if len(fields) > 0 {
+		return error_utils.NewValidationError(fields)
+	}
+
+	return nil
+}
+
+func (l *LoginRequest) Validate() error_utils.MessageErr {
+	l.Email = strings.ToLower(strings.TrimSpace(l.Email))
+
+	fields := validation_utils.ValidateStruct(l)
+
+	if len(fields) > 0 {
+		return error_utils.NewValidationError(fields)
+	}
+
+	return nil
+}
+
+func (r *RefreshTokenRequest) Validate() error_utils.MessageErr {
+	fields := validation_utils.ValidateStruct(r)
+
+	if len(fields) > 0 {
+		return error_utils.NewValidationError(fields)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package user_domain
+
+import (
+	"assignment-4/utils/error_formats"
+	"assignment-4/utils/error_utils"
+	"context"
+	"sync"
+)
+
+type userMemoryRepo struct {
+	mu     sync.RWMutex
+	lastId int64
+	users  map[int64]User
+}
+
+func NewUserMemoryRepo() userDomain {
+	return &userMemoryRepo{
+		users: map[int64]User{},
+	}
+}
+
+func (m *userMemoryRepo) CreateUser(ctx context.Context, userReq *User) (*User, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, user := range m.users {
+		if user.Email == userReq.Email {
+			return nil, error_utils.NewConflictError("email has been taken, try another one")
+		}
+	}
+
+	m.lastId++
+
+	user := User{
+		Id:       m.lastId,
+		Email:    userReq.Email,
+		Password: userReq.Password,
+	}
+	m.users[user.Id] = user
+
+	return &user, nil
+}
+
+func (m *userMemoryRepo) GetUserByEmail(ctx context.Context, email string) (*User, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, user := range m.users {
+		if user.Email == email {
+			return &user, nil
+		}
+	}
+
+	return nil, error_utils.NewNotFoundError("no record found")
+}
+
+func (m *userMemoryRepo) GetUserById(ctx context.Context, userId int64) (*User, error_utils.MessageErr) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	user, ok := m.users[userId]
+	if !ok {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	return &user, nil
+}
+
+func checkContext(ctx context.Context) error_utils.MessageErr {
+	if err := ctx.Err(); err != nil {
+		return error_formats.ParseError(err)
+	}
+
+	return nil
+}
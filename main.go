@@ -1,7 +1,66 @@
 package main
 
-import "assignment-4/router"
+import (
+	"assignment-4/app"
+	"assignment-4/config"
+	"assignment-4/db"
+	"assignment-4/migrations"
+	"assignment-4/utils/logger_utils"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/rs/zerolog/log"
+)
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description access token from /users/login, formatted as "Bearer {token}"
 func main() {
-	router.StartRouter()
+	cfg, args, err := config.Load(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if err := logger_utils.Setup(cfg.Log.Level, os.Stderr); err != nil {
+		log.Fatal().Err(err).Send()
+	}
+
+	if len(args) > 0 {
+		switch args[0] {
+		case "migrate":
+			if err := db.ConnectDB(cfg.DB); err != nil {
+				log.Fatal().Err(err).Send()
+			}
+			err := migrations.RunCommand(db.GetDB(), args[1:], os.Stdout)
+			db.Close()
+
+			if err != nil {
+				log.Fatal().Err(err).Send()
+			}
+		case "config":
+			if err := cfg.Print(os.Stdout); err != nil {
+				log.Fatal().Err(err).Send()
+			}
+		default:
+			log.Fatal().Str("command", args[0]).Msg("unknown command, expected migrate or config")
+		}
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	application, err := app.New(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+
+	if err := application.Run(ctx); err != nil {
+		log.Fatal().Err(err).Send()
+	}
 }
@@ -0,0 +1,48 @@
+package middlewares
+
+import (
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/response_utils"
+	"assignment-4/utils/token_utils"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const UserIdKey = "userId"
+
+func Authentication() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+
+		tokenString := strings.TrimPrefix(header, "Bearer ")
+		if header == "" || tokenString == header {
+			theErr := error_utils.NewNotAuthenticated("missing bearer token")
+			response_utils.AbortWithError(c, theErr)
+			return
+		}
+
+		claims, err := token_utils.ValidateToken(tokenString, token_utils.AccessToken)
+		if err != nil {
+			response_utils.AbortWithError(c, err)
+			return
+		}
+
+		c.Set(UserIdKey, claims.UserId())
+		c.Next()
+	}
+}
+
+func GetUserId(c *gin.Context) (int64, error_utils.MessageErr) {
+	userId, ok := c.Get(UserIdKey)
+	if !ok {
+		return 0, error_utils.NewNotAuthenticated("missing bearer token")
+	}
+
+	id, ok := userId.(int64)
+	if !ok || id <= 0 {
+		return 0, error_utils.NewNotAuthenticated("missing bearer token")
+	}
+
+	return id, nil
+}
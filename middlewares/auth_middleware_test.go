@@ -0,0 +1,82 @@
+package middlewares
+
+import (
+	"assignment-4/utils/token_utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newProtectedRouter(userId *int64) *gin.Engine {
+	r := gin.Default()
+
+	r.GET("/protected", Authentication(), func(c *gin.Context) {
+		*userId, _ = GetUserId(c)
+		c.Status(http.StatusOK)
+	})
+
+	return r
+}
+
+func TestAuthentication_Success(t *testing.T) {
+	token_utils.SetSecret("test-secret")
+
+	tokens, _ := token_utils.GenerateTokens(7, "john@mail.com")
+
+	var userId int64
+	r := newProtectedRouter(&userId)
+
+	req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusOK, rr.Code)
+	assert.EqualValues(t, 7, userId)
+}
+
+func TestAuthentication_NotAuthenticated(t *testing.T) {
+	token_utils.SetSecret("test-secret")
+
+	tokens, _ := token_utils.GenerateTokens(7, "john@mail.com")
+
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{
+			name:   "missing header",
+			header: "",
+		},
+		{
+			name:   "missing bearer prefix",
+			header: tokens.AccessToken,
+		},
+		{
+			name:   "refresh token",
+			header: "Bearer " + tokens.RefreshToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var userId int64
+			r := newProtectedRouter(&userId)
+
+			req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.EqualValues(t, http.StatusUnauthorized, rr.Code)
+			assert.EqualValues(t, 0, userId)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package middlewares
+
+import (
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/logger_utils"
+	"assignment-4/utils/response_utils"
+	"io"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Logger writes one structured access log line per request. It must run
+// after RequestId and Tracing so the line carries the request and trace id.
+func Logger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		status := c.Writer.Status()
+
+		level := zerolog.InfoLevel
+		switch {
+		case status >= 500:
+			level = zerolog.ErrorLevel
+		case status >= 400:
+			level = zerolog.WarnLevel
+		}
+
+		event := logger_utils.Ctx(c.Request.Context()).WithLevel(level)
+
+		if span := trace.SpanContextFromContext(c.Request.Context()); span.HasTraceID() {
+			event.Str("trace_id", span.TraceID().String())
+		}
+
+		event.Str("method", c.Request.Method).
+			Str("route", c.FullPath()).
+			Str("path", c.Request.URL.Path).
+			Int("status", status).
+			Dur("latency", time.Since(start)).
+			Int("bytes", c.Writer.Size()).
+			Str("client_ip", c.ClientIP()).
+			Msg("request")
+	}
+}
+
+// Recovery turns a panic into a 500 and logs it with the stack, instead of
+// gin's plain text dump.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recovered interface{}) {
+		logger_utils.Ctx(c.Request.Context()).Error().
+			Interface("panic", recovered).
+			Bytes("stack", debug.Stack()).
+			Msg("recovered from panic")
+
+		theErr := error_utils.NewInternalServerError("something went wrong")
+		response_utils.AbortWithError(c, theErr)
+	})
+}
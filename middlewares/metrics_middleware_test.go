@@ -0,0 +1,73 @@
+package middlewares
+
+import (
+	"assignment-4/metrics"
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/response_utils"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics_LabelsByRouteTemplate(t *testing.T) {
+	r := gin.Default()
+	r.Use(Metrics())
+
+	r.GET("/todo/:todoId", func(c *gin.Context) {
+		if c.Param("todoId") == "404" {
+			response_utils.Error(c, error_utils.NewNotFoundError("no record found"))
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	ok := testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues("/todo/:todoId", http.MethodGet, "200"))
+	notFound := testutil.ToFloat64(metrics.Errors.WithLabelValues("not_found", "404"))
+
+	for _, path := range []string{"/todo/1", "/todo/2", "/todo/404", "/nowhere"} {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.EqualValues(t, ok+2, testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues("/todo/:todoId", http.MethodGet, "200")))
+	assert.EqualValues(t, notFound+1, testutil.ToFloat64(metrics.Errors.WithLabelValues("not_found", "404")))
+	assert.EqualValues(t, 1, testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues(unmatchedRoute, http.MethodGet, "404")))
+
+	req, _ := http.NewRequest(http.MethodGet, "/metrics", nil)
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusOK, rr.Code)
+	assert.True(t, strings.Contains(rr.Body.String(), `todo_api_http_request_duration_seconds_bucket{method="GET",route="/todo/:todoId",status="200"`))
+}
+
+func TestMetrics_CountsRecoveredPanics(t *testing.T) {
+	r := gin.New()
+	r.Use(Metrics())
+	r.Use(Recovery())
+
+	r.GET("/panic/:id", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	failed := testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues("/panic/:id", http.MethodGet, "500"))
+
+	req, _ := http.NewRequest(http.MethodGet, "/panic/1", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusInternalServerError, rr.Code)
+	assert.EqualValues(t, failed+1, testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues("/panic/:id", http.MethodGet, "500")))
+
+	rr = httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Contains(t, rr.Body.String(), `todo_api_http_request_duration_seconds_count{method="GET",route="/panic/:id",status="500"} 1`)
+}
@@ -0,0 +1,50 @@
+package middlewares
+
+import (
+	"assignment-4/utils/logger_utils"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxRequestIdLength = 128
+
+// RequestId takes the X-Request-ID header from the client or generates one,
+// echoes it in the response and puts it on the request context for logging.
+func RequestId() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(logger_utils.RequestIdHeader)
+		if !validRequestId(id) {
+			id = newRequestId()
+		}
+
+		c.Header(logger_utils.RequestIdHeader, id)
+		c.Request = c.Request.WithContext(logger_utils.WithRequestId(c.Request.Context(), id))
+
+		c.Next()
+	}
+}
+
+// validRequestId accepts printable ASCII only, so a client cannot inject
+// control characters into our logs or headers.
+func validRequestId(id string) bool {
+	if id == "" || len(id) > maxRequestIdLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newRequestId() string {
+	var b [16]byte
+	rand.Read(b[:])
+
+	return hex.EncodeToString(b[:])
+}
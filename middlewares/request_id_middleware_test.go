@@ -0,0 +1,96 @@
+package middlewares
+
+import (
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/logger_utils"
+	"assignment-4/utils/response_utils"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRequestIdRouter(logs *bytes.Buffer) *gin.Engine {
+	logger_utils.Setup("info", logs)
+
+	r := gin.New()
+	r.Use(RequestId(), Logger(), Recovery())
+
+	r.GET("/todo/:todoId", func(c *gin.Context) {
+		logger_utils.Ctx(c.Request.Context()).Info().Msg("handling")
+		response_utils.Error(c, error_utils.NewNotFoundError("no record found"))
+	})
+	r.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	return r
+}
+
+func TestRequestId_AcceptsClientId(t *testing.T) {
+	var logs bytes.Buffer
+	r := newRequestIdRouter(&logs)
+
+	req, _ := http.NewRequest(http.MethodGet, "/todo/42", nil)
+	req.Header.Set(logger_utils.RequestIdHeader, "client-id-1")
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	var body map[string]interface{}
+	require.Nil(t, json.Unmarshal(rr.Body.Bytes(), &body))
+
+	assert.EqualValues(t, "client-id-1", rr.Header().Get(logger_utils.RequestIdHeader))
+	assert.EqualValues(t, "client-id-1", body["request_id"])
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	require.Len(t, lines, 2)
+
+	for _, line := range lines {
+		var entry map[string]interface{}
+		require.Nil(t, json.Unmarshal([]byte(line), &entry))
+		assert.EqualValues(t, "client-id-1", entry["request_id"])
+	}
+}
+
+func TestRequestId_GeneratesId(t *testing.T) {
+	for _, header := range []string{"", "has space", strings.Repeat("a", maxRequestIdLength+1)} {
+		var logs bytes.Buffer
+		r := newRequestIdRouter(&logs)
+
+		req, _ := http.NewRequest(http.MethodGet, "/todo/42", nil)
+		req.Header.Set(logger_utils.RequestIdHeader, header)
+		rr := httptest.NewRecorder()
+
+		r.ServeHTTP(rr, req)
+
+		id := rr.Header().Get(logger_utils.RequestIdHeader)
+		assert.Len(t, id, 32)
+		assert.NotEqualValues(t, header, id)
+	}
+}
+
+func TestRecovery_LogsPanic(t *testing.T) {
+	var logs bytes.Buffer
+	r := newRequestIdRouter(&logs)
+
+	req, _ := http.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set("Accept", error_utils.ProblemContentType)
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	var problem error_utils.Problem
+	require.Nil(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+
+	assert.EqualValues(t, http.StatusInternalServerError, rr.Code)
+	assert.EqualValues(t, rr.Header().Get(logger_utils.RequestIdHeader), problem.RequestId)
+	assert.Contains(t, logs.String(), `"panic":"boom"`)
+	assert.Contains(t, logs.String(), `"status":500`)
+}
@@ -0,0 +1,28 @@
+package middlewares
+
+import (
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/response_utils"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Timeout puts a deadline on the request context. Handlers that run past it
+// get a 504 instead of a dangling query.
+func Timeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			theErr := error_utils.NewGatewayTimeoutError("request timed out")
+			response_utils.AbortWithError(c, theErr)
+		}
+	}
+}
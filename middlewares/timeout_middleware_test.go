@@ -0,0 +1,70 @@
+package middlewares
+
+import (
+	"assignment-4/utils/error_formats"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeout_DeadlineExceeded(t *testing.T) {
+	r := gin.Default()
+
+	r.GET("/slow", Timeout(10*time.Millisecond), func(c *gin.Context) {
+		<-c.Request.Context().Done()
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/slow", nil)
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	var body map[string]interface{}
+	require.Nil(t, json.Unmarshal(rr.Body.Bytes(), &body))
+
+	assert.EqualValues(t, http.StatusGatewayTimeout, rr.Code)
+	assert.EqualValues(t, "gateway_timeout", body["error"])
+	assert.EqualValues(t, "request timed out", body["message"])
+}
+
+func TestTimeout_HandlerReportsContextError(t *testing.T) {
+	r := gin.Default()
+
+	r.GET("/slow", Timeout(10*time.Millisecond), func(c *gin.Context) {
+		<-c.Request.Context().Done()
+
+		theErr := error_formats.ParseError(c.Request.Context().Err())
+		c.JSON(theErr.Status(), theErr)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/slow", nil)
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusGatewayTimeout, rr.Code)
+}
+
+func TestTimeout_WithinDeadline(t *testing.T) {
+	r := gin.Default()
+
+	r.GET("/fast", Timeout(time.Second), func(c *gin.Context) {
+		_, hasDeadline := c.Request.Context().Deadline()
+		assert.True(t, hasDeadline)
+
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/fast", nil)
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	assert.EqualValues(t, http.StatusOK, rr.Code)
+}
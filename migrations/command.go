@@ -0,0 +1,77 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+)
+
+const commandUsage = "usage: migrate up|down|status|goto N"
+
+// RunCommand executes the `migrate` subcommand with the arguments following it.
+func RunCommand(db *sql.DB, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(commandUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		if err := Up(db); err != nil {
+			return err
+		}
+	case "down":
+		if err := Down(db); err != nil {
+			return err
+		}
+	case "goto":
+		if len(args) != 2 {
+			return errors.New(commandUsage)
+		}
+
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || version < 0 {
+			return fmt.Errorf("invalid migration version %q", args[1])
+		}
+
+		if err := Goto(db, version); err != nil {
+			return err
+		}
+	case "status":
+		return printStatus(db, out)
+	default:
+		return errors.New(commandUsage)
+	}
+
+	version, err := Version(db)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "database is at migration version %d\n", version)
+
+	return nil
+}
+
+func printStatus(db *sql.DB, out io.Writer) error {
+	statuses, err := Status(db)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+
+	for _, status := range statuses {
+		state, appliedAt := "pending", "-"
+		if status.Applied {
+			state, appliedAt = "applied", status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+
+	return w.Flush()
+}
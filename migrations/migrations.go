@@ -0,0 +1,375 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+const (
+	// arbitrary key for pg_advisory_lock so two instances never migrate at once
+	migrationLockKey = 720401
+
+	queryCreateMigrationsTable = `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)
+	`
+	queryGetAppliedMigrations = `
+		SELECT version, applied_at
+		FROM schema_migrations
+		ORDER BY version
+	`
+	queryInsertMigration = `
+		INSERT INTO schema_migrations
+		(version, name)
+		VALUES ($1, $2)
+	`
+	queryDeleteMigration = `
+		DELETE
+		FROM schema_migrations
+		WHERE version = $1
+	`
+	queryGetCurrentVersion = `
+		SELECT COALESCE(MAX(version), 0)
+		FROM schema_migrations
+	`
+	queryLockMigrations   = `SELECT pg_advisory_lock($1)`
+	queryUnlockMigrations = `SELECT pg_advisory_unlock($1)`
+)
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+func Load() ([]Migration, error) {
+	return loadMigrations(sqlFiles, "sql")
+}
+
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %q", entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up applies every pending migration in version order.
+func Up(db *sql.DB) error {
+	migrations, err := Load()
+	if err != nil {
+		return err
+	}
+
+	return withLock(db, func() error {
+		return migrateTo(db, migrations, latestVersion(migrations))
+	})
+}
+
+// Down rolls back the most recently applied migration.
+func Down(db *sql.DB) error {
+	migrations, err := Load()
+	if err != nil {
+		return err
+	}
+
+	return withLock(db, func() error {
+		applied, err := appliedVersions(db)
+		if err != nil {
+			return err
+		}
+
+		current := currentVersion(applied)
+		if current == 0 {
+			return nil
+		}
+
+		target := int64(0)
+		for _, migration := range migrations {
+			if migration.Version < current {
+				target = migration.Version
+			}
+		}
+
+		return migrateTo(db, migrations, target)
+	})
+}
+
+// Goto migrates up or down until version is the latest applied migration.
+// Version 0 rolls back everything.
+func Goto(db *sql.DB, version int64) error {
+	migrations, err := Load()
+	if err != nil {
+		return err
+	}
+
+	if version != 0 && findMigration(migrations, version) == nil {
+		return fmt.Errorf("migration version %d does not exist", version)
+	}
+
+	return withLock(db, func() error {
+		return migrateTo(db, migrations, version)
+	})
+}
+
+// Latest returns the version of the newest embedded migration.
+func Latest() (int64, error) {
+	migrations, err := Load()
+	if err != nil || len(migrations) == 0 {
+		return 0, err
+	}
+
+	return migrations[len(migrations)-1].Version, nil
+}
+
+// CurrentVersion reads the applied version without creating the bookkeeping
+// table, so it is safe for frequent health checks.
+func CurrentVersion(ctx context.Context, db *sql.DB) (int64, error) {
+	var version int64
+	err := db.QueryRowContext(ctx, queryGetCurrentVersion).Scan(&version)
+
+	return version, err
+}
+
+// Version returns the latest applied migration version, or 0 when none is applied.
+func Version(db *sql.DB) (int64, error) {
+	if _, err := db.Exec(queryCreateMigrationsTable); err != nil {
+		return 0, err
+	}
+
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return 0, err
+	}
+
+	return currentVersion(applied), nil
+}
+
+func Status(db *sql.DB) ([]MigrationStatus, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := db.Exec(queryCreateMigrationsTable); err != nil {
+		return nil, err
+	}
+
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+
+	for _, migration := range migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+
+		if appliedAt, ok := applied[migration.Version]; ok {
+			appliedAt := appliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func migrateTo(db *sql.DB, migrations []Migration, target int64) error {
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range migrations {
+		if _, ok := applied[migration.Version]; ok || migration.Version > target {
+			continue
+		}
+
+		if err := apply(db, migration.Up, queryInsertMigration, migration.Version, migration.Name); err != nil {
+			return fmt.Errorf("migration %d_%s up: %v", migration.Version, migration.Name, err)
+		}
+	}
+
+	for i := len(migrations) - 1; i >= 0; i-- {
+		migration := migrations[i]
+
+		if _, ok := applied[migration.Version]; !ok || migration.Version <= target {
+			continue
+		}
+
+		if err := apply(db, migration.Down, queryDeleteMigration, migration.Version); err != nil {
+			return fmt.Errorf("migration %d_%s down: %v", migration.Version, migration.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func apply(db *sql.DB, statement string, bookkeeping string, args ...interface{}) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(statement); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.Exec(bookkeeping, args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func withLock(db *sql.DB, fn func() error) error {
+	if _, err := db.Exec(queryCreateMigrationsTable); err != nil {
+		return err
+	}
+
+	// advisory locks belong to a session, so lock and unlock on the same connection
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(context.Background(), queryLockMigrations, migrationLockKey); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.Background(), queryUnlockMigrations, migrationLockKey)
+
+	return fn()
+}
+
+func appliedVersions(db *sql.DB) (map[int64]time.Time, error) {
+	rows, err := db.Query(queryGetAppliedMigrations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int64]time.Time{}
+
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+
+	return applied, rows.Err()
+}
+
+func currentVersion(applied map[int64]time.Time) int64 {
+	var current int64
+
+	for version := range applied {
+		if version > current {
+			current = version
+		}
+	}
+
+	return current
+}
+
+func latestVersion(migrations []Migration) int64 {
+	if len(migrations) == 0 {
+		return 0
+	}
+
+	return migrations[len(migrations)-1].Version
+}
+
+func findMigration(migrations []Migration, version int64) *Migration {
+	for i := range migrations {
+		if migrations[i].Version == version {
+			return &migrations[i]
+		}
+	}
+
+	return nil
+}
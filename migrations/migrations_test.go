@@ -0,0 +1,83 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_EmbeddedMigrations(t *testing.T) {
+	migrations, err := Load()
+
+	require.Nil(t, err)
+	require.NotEmpty(t, migrations)
+
+	for i, migration := range migrations {
+		assert.NotEmpty(t, migration.Up)
+		assert.NotEmpty(t, migration.Down)
+
+		if i > 0 {
+			assert.Greater(t, migration.Version, migrations[i-1].Version)
+		}
+	}
+
+	assert.EqualValues(t, 1, migrations[0].Version)
+	assert.EqualValues(t, "create_todos_table", migrations[0].Name)
+}
+
+func TestLoadMigrations_SortedByVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sql/0010_third.up.sql":    {Data: []byte("SELECT 10")},
+		"sql/0010_third.down.sql":  {Data: []byte("SELECT -10")},
+		"sql/0002_second.up.sql":   {Data: []byte("SELECT 2")},
+		"sql/0002_second.down.sql": {Data: []byte("SELECT -2")},
+		"sql/0001_first.up.sql":    {Data: []byte("SELECT 1")},
+		"sql/0001_first.down.sql":  {Data: []byte("SELECT -1")},
+	}
+
+	migrations, err := loadMigrations(fsys, "sql")
+
+	require.Nil(t, err)
+	require.Len(t, migrations, 3)
+	assert.EqualValues(t, []int64{1, 2, 10}, []int64{migrations[0].Version, migrations[1].Version, migrations[2].Version})
+	assert.EqualValues(t, "SELECT 2", migrations[1].Up)
+	assert.EqualValues(t, "SELECT -2", migrations[1].Down)
+}
+
+func TestLoadMigrations_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{
+			name: "missing down file",
+			fsys: fstest.MapFS{
+				"sql/0001_first.up.sql": {Data: []byte("SELECT 1")},
+			},
+		},
+		{
+			name: "invalid file name",
+			fsys: fstest.MapFS{
+				"sql/first.up.sql": {Data: []byte("SELECT 1")},
+			},
+		},
+		{
+			name: "conflicting names",
+			fsys: fstest.MapFS{
+				"sql/0001_first.up.sql":   {Data: []byte("SELECT 1")},
+				"sql/0001_other.down.sql": {Data: []byte("SELECT -1")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := loadMigrations(tt.fsys, "sql")
+
+			assert.NotNil(t, err)
+			assert.Nil(t, migrations)
+		})
+	}
+}
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS todos_owner_id_idx;
+
+ALTER TABLE todos
+    DROP COLUMN IF EXISTS owner_id;
+
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS users (
+    id SERIAL PRIMARY KEY,
+    email TEXT NOT NULL,
+    password TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT users_email_key UNIQUE (email)
+);
+
+-- existing todos get no owner here, 0012 requires them to be backfilled
+ALTER TABLE todos
+    ADD COLUMN owner_id INTEGER REFERENCES users (id) ON DELETE CASCADE;
+
+CREATE INDEX todos_owner_id_idx ON todos (owner_id);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS todos_owner_id_due_at_idx;
+
+ALTER TABLE todos
+    DROP CONSTRAINT IF EXISTS todos_remind_before_due_check,
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS completed_at,
+    DROP COLUMN IF EXISTS remind_at,
+    DROP COLUMN IF EXISTS due_at;
@@ -0,0 +1,11 @@
+ALTER TABLE todos
+    ADD COLUMN due_at TIMESTAMPTZ,
+    ADD COLUMN remind_at TIMESTAMPTZ,
+    ADD COLUMN completed_at TIMESTAMPTZ,
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD CONSTRAINT todos_remind_before_due_check CHECK (remind_at IS NULL OR due_at IS NULL OR remind_at < due_at);
+
+UPDATE todos SET completed_at = NOW() WHERE completed;
+
+CREATE INDEX todos_owner_id_due_at_idx ON todos (owner_id, due_at) WHERE NOT completed;
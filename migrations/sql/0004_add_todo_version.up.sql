@@ -0,0 +1,2 @@
+ALTER TABLE todos
+    ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
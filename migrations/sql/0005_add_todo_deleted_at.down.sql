@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS todos_deleted_at_idx;
+
+-- without the column trashed todos would come back, delete them for good
+DELETE FROM todos WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE todos
+    DROP COLUMN IF EXISTS deleted_at;
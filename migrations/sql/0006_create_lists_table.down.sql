@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS todos_list_id_idx;
+
+ALTER TABLE todos
+    DROP COLUMN IF EXISTS list_id;
+
+DROP TABLE IF EXISTS lists;
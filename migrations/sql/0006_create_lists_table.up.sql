@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS lists (
+    id SERIAL PRIMARY KEY,
+    owner_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    archived_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT lists_owner_id_name_key UNIQUE (owner_id, name)
+);
+
+ALTER TABLE todos
+    ADD COLUMN list_id INTEGER REFERENCES lists (id) ON DELETE SET NULL;
+
+CREATE INDEX todos_list_id_idx ON todos (list_id) WHERE list_id IS NOT NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE todos
+    DROP CONSTRAINT IF EXISTS todos_recurrence_due_check,
+    DROP COLUMN IF EXISTS next_occurrence_id,
+    DROP COLUMN IF EXISTS occurrence,
+    DROP COLUMN IF EXISTS recurrence;
@@ -0,0 +1,5 @@
+ALTER TABLE todos
+    ADD COLUMN recurrence TEXT,
+    ADD COLUMN occurrence INTEGER NOT NULL DEFAULT 1,
+    ADD COLUMN next_occurrence_id INTEGER REFERENCES todos(id) ON DELETE SET NULL,
+    ADD CONSTRAINT todos_recurrence_due_check CHECK (recurrence IS NULL OR due_at IS NOT NULL);
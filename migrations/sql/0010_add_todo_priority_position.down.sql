@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS todos_owner_id_position_idx;
+
+ALTER TABLE todos
+    DROP CONSTRAINT IF EXISTS todos_priority_check,
+    DROP COLUMN IF EXISTS position,
+    DROP COLUMN IF EXISTS priority;
@@ -0,0 +1,18 @@
+ALTER TABLE todos
+    ADD COLUMN priority SMALLINT NOT NULL DEFAULT 0,
+    ADD COLUMN position TEXT COLLATE "C",
+    ADD CONSTRAINT todos_priority_check CHECK (priority BETWEEN 0 AND 4);
+
+-- existing todos keep their id order, as positions d0001, d0002, ... per owner
+UPDATE todos
+SET position = 'd' || (
+    SELECT string_agg(substr('0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz', (ranked.n / (62 ^ digit)::bigint % 62)::integer + 1, 1), '' ORDER BY digit DESC)
+    FROM generate_series(0, 3) AS digit
+)
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY owner_id ORDER BY id) AS n FROM todos) AS ranked
+WHERE todos.id = ranked.id;
+
+ALTER TABLE todos
+    ALTER COLUMN position SET NOT NULL;
+
+CREATE UNIQUE INDEX todos_owner_id_position_idx ON todos (owner_id, position);
@@ -0,0 +1,8 @@
+-- the simple configuration lower cases words without stemming them, so
+-- searches match whole words or prefixes the same way in memory
+ALTER TABLE todos
+    ADD COLUMN search tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', title), 'A') || setweight(to_tsvector('simple', description), 'B')
+    ) STORED;
+
+CREATE INDEX todos_search_idx ON todos USING GIN (search);
@@ -0,0 +1,2 @@
+ALTER TABLE todos
+    ALTER COLUMN owner_id DROP NOT NULL;
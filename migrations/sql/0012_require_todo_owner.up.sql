@@ -0,0 +1,14 @@
+-- 0002 added owner_id without a value for the todos that already existed,
+-- which no owner scoped query returns. They cannot be assigned automatically,
+-- so this migration stops until they are given an owner or deleted, e.g.
+--   UPDATE todos SET owner_id = <user id> WHERE owner_id IS NULL;
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM todos WHERE owner_id IS NULL) THEN
+        RAISE EXCEPTION 'todos without owner_id found, assign them with UPDATE todos SET owner_id = <user id> WHERE owner_id IS NULL or delete them, then migrate again';
+    END IF;
+END
+$$;
+
+ALTER TABLE todos
+    ALTER COLUMN owner_id SET NOT NULL;
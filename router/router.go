@@ -1,8 +1,16 @@
 package router
 
 import (
+	"assignment-4/config"
+	"assignment-4/controllers/health_controller"
+	"assignment-4/controllers/list_controller"
+	"assignment-4/controllers/problem_controller"
+	"assignment-4/controllers/tag_controller"
 	"assignment-4/controllers/todo_controller"
-	"assignment-4/db"
+	"assignment-4/controllers/user_controller"
+	"assignment-4/metrics"
+	"assignment-4/middlewares"
+	"strings"
 
 	"assignment-4/docs"
 
@@ -13,30 +21,95 @@ import (
 	// swagger embed files
 )
 
-var PORT = ":8080"
+func swaggerHost(addr string) string {
+	if strings.HasPrefix(addr, ":") {
+		return "localhost" + addr
+	}
 
-func init() {
-	db.InitializeDB()
+	return addr
 }
 
-func StartRouter() {
-	route := gin.Default()
+// New builds the HTTP handler with every route registered. It does not touch
+// the database, see package app for the application lifecycle.
+func New(cfg *config.Config) *gin.Engine {
+	if cfg.Log.Level != "debug" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	route := gin.New()
+	route.Use(middlewares.RequestId())
+	route.Use(middlewares.Tracing(cfg.Tracing.ServiceName))
+	route.Use(middlewares.Logger())
+	// Metrics wraps Recovery so requests that panic are counted as 500s
+	route.Use(middlewares.Metrics())
+	route.Use(middlewares.Recovery())
+	route.Use(middlewares.Timeout(cfg.HTTP.RequestTimeout))
 
 	docs.SwaggerInfo.Title = "Example Swagger TODO Rest API"
 	docs.SwaggerInfo.Description = "Documentation of TODO Rest API"
 	docs.SwaggerInfo.Version = "1.0"
-	docs.SwaggerInfo.Host = "localhost" + PORT
+	docs.SwaggerInfo.Host = swaggerHost(cfg.HTTP.Addr)
 	docs.SwaggerInfo.Schemes = []string{"http"}
 
 	route.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	route.GET("/metrics", gin.WrapH(metrics.Handler()))
+	route.GET("/healthz", health_controller.Healthz)
+	route.GET("/readyz", health_controller.Readyz)
+	route.GET("/problems", problem_controller.GetProblemTypes)
+	route.GET("/problems/:code", problem_controller.GetProblemType)
+	userRoute := route.Group("/users")
+	{
+		userRoute.POST("/register", user_controller.Register)
+		userRoute.POST("/login", user_controller.Login)
+		userRoute.POST("/refresh", user_controller.RefreshToken)
+	}
+
 	todoRoute := route.Group("/todo")
+	todoRoute.Use(middlewares.Authentication())
 	{
 		todoRoute.POST("/", todo_controller.CreateTodo)
+		todoRoute.POST("/batch", todo_controller.ApplyBatch)
+		todoRoute.GET("/trash", todo_controller.GetTrash)
+		todoRoute.GET("/search", todo_controller.SearchTodos)
 		todoRoute.GET("/:todoId", todo_controller.GetTodoById)
 		todoRoute.GET("/", todo_controller.GetAllTodos)
 		todoRoute.PUT("/:todoId", todo_controller.UpdateTodo)
+		todoRoute.PATCH("/:todoId", todo_controller.PatchTodo)
 		todoRoute.DELETE("/:todoId", todo_controller.DeleteTodoById)
+		todoRoute.POST("/:todoId/restore", todo_controller.RestoreTodoById)
+		todoRoute.GET("/:todoId/checklist", todo_controller.GetChecklist)
+		todoRoute.POST("/:todoId/checklist", todo_controller.AddChecklistItem)
+		todoRoute.PUT("/:todoId/checklist/order", todo_controller.ReorderChecklist)
+		todoRoute.PATCH("/:todoId/checklist/:itemId", todo_controller.UpdateChecklistItem)
+		todoRoute.DELETE("/:todoId/checklist/:itemId", todo_controller.DeleteChecklistItem)
+		todoRoute.POST("/:todoId/checklist/:itemId/toggle", todo_controller.ToggleChecklistItem)
+		todoRoute.GET("/:todoId/occurrences", todo_controller.GetOccurrences)
+		todoRoute.POST("/:todoId/move", todo_controller.MoveTodo)
+	}
+
+	listRoute := route.Group("/lists")
+	listRoute.Use(middlewares.Authentication())
+	{
+		listRoute.POST("/", list_controller.CreateList)
+		listRoute.GET("/", list_controller.GetAllLists)
+		listRoute.GET("/:listId", list_controller.GetListById)
+		listRoute.PUT("/:listId", list_controller.UpdateList)
+		listRoute.DELETE("/:listId", list_controller.DeleteListById)
+		listRoute.POST("/:listId/archive", list_controller.ArchiveList)
+		listRoute.POST("/:listId/unarchive", list_controller.UnarchiveList)
+		listRoute.GET("/:listId/todos", list_controller.GetListTodos)
+		listRoute.POST("/:listId/todos", list_controller.MoveTodos)
+	}
+
+	tagRoute := route.Group("/tags")
+	tagRoute.Use(middlewares.Authentication())
+	{
+		tagRoute.POST("/", tag_controller.CreateTag)
+		tagRoute.GET("/", tag_controller.GetAllTags)
+		tagRoute.GET("/:tagId", tag_controller.GetTagById)
+		tagRoute.PUT("/:tagId", tag_controller.UpdateTag)
+		tagRoute.DELETE("/:tagId", tag_controller.DeleteTagById)
 	}
 
-	route.Run(PORT)
+	return route
 }
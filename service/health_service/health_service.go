@@ -0,0 +1,133 @@
+package health_service
+
+import (
+	"assignment-4/db"
+	"assignment-4/migrations"
+	"assignment-4/utils/logger_utils"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const (
+	StatusOk   = "ok"
+	StatusFail = "fail"
+
+	// readiness is public, so checks only report these fixed messages and
+	// the errors behind them go to the log
+	messageDatabaseUnreachable = "database unreachable"
+	messageMigrationsUnknown   = "migration version unavailable"
+	messageMigrationsPending   = "migrations pending"
+
+	defaultPingTimeout = 2 * time.Second
+)
+
+type Check struct {
+	Status string `json:"status" example:"ok"`
+	Error  string `json:"error,omitempty"`
+}
+
+type PoolStats struct {
+	MaxOpenConnections int    `json:"max_open_connections" example:"25"`
+	OpenConnections    int    `json:"open_connections" example:"3"`
+	InUse              int    `json:"in_use" example:"1"`
+	Idle               int    `json:"idle" example:"2"`
+	WaitCount          int64  `json:"wait_count" example:"0"`
+	WaitDuration       string `json:"wait_duration" example:"0s"`
+}
+
+type Readiness struct {
+	Status           string           `json:"status" example:"ok"`
+	Checks           map[string]Check `json:"checks"`
+	MigrationVersion int64            `json:"migration_version,omitempty" example:"3"`
+	LatestMigration  int64            `json:"latest_migration,omitempty" example:"3"`
+	Pool             *PoolStats       `json:"pool,omitempty"`
+}
+
+var HealthService healthServiceInterface = NewHealthService(defaultPingTimeout)
+
+type healthServiceInterface interface {
+	Readiness(context.Context) *Readiness
+}
+
+type healthService struct {
+	pingTimeout time.Duration
+	getDB       func() *sql.DB
+}
+
+func NewHealthService(pingTimeout time.Duration) healthServiceInterface {
+	return &healthService{
+		pingTimeout: pingTimeout,
+		getDB:       db.GetDB,
+	}
+}
+
+// Readiness reports whether the app can serve traffic. Without a database
+// (the in-memory repository) it is always ready.
+func (h *healthService) Readiness(ctx context.Context) *Readiness {
+	readiness := &Readiness{
+		Status: StatusOk,
+		Checks: map[string]Check{},
+	}
+
+	conn := h.getDB()
+	if conn == nil {
+		readiness.Checks["repository"] = Check{Status: StatusOk}
+		return readiness
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.pingTimeout)
+	defer cancel()
+
+	if err := conn.PingContext(ctx); err != nil {
+		readiness.fail(ctx, "database", messageDatabaseUnreachable, err)
+	} else {
+		readiness.Checks["database"] = Check{Status: StatusOk}
+		readiness.checkMigrations(ctx, conn)
+	}
+
+	stats := conn.Stats()
+	readiness.Pool = &PoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDuration:       stats.WaitDuration.String(),
+	}
+
+	return readiness
+}
+
+func (r *Readiness) checkMigrations(ctx context.Context, conn *sql.DB) {
+	latest, err := migrations.Latest()
+	if err != nil {
+		r.fail(ctx, "migrations", messageMigrationsUnknown, err)
+		return
+	}
+	r.LatestMigration = latest
+
+	current, err := migrations.CurrentVersion(ctx, conn)
+	if err != nil {
+		r.fail(ctx, "migrations", messageMigrationsUnknown, err)
+		return
+	}
+	r.MigrationVersion = current
+
+	// A newer replica may already have migrated past latest during a rolling
+	// deploy; this one keeps serving until it is replaced.
+	if current < latest {
+		r.fail(ctx, "migrations", messageMigrationsPending, fmt.Errorf("database is at migration %d, expected %d", current, latest))
+		return
+	}
+
+	r.Checks["migrations"] = Check{Status: StatusOk}
+}
+
+func (r *Readiness) fail(ctx context.Context, name string, message string, err error) {
+	logger_utils.Ctx(ctx).Error().Err(err).Str("check", name).Msg("readiness check failed: " + message)
+
+	r.Status = StatusFail
+	r.Checks[name] = Check{Status: StatusFail, Error: message}
+}
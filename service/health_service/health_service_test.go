@@ -0,0 +1,104 @@
+package health_service
+
+import (
+	"assignment-4/migrations"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newServiceWithMock(t *testing.T) (*healthService, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.Nil(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return &healthService{
+		pingTimeout: time.Second,
+		getDB:       func() *sql.DB { return conn },
+	}, mock
+}
+
+func TestHealthService_Readiness_NoDatabase(t *testing.T) {
+	service := &healthService{pingTimeout: time.Second, getDB: func() *sql.DB { return nil }}
+
+	readiness := service.Readiness(context.Background())
+
+	assert.EqualValues(t, StatusOk, readiness.Status)
+	assert.Nil(t, readiness.Pool)
+}
+
+func TestHealthService_Readiness_Ok(t *testing.T) {
+	latest, err := migrations.Latest()
+	require.Nil(t, err)
+
+	service, mock := newServiceWithMock(t)
+	mock.ExpectPing()
+	mock.ExpectQuery("SELECT COALESCE").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(latest))
+
+	readiness := service.Readiness(context.Background())
+
+	assert.EqualValues(t, StatusOk, readiness.Status)
+	assert.EqualValues(t, StatusOk, readiness.Checks["database"].Status)
+	assert.EqualValues(t, StatusOk, readiness.Checks["migrations"].Status)
+	assert.EqualValues(t, latest, readiness.MigrationVersion)
+	assert.NotNil(t, readiness.Pool)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestHealthService_Readiness_PingFails(t *testing.T) {
+	service, mock := newServiceWithMock(t)
+	mock.ExpectPing().WillReturnError(errors.New("dial tcp db.internal:5432: connection refused"))
+
+	readiness := service.Readiness(context.Background())
+
+	assert.EqualValues(t, StatusFail, readiness.Status)
+	assert.EqualValues(t, StatusFail, readiness.Checks["database"].Status)
+	assert.EqualValues(t, "database unreachable", readiness.Checks["database"].Error)
+	assert.NotContains(t, readiness.Checks, "migrations")
+}
+
+func TestHealthService_Readiness_PendingMigrations(t *testing.T) {
+	service, mock := newServiceWithMock(t)
+	mock.ExpectPing()
+	mock.ExpectQuery("SELECT COALESCE").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(0))
+
+	readiness := service.Readiness(context.Background())
+
+	assert.EqualValues(t, StatusFail, readiness.Status)
+	assert.EqualValues(t, StatusOk, readiness.Checks["database"].Status)
+	assert.EqualValues(t, StatusFail, readiness.Checks["migrations"].Status)
+	assert.EqualValues(t, "migrations pending", readiness.Checks["migrations"].Error)
+}
+
+func TestHealthService_Readiness_DatabaseAheadOfBinary(t *testing.T) {
+	latest, err := migrations.Latest()
+	require.Nil(t, err)
+
+	service, mock := newServiceWithMock(t)
+	mock.ExpectPing()
+	mock.ExpectQuery("SELECT COALESCE").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(latest + 1))
+
+	readiness := service.Readiness(context.Background())
+
+	assert.EqualValues(t, StatusOk, readiness.Status)
+	assert.EqualValues(t, StatusOk, readiness.Checks["migrations"].Status)
+	assert.EqualValues(t, latest+1, readiness.MigrationVersion)
+	assert.EqualValues(t, latest, readiness.LatestMigration)
+}
+
+func TestHealthService_Readiness_MigrationQueryFails(t *testing.T) {
+	service, mock := newServiceWithMock(t)
+	mock.ExpectPing()
+	mock.ExpectQuery("SELECT COALESCE").WillReturnError(errors.New(`pq: relation "schema_migrations" does not exist`))
+
+	readiness := service.Readiness(context.Background())
+
+	assert.EqualValues(t, StatusFail, readiness.Status)
+	assert.EqualValues(t, "migration version unavailable", readiness.Checks["migrations"].Error)
+}
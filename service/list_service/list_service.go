@@ -0,0 +1,209 @@
+package list_service
+
+import (
+	"assignment-4/domain/list_domain"
+	"assignment-4/domain/todo_domain"
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/logger_utils"
+	"context"
+)
+
+var ListService listServiceInterface = &listService{}
+
+type listServiceInterface interface {
+	CreateList(context.Context, *list_domain.List) (*list_domain.List, error_utils.MessageErr)
+	UpdateList(context.Context, *list_domain.List) (*list_domain.List, error_utils.MessageErr)
+	ArchiveList(context.Context, int64, int64, bool) (*list_domain.List, error_utils.MessageErr)
+	GetListById(context.Context, int64, int64) (*list_domain.List, error_utils.MessageErr)
+	GetAllLists(context.Context, *list_domain.ListQuery) (*list_domain.ListCollection, error_utils.MessageErr)
+	DeleteListById(context.Context, int64, int64) error_utils.MessageErr
+	GetListTodos(context.Context, int64, *todo_domain.TodoQuery) (*todo_domain.TodoPage, error_utils.MessageErr)
+	MoveTodos(context.Context, int64, int64, *list_domain.MoveTodosRequest) (*list_domain.List, error_utils.MessageErr)
+}
+
+type listService struct{}
+
+func (l *listService) CreateList(ctx context.Context, listReq *list_domain.List) (*list_domain.List, error_utils.MessageErr) {
+	err := listReq.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := list_domain.ListDomain.CreateList(ctx, listReq)
+
+	if err != nil {
+		return nil, err
+	}
+
+	logger_utils.Ctx(ctx).Info().Int64("list_id", res.Id).Int64("owner_id", res.OwnerId).Msg("list created")
+
+	return res, nil
+}
+
+func (l *listService) UpdateList(ctx context.Context, listReq *list_domain.List) (*list_domain.List, error_utils.MessageErr) {
+	err := listReq.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := list_domain.ListDomain.UpdateList(ctx, listReq)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return withCounts(ctx, res)
+}
+
+// ArchiveList archives or unarchives the list. Todos stay in an archived
+// list, but no todo can be added to it.
+func (l *listService) ArchiveList(ctx context.Context, listId int64, ownerId int64, archived bool) (*list_domain.List, error_utils.MessageErr) {
+	res, err := list_domain.ListDomain.ArchiveList(ctx, listId, ownerId, archived)
+
+	if err != nil {
+		return nil, err
+	}
+
+	logger_utils.Ctx(ctx).Info().Int64("list_id", listId).Int64("owner_id", ownerId).Bool("archived", archived).Msg("list archive state changed")
+
+	return withCounts(ctx, res)
+}
+
+func (l *listService) GetListById(ctx context.Context, listId int64, ownerId int64) (*list_domain.List, error_utils.MessageErr) {
+	res, err := list_domain.ListDomain.GetListById(ctx, listId, ownerId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return withCounts(ctx, res)
+}
+
+func (l *listService) GetAllLists(ctx context.Context, query *list_domain.ListQuery) (*list_domain.ListCollection, error_utils.MessageErr) {
+	lists, err := list_domain.ListDomain.GetAllLists(ctx, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := todo_domain.TodoDomain.CountTodosByList(ctx, query.OwnerId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range lists {
+		setCounts(&lists[i], counts)
+	}
+
+	return &list_domain.ListCollection{Lists: lists}, nil
+}
+
+// DeleteListById deletes the list and keeps its todos, which end up in no
+// list. Both happen in one transaction, so a failed delete leaves the todos
+// in the list.
+func (l *listService) DeleteListById(ctx context.Context, listId int64, ownerId int64) error_utils.MessageErr {
+	err := todo_domain.TodoDomain.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+		_, err := list_domain.ListDomain.GetListById(ctx, listId, ownerId)
+
+		if err != nil {
+			return err
+		}
+
+		err = todo_domain.TodoDomain.UnassignList(ctx, ownerId, listId)
+
+		if err != nil {
+			return err
+		}
+
+		return list_domain.ListDomain.DeleteListById(ctx, listId, ownerId)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	logger_utils.Ctx(ctx).Info().Int64("list_id", listId).Int64("owner_id", ownerId).Msg("list deleted")
+
+	return nil
+}
+
+func (l *listService) GetListTodos(ctx context.Context, listId int64, query *todo_domain.TodoQuery) (*todo_domain.TodoPage, error_utils.MessageErr) {
+	_, err := list_domain.ListDomain.GetListById(ctx, listId, query.OwnerId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	query.ListId = &listId
+
+	return todo_domain.TodoDomain.GetAllTodos(ctx, query)
+}
+
+// MoveTodos puts the requested todos into the list, wherever they were
+// before. Either all of them are moved or, when one of them is not found,
+// none are.
+func (l *listService) MoveTodos(ctx context.Context, listId int64, ownerId int64, moveReq *list_domain.MoveTodosRequest) (*list_domain.List, error_utils.MessageErr) {
+	err := moveReq.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := list_domain.ListDomain.GetListById(ctx, listId, ownerId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if list.IsArchived() {
+		return nil, list_domain.NewListArchivedError()
+	}
+
+	err = todo_domain.TodoDomain.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+		moved, err := todo_domain.TodoDomain.MoveTodos(ctx, ownerId, moveReq.TodoIds, &listId)
+
+		if err != nil {
+			return err
+		}
+
+		if moved != int64(len(moveReq.TodoIds)) {
+			return error_utils.NewNotFoundError("one or more todos were not found")
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	logger_utils.Ctx(ctx).Info().Int64("list_id", listId).Int64("owner_id", ownerId).Int("moved", len(moveReq.TodoIds)).Msg("todos moved")
+
+	return withCounts(ctx, list)
+}
+
+func withCounts(ctx context.Context, list *list_domain.List) (*list_domain.List, error_utils.MessageErr) {
+	counts, err := todo_domain.TodoDomain.CountTodosByList(ctx, list.OwnerId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	setCounts(list, counts)
+
+	return list, nil
+}
+
+func setCounts(list *list_domain.List, counts map[int64]todo_domain.TodoCounts) {
+	list.TodoCount = counts[list.Id].Total
+	list.CompletedCount = counts[list.Id].Completed
+}
@@ -0,0 +1,335 @@
+package list_service
+
+import (
+	"assignment-4/domain/list_domain"
+	"assignment-4/domain/todo_domain"
+	"assignment-4/utils/error_utils"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	createList     func(list *list_domain.List) (*list_domain.List, error_utils.MessageErr)
+	updateList     func(list *list_domain.List) (*list_domain.List, error_utils.MessageErr)
+	archiveList    func(listId int64, ownerId int64, archived bool) (*list_domain.List, error_utils.MessageErr)
+	getListById    func(listId int64, ownerId int64) (*list_domain.List, error_utils.MessageErr)
+	getAllLists    func(query *list_domain.ListQuery) ([]list_domain.List, error_utils.MessageErr)
+	deleteListById func(listId int64, ownerId int64) error_utils.MessageErr
+)
+
+type listDomainMock struct{}
+
+func (l *listDomainMock) CreateList(ctx context.Context, list *list_domain.List) (*list_domain.List, error_utils.MessageErr) {
+	return createList(list)
+}
+
+func (l *listDomainMock) UpdateList(ctx context.Context, list *list_domain.List) (*list_domain.List, error_utils.MessageErr) {
+	return updateList(list)
+}
+
+func (l *listDomainMock) ArchiveList(ctx context.Context, listId int64, ownerId int64, archived bool) (*list_domain.List, error_utils.MessageErr) {
+	return archiveList(listId, ownerId, archived)
+}
+
+func (l *listDomainMock) GetListById(ctx context.Context, listId int64, ownerId int64) (*list_domain.List, error_utils.MessageErr) {
+	return getListById(listId, ownerId)
+}
+
+func (l *listDomainMock) GetAllLists(ctx context.Context, query *list_domain.ListQuery) ([]list_domain.List, error_utils.MessageErr) {
+	return getAllLists(query)
+}
+
+func (l *listDomainMock) DeleteListById(ctx context.Context, listId int64, ownerId int64) error_utils.MessageErr {
+	return deleteListById(listId, ownerId)
+}
+
+// newTodos fills a todo memory repo with todos of owner 1: two in list 1,
+// one of them completed, and one in no list.
+func newTodos(t *testing.T) {
+	todo_domain.TodoDomain = todo_domain.NewTodoMemoryRepo()
+
+	listId := int64(1)
+	todos := []todo_domain.Todo{
+		{Title: "Homework", Description: "Math", OwnerId: 1, ListId: &listId},
+		{Title: "Essay", Description: "History", OwnerId: 1, ListId: &listId, Completed: true},
+		{Title: "Groceries", Description: "Milk", OwnerId: 1},
+	}
+
+	for i := range todos {
+		_, err := todo_domain.TodoDomain.CreateTodo(context.Background(), &todos[i])
+		require.Nil(t, err)
+	}
+}
+
+func foundList(listId int64, ownerId int64) (*list_domain.List, error_utils.MessageErr) {
+	return &list_domain.List{Id: listId, Name: "School", OwnerId: ownerId}, nil
+}
+
+// ----------------
+// Test Create List
+
+func TestListService_CreateList_Success(t *testing.T) {
+	list_domain.ListDomain = &listDomainMock{}
+
+	createList = func(list *list_domain.List) (*list_domain.List, error_utils.MessageErr) {
+		created := *list
+		created.Id = 1
+		return &created, nil
+	}
+
+	list, err := ListService.CreateList(context.Background(), &list_domain.List{Name: "  School ", OwnerId: 1})
+
+	assert.Nil(t, err)
+	require.NotNil(t, list)
+	assert.EqualValues(t, 1, list.Id)
+	assert.EqualValues(t, "School", list.Name)
+}
+
+func TestListService_CreateList_BadRequest(t *testing.T) {
+	list_domain.ListDomain = &listDomainMock{}
+
+	list, err := ListService.CreateList(context.Background(), &list_domain.List{Name: " ", OwnerId: 1})
+
+	assert.Nil(t, list)
+	require.NotNil(t, err)
+	assert.EqualValues(t, "bad_request", err.Error())
+	assert.EqualValues(t, "name is required", err.Message())
+	assert.EqualValues(t, http.StatusBadRequest, err.Status())
+}
+
+func TestListService_CreateList_Conflict(t *testing.T) {
+	list_domain.ListDomain = &listDomainMock{}
+
+	createList = func(list *list_domain.List) (*list_domain.List, error_utils.MessageErr) {
+		return nil, error_utils.NewConflictError("a list with this name already exists")
+	}
+
+	list, err := ListService.CreateList(context.Background(), &list_domain.List{Name: "School", OwnerId: 1})
+
+	assert.Nil(t, list)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusConflict, err.Status())
+	assert.EqualValues(t, "a list with this name already exists", err.Message())
+}
+
+// ----------------
+// Test Get List
+
+func TestListService_GetListById_Counts(t *testing.T) {
+	list_domain.ListDomain = &listDomainMock{}
+	newTodos(t)
+
+	getListById = foundList
+
+	list, err := ListService.GetListById(context.Background(), 1, 1)
+
+	assert.Nil(t, err)
+	require.NotNil(t, list)
+	assert.EqualValues(t, 2, list.TodoCount)
+	assert.EqualValues(t, 1, list.CompletedCount)
+}
+
+func TestListService_GetListById_NotFoundError(t *testing.T) {
+	list_domain.ListDomain = &listDomainMock{}
+
+	getListById = func(listId int64, ownerId int64) (*list_domain.List, error_utils.MessageErr) {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	list, err := ListService.GetListById(context.Background(), 1, 1)
+
+	assert.Nil(t, list)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusNotFound, err.Status())
+}
+
+func TestListService_GetAllLists_Counts(t *testing.T) {
+	list_domain.ListDomain = &listDomainMock{}
+	newTodos(t)
+
+	getAllLists = func(query *list_domain.ListQuery) ([]list_domain.List, error_utils.MessageErr) {
+		assert.True(t, query.Archived)
+		return []list_domain.List{{Id: 1, Name: "School"}, {Id: 2, Name: "Empty"}}, nil
+	}
+
+	res, err := ListService.GetAllLists(context.Background(), &list_domain.ListQuery{OwnerId: 1, Archived: true})
+
+	assert.Nil(t, err)
+	require.NotNil(t, res)
+	require.Len(t, res.Lists, 2)
+	assert.EqualValues(t, 2, res.Lists[0].TodoCount)
+	assert.EqualValues(t, 1, res.Lists[0].CompletedCount)
+	assert.EqualValues(t, 0, res.Lists[1].TodoCount)
+}
+
+// ----------------
+// Test Delete List
+
+func TestListService_DeleteListById_KeepsTodos(t *testing.T) {
+	list_domain.ListDomain = &listDomainMock{}
+	newTodos(t)
+
+	getListById = foundList
+	deleteListById = func(listId int64, ownerId int64) error_utils.MessageErr {
+		return nil
+	}
+
+	err := ListService.DeleteListById(context.Background(), 1, 1)
+
+	assert.Nil(t, err)
+
+	page, err := todo_domain.TodoDomain.GetAllTodos(context.Background(), &todo_domain.TodoQuery{OwnerId: 1, Limit: 10})
+	require.Nil(t, err)
+	require.Len(t, page.Todos, 3)
+	for _, todo := range page.Todos {
+		assert.Nil(t, todo.ListId)
+	}
+}
+
+func TestListService_DeleteListById_RollsBack(t *testing.T) {
+	list_domain.ListDomain = &listDomainMock{}
+	newTodos(t)
+
+	getListById = foundList
+	deleteListById = func(listId int64, ownerId int64) error_utils.MessageErr {
+		return error_utils.NewInternalServerError("something went wrong")
+	}
+
+	err := ListService.DeleteListById(context.Background(), 1, 1)
+
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusInternalServerError, err.Status())
+
+	counts, _ := todo_domain.TodoDomain.CountTodosByList(context.Background(), 1)
+	assert.EqualValues(t, 2, counts[1].Total)
+}
+
+func TestListService_DeleteListById_NotFoundError(t *testing.T) {
+	list_domain.ListDomain = &listDomainMock{}
+	newTodos(t)
+
+	getListById = func(listId int64, ownerId int64) (*list_domain.List, error_utils.MessageErr) {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	err := ListService.DeleteListById(context.Background(), 1, 1)
+
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusNotFound, err.Status())
+
+	counts, _ := todo_domain.TodoDomain.CountTodosByList(context.Background(), 1)
+	assert.EqualValues(t, 2, counts[1].Total)
+}
+
+// ----------------
+// Test Move Todos
+
+func TestListService_MoveTodos_Success(t *testing.T) {
+	list_domain.ListDomain = &listDomainMock{}
+	newTodos(t)
+
+	getListById = foundList
+
+	list, err := ListService.MoveTodos(context.Background(), 2, 1, &list_domain.MoveTodosRequest{TodoIds: []int64{2, 3, 3}})
+
+	assert.Nil(t, err)
+	require.NotNil(t, list)
+	assert.EqualValues(t, 2, list.Id)
+	assert.EqualValues(t, 2, list.TodoCount)
+	assert.EqualValues(t, 1, list.CompletedCount)
+
+	todo, _ := todo_domain.TodoDomain.GetTodoById(context.Background(), 1, 1)
+	assert.EqualValues(t, 1, *todo.ListId)
+}
+
+func TestListService_MoveTodos_NotFoundRollsBack(t *testing.T) {
+	list_domain.ListDomain = &listDomainMock{}
+	newTodos(t)
+
+	getListById = foundList
+
+	list, err := ListService.MoveTodos(context.Background(), 2, 1, &list_domain.MoveTodosRequest{TodoIds: []int64{3, 99}})
+
+	assert.Nil(t, list)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusNotFound, err.Status())
+
+	todo, _ := todo_domain.TodoDomain.GetTodoById(context.Background(), 3, 1)
+	assert.Nil(t, todo.ListId)
+}
+
+func TestListService_MoveTodos_Archived(t *testing.T) {
+	list_domain.ListDomain = &listDomainMock{}
+	newTodos(t)
+
+	getListById = func(listId int64, ownerId int64) (*list_domain.List, error_utils.MessageErr) {
+		archivedAt := time.Now()
+		return &list_domain.List{Id: listId, Name: "School", OwnerId: ownerId, ArchivedAt: &archivedAt}, nil
+	}
+
+	list, err := ListService.MoveTodos(context.Background(), 2, 1, &list_domain.MoveTodosRequest{TodoIds: []int64{3}})
+
+	assert.Nil(t, list)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusConflict, err.Status())
+}
+
+func TestListService_MoveTodos_BadRequest(t *testing.T) {
+	list_domain.ListDomain = &listDomainMock{}
+
+	tests := []struct {
+		name    string
+		todoIds []int64
+		errMsg  string
+	}{
+		{name: "empty", todoIds: nil, errMsg: "todo_ids must not be empty"},
+		{name: "negative id", todoIds: []int64{1, -1}, errMsg: "todo_ids must be positive"},
+		{name: "too many", todoIds: make([]int64, list_domain.MaxMoveTodos+1), errMsg: "at most 100 todos can be moved at once"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := ListService.MoveTodos(context.Background(), 1, 1, &list_domain.MoveTodosRequest{TodoIds: tt.todoIds})
+
+			assert.Nil(t, list)
+			require.NotNil(t, err)
+			assert.EqualValues(t, http.StatusBadRequest, err.Status())
+			assert.EqualValues(t, tt.errMsg, err.Message())
+		})
+	}
+}
+
+// ----------------
+// Test Get List Todos
+
+func TestListService_GetListTodos_Success(t *testing.T) {
+	list_domain.ListDomain = &listDomainMock{}
+	newTodos(t)
+
+	getListById = foundList
+
+	page, err := ListService.GetListTodos(context.Background(), 1, &todo_domain.TodoQuery{OwnerId: 1, Limit: 10})
+
+	assert.Nil(t, err)
+	require.NotNil(t, page)
+	assert.Len(t, page.Todos, 2)
+}
+
+func TestListService_GetListTodos_NotFoundError(t *testing.T) {
+	list_domain.ListDomain = &listDomainMock{}
+
+	getListById = func(listId int64, ownerId int64) (*list_domain.List, error_utils.MessageErr) {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	page, err := ListService.GetListTodos(context.Background(), 1, &todo_domain.TodoQuery{OwnerId: 1, Limit: 10})
+
+	assert.Nil(t, page)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusNotFound, err.Status())
+}
@@ -0,0 +1,148 @@
+package tag_service
+
+import (
+	"assignment-4/domain/tag_domain"
+	"assignment-4/domain/todo_domain"
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/logger_utils"
+	"context"
+)
+
+var TagService tagServiceInterface = &tagService{}
+
+type tagServiceInterface interface {
+	CreateTag(context.Context, *tag_domain.Tag) (*tag_domain.Tag, error_utils.MessageErr)
+	UpdateTag(context.Context, *tag_domain.Tag) (*tag_domain.Tag, error_utils.MessageErr)
+	GetTagById(context.Context, int64, int64) (*tag_domain.Tag, error_utils.MessageErr)
+	GetAllTags(context.Context, int64) (*tag_domain.TagCollection, error_utils.MessageErr)
+	DeleteTagById(context.Context, int64, int64) error_utils.MessageErr
+}
+
+type tagService struct{}
+
+func (t *tagService) CreateTag(ctx context.Context, tagReq *tag_domain.Tag) (*tag_domain.Tag, error_utils.MessageErr) {
+	err := tagReq.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := tag_domain.TagDomain.CreateTag(ctx, tagReq)
+
+	if err != nil {
+		return nil, err
+	}
+
+	logger_utils.Ctx(ctx).Info().Int64("tag_id", res.Id).Int64("owner_id", res.OwnerId).Msg("tag created")
+
+	return res, nil
+}
+
+// UpdateTag renames or recolors the tag. Todos carrying it are returned with
+// the new name from then on; the tag and their versions change in one
+// transaction.
+func (t *tagService) UpdateTag(ctx context.Context, tagReq *tag_domain.Tag) (*tag_domain.Tag, error_utils.MessageErr) {
+	err := tagReq.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var res *tag_domain.Tag
+
+	err = todo_domain.TodoDomain.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+		current, err := tag_domain.TagDomain.GetTagById(ctx, tagReq.Id, tagReq.OwnerId)
+
+		if err != nil {
+			return err
+		}
+
+		res, err = tag_domain.TagDomain.UpdateTag(ctx, tagReq)
+
+		if err != nil {
+			return err
+		}
+
+		if res.Name != current.Name {
+			return todo_domain.TodoDomain.RenameTag(ctx, res.OwnerId, current.Name, res.Name)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return withCount(ctx, res)
+}
+
+func (t *tagService) GetTagById(ctx context.Context, tagId int64, ownerId int64) (*tag_domain.Tag, error_utils.MessageErr) {
+	res, err := tag_domain.TagDomain.GetTagById(ctx, tagId, ownerId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return withCount(ctx, res)
+}
+
+func (t *tagService) GetAllTags(ctx context.Context, ownerId int64) (*tag_domain.TagCollection, error_utils.MessageErr) {
+	tags, err := tag_domain.TagDomain.GetAllTags(ctx, ownerId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := todo_domain.TodoDomain.CountTodosByTag(ctx, ownerId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range tags {
+		tags[i].TodoCount = counts[tags[i].Name]
+	}
+
+	return &tag_domain.TagCollection{Tags: tags}, nil
+}
+
+// DeleteTagById takes the tag off its todos and deletes it, in one
+// transaction.
+func (t *tagService) DeleteTagById(ctx context.Context, tagId int64, ownerId int64) error_utils.MessageErr {
+	err := todo_domain.TodoDomain.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+		tag, err := tag_domain.TagDomain.GetTagById(ctx, tagId, ownerId)
+
+		if err != nil {
+			return err
+		}
+
+		err = todo_domain.TodoDomain.RemoveTag(ctx, ownerId, tag.Name)
+
+		if err != nil {
+			return err
+		}
+
+		return tag_domain.TagDomain.DeleteTagById(ctx, tagId, ownerId)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	logger_utils.Ctx(ctx).Info().Int64("tag_id", tagId).Int64("owner_id", ownerId).Msg("tag deleted")
+
+	return nil
+}
+
+func withCount(ctx context.Context, tag *tag_domain.Tag) (*tag_domain.Tag, error_utils.MessageErr) {
+	counts, err := todo_domain.TodoDomain.CountTodosByTag(ctx, tag.OwnerId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tag.TodoCount = counts[tag.Name]
+
+	return tag, nil
+}
@@ -0,0 +1,242 @@
+package tag_service
+
+import (
+	"assignment-4/domain/tag_domain"
+	"assignment-4/domain/todo_domain"
+	"assignment-4/utils/error_utils"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	createTag     func(tag *tag_domain.Tag) (*tag_domain.Tag, error_utils.MessageErr)
+	ensureTags    func(ownerId int64, names []string) error_utils.MessageErr
+	updateTag     func(tag *tag_domain.Tag) (*tag_domain.Tag, error_utils.MessageErr)
+	getTagById    func(tagId int64, ownerId int64) (*tag_domain.Tag, error_utils.MessageErr)
+	getAllTags    func(ownerId int64) ([]tag_domain.Tag, error_utils.MessageErr)
+	deleteTagById func(tagId int64, ownerId int64) error_utils.MessageErr
+)
+
+type tagDomainMock struct{}
+
+func (t *tagDomainMock) CreateTag(ctx context.Context, tag *tag_domain.Tag) (*tag_domain.Tag, error_utils.MessageErr) {
+	return createTag(tag)
+}
+
+func (t *tagDomainMock) EnsureTags(ctx context.Context, ownerId int64, names []string) error_utils.MessageErr {
+	return ensureTags(ownerId, names)
+}
+
+func (t *tagDomainMock) UpdateTag(ctx context.Context, tag *tag_domain.Tag) (*tag_domain.Tag, error_utils.MessageErr) {
+	return updateTag(tag)
+}
+
+func (t *tagDomainMock) GetTagById(ctx context.Context, tagId int64, ownerId int64) (*tag_domain.Tag, error_utils.MessageErr) {
+	return getTagById(tagId, ownerId)
+}
+
+func (t *tagDomainMock) GetAllTags(ctx context.Context, ownerId int64) ([]tag_domain.Tag, error_utils.MessageErr) {
+	return getAllTags(ownerId)
+}
+
+func (t *tagDomainMock) DeleteTagById(ctx context.Context, tagId int64, ownerId int64) error_utils.MessageErr {
+	return deleteTagById(tagId, ownerId)
+}
+
+// newTodos fills a todo memory repo with todos of owner 1: two tagged
+// school, one of them also urgent, and a trashed one tagged urgent.
+func newTodos(t *testing.T) {
+	todo_domain.TodoDomain = todo_domain.NewTodoMemoryRepo()
+
+	todos := []todo_domain.Todo{
+		{Title: "Homework", Description: "Math", OwnerId: 1, Tags: []string{"school", "urgent"}},
+		{Title: "Essay", Description: "History", OwnerId: 1, Tags: []string{"school"}},
+		{Title: "Taxes", Description: "Last year", OwnerId: 1, Tags: []string{"urgent"}},
+	}
+
+	for i := range todos {
+		_, err := todo_domain.TodoDomain.CreateTodo(context.Background(), &todos[i])
+		require.Nil(t, err)
+	}
+
+	_, err := todo_domain.TodoDomain.DeleteTodoById(context.Background(), 3, 1, 0)
+	require.Nil(t, err)
+}
+
+func foundTag(name string) func(tagId int64, ownerId int64) (*tag_domain.Tag, error_utils.MessageErr) {
+	return func(tagId int64, ownerId int64) (*tag_domain.Tag, error_utils.MessageErr) {
+		return &tag_domain.Tag{Id: tagId, Name: name, Color: tag_domain.DefaultColor, OwnerId: ownerId}, nil
+	}
+}
+
+// ----------------
+// Test Create Tag
+
+func TestTagService_CreateTag_Success(t *testing.T) {
+	tag_domain.TagDomain = &tagDomainMock{}
+
+	createTag = func(tag *tag_domain.Tag) (*tag_domain.Tag, error_utils.MessageErr) {
+		created := *tag
+		created.Id = 1
+		return &created, nil
+	}
+
+	tag, err := TagService.CreateTag(context.Background(), &tag_domain.Tag{Name: " urgent ", OwnerId: 1})
+
+	assert.Nil(t, err)
+	require.NotNil(t, tag)
+	assert.EqualValues(t, "urgent", tag.Name)
+	assert.EqualValues(t, tag_domain.DefaultColor, tag.Color)
+}
+
+func TestTagService_CreateTag_BadRequest(t *testing.T) {
+	tag_domain.TagDomain = &tagDomainMock{}
+
+	tag, err := TagService.CreateTag(context.Background(), &tag_domain.Tag{Name: "", Color: "red", OwnerId: 1})
+
+	assert.Nil(t, tag)
+	require.NotNil(t, err)
+
+	validationErr, ok := err.(*error_utils.ValidationErrData)
+	require.True(t, ok)
+	assert.EqualValues(t, []error_utils.FieldError{
+		{Field: "name", Rule: "required", Message: "name is required"},
+		{Field: "color", Rule: "hexcolor", Message: "color must be a hex color such as #1e90ff"},
+	}, validationErr.Fields)
+}
+
+func TestTagService_CreateTag_Conflict(t *testing.T) {
+	tag_domain.TagDomain = tag_domain.NewTagMemoryRepo()
+
+	_, err := TagService.CreateTag(context.Background(), &tag_domain.Tag{Name: "urgent", OwnerId: 1})
+	require.Nil(t, err)
+
+	_, err = TagService.CreateTag(context.Background(), &tag_domain.Tag{Name: "urgent", OwnerId: 2})
+	require.Nil(t, err)
+
+	tag, err := TagService.CreateTag(context.Background(), &tag_domain.Tag{Name: "urgent", OwnerId: 1})
+
+	assert.Nil(t, tag)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusConflict, err.Status())
+	assert.EqualValues(t, "a tag with this name already exists", err.Message())
+}
+
+// ----------------
+// Test Get Tags
+
+func TestTagService_GetAllTags_Counts(t *testing.T) {
+	tag_domain.TagDomain = &tagDomainMock{}
+	newTodos(t)
+
+	getAllTags = func(ownerId int64) ([]tag_domain.Tag, error_utils.MessageErr) {
+		return []tag_domain.Tag{{Id: 1, Name: "home"}, {Id: 2, Name: "school"}, {Id: 3, Name: "urgent"}}, nil
+	}
+
+	res, err := TagService.GetAllTags(context.Background(), 1)
+
+	assert.Nil(t, err)
+	require.NotNil(t, res)
+	require.Len(t, res.Tags, 3)
+	assert.EqualValues(t, 0, res.Tags[0].TodoCount)
+	assert.EqualValues(t, 2, res.Tags[1].TodoCount)
+	assert.EqualValues(t, 1, res.Tags[2].TodoCount, "trashed todos are not counted")
+}
+
+func TestTagService_GetTagById_NotFoundError(t *testing.T) {
+	tag_domain.TagDomain = &tagDomainMock{}
+
+	getTagById = func(tagId int64, ownerId int64) (*tag_domain.Tag, error_utils.MessageErr) {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	tag, err := TagService.GetTagById(context.Background(), 1, 1)
+
+	assert.Nil(t, tag)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusNotFound, err.Status())
+}
+
+// ----------------
+// Test Update Tag
+
+func TestTagService_UpdateTag_Rename(t *testing.T) {
+	tag_domain.TagDomain = &tagDomainMock{}
+	newTodos(t)
+
+	getTagById = foundTag("school")
+	updateTag = func(tag *tag_domain.Tag) (*tag_domain.Tag, error_utils.MessageErr) {
+		return tag, nil
+	}
+
+	tag, err := TagService.UpdateTag(context.Background(), &tag_domain.Tag{Id: 2, Name: "class", Color: "#1e90ff", OwnerId: 1})
+
+	assert.Nil(t, err)
+	require.NotNil(t, tag)
+	assert.EqualValues(t, 2, tag.TodoCount)
+
+	todo, _ := todo_domain.TodoDomain.GetTodoById(context.Background(), 1, 1)
+	assert.EqualValues(t, []string{"class", "urgent"}, todo.Tags)
+}
+
+// ----------------
+// Test Delete Tag
+
+func TestTagService_DeleteTagById_Success(t *testing.T) {
+	tag_domain.TagDomain = &tagDomainMock{}
+	newTodos(t)
+
+	getTagById = foundTag("urgent")
+	deleteTagById = func(tagId int64, ownerId int64) error_utils.MessageErr {
+		return nil
+	}
+
+	err := TagService.DeleteTagById(context.Background(), 3, 1)
+
+	assert.Nil(t, err)
+
+	todo, _ := todo_domain.TodoDomain.GetTodoById(context.Background(), 1, 1)
+	assert.EqualValues(t, []string{"school"}, todo.Tags)
+}
+
+func TestTagService_DeleteTagById_RollsBack(t *testing.T) {
+	tag_domain.TagDomain = &tagDomainMock{}
+	newTodos(t)
+
+	before, _ := todo_domain.TodoDomain.GetTodoById(context.Background(), 1, 1)
+
+	getTagById = foundTag("urgent")
+	deleteTagById = func(tagId int64, ownerId int64) error_utils.MessageErr {
+		return error_utils.NewInternalServerError("something went wrong")
+	}
+
+	err := TagService.DeleteTagById(context.Background(), 3, 1)
+
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusInternalServerError, err.Status())
+
+	todo, _ := todo_domain.TodoDomain.GetTodoById(context.Background(), 1, 1)
+	assert.EqualValues(t, []string{"school", "urgent"}, todo.Tags)
+	assert.EqualValues(t, before.Version, todo.Version)
+}
+
+func TestTagService_DeleteTagById_NotFoundError(t *testing.T) {
+	tag_domain.TagDomain = &tagDomainMock{}
+	newTodos(t)
+
+	getTagById = func(tagId int64, ownerId int64) (*tag_domain.Tag, error_utils.MessageErr) {
+		return nil, error_utils.NewNotFoundError("no record found")
+	}
+
+	err := TagService.DeleteTagById(context.Background(), 3, 1)
+
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusNotFound, err.Status())
+
+	todo, _ := todo_domain.TodoDomain.GetTodoById(context.Background(), 1, 1)
+	assert.EqualValues(t, []string{"school", "urgent"}, todo.Tags)
+}
@@ -0,0 +1,125 @@
+package todo_service
+
+import (
+	"assignment-4/domain/todo_domain"
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/logger_utils"
+	"context"
+	"net/http"
+)
+
+// ApplyBatch runs the operations of batch in one transaction, in order. In
+// all_or_nothing mode the first failing operation rolls everything back and
+// is the only entry of the results; the batch is then not committed.
+func (t *todoService) ApplyBatch(ctx context.Context, ownerId int64, batch *todo_domain.Batch) (*todo_domain.BatchResult, error_utils.MessageErr) {
+	if err := batch.Validate(); err != nil {
+		return nil, err
+	}
+
+	res := &todo_domain.BatchResult{Mode: batch.Mode, Results: []todo_domain.BatchItemResult{}}
+	var failed *todo_domain.BatchItemResult
+
+	err := todo_domain.TodoDomain.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+		for i := range batch.Operations {
+			op := &batch.Operations[i]
+			item := todo_domain.BatchItemResult{Index: i, Op: op.Op}
+
+			var err error_utils.MessageErr
+
+			if batch.Mode == todo_domain.BatchModePerItem {
+				err = todo_domain.TodoDomain.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+					return t.applyOperation(ctx, ownerId, op, &item)
+				})
+			} else {
+				err = t.applyOperation(ctx, ownerId, op, &item)
+			}
+
+			if err != nil {
+				logBatchError(ctx, &item, err)
+
+				item = todo_domain.BatchItemResult{Index: i, Op: op.Op, Status: err.Status(), Error: err}
+				res.Failed++
+
+				if batch.Mode == todo_domain.BatchModeAllOrNothing {
+					failed = &item
+					return err
+				}
+			} else {
+				res.Succeeded++
+			}
+
+			res.Results = append(res.Results, item)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		if failed == nil {
+			return nil, err
+		}
+
+		res.Succeeded = 0
+		res.Results = []todo_domain.BatchItemResult{*failed}
+
+		return res, nil
+	}
+
+	res.Committed = true
+
+	logger_utils.Ctx(ctx).Info().
+		Int64("owner_id", ownerId).
+		Str("mode", res.Mode).
+		Int("succeeded", res.Succeeded).
+		Int("failed", res.Failed).
+		Msg("batch applied")
+
+	return res, nil
+}
+
+func (t *todoService) applyOperation(ctx context.Context, ownerId int64, op *todo_domain.BatchOperation, item *todo_domain.BatchItemResult) error_utils.MessageErr {
+	var err error_utils.MessageErr
+
+	item.Status = http.StatusOK
+
+	switch op.Op {
+	case todo_domain.BatchOpCreate:
+		todo := *op.Todo
+		todo.OwnerId = ownerId
+
+		item.Status = http.StatusCreated
+		item.Todo, err = t.CreateTodo(ctx, &todo)
+	case todo_domain.BatchOpUpdate:
+		todo := *op.Todo
+		todo.Id = op.Id
+		todo.OwnerId = ownerId
+		todo.Version = op.Version
+
+		item.Todo, err = t.UpdateTodo(ctx, &todo)
+	case todo_domain.BatchOpDelete:
+		item.Deleted, err = t.DeleteTodoById(ctx, op.Id, ownerId, op.Version, op.Permanent)
+	case todo_domain.BatchOpComplete:
+		todo := todo_domain.Todo{Id: op.Id, OwnerId: ownerId, Version: op.Version, Completed: true}
+
+		item.Todo, err = writeTodo(ctx, func(ctx context.Context) (*todo_domain.Todo, error_utils.MessageErr) {
+			return todo_domain.TodoDomain.PatchTodo(ctx, &todo, []string{"completed"})
+		})
+	default:
+		err = error_utils.NewBadRequest("unknown batch op " + op.Op)
+	}
+
+	return err
+}
+
+// logBatchError logs the internal cause of a failed operation, which the
+// error in the batch result leaves out.
+func logBatchError(ctx context.Context, item *todo_domain.BatchItemResult, err error_utils.MessageErr) {
+	if cause := error_utils.Cause(err); cause != nil {
+		logger_utils.Ctx(ctx).Error().
+			Err(cause).
+			Int("index", item.Index).
+			Str("op", item.Op).
+			Str("code", err.Error()).
+			Msg(err.Message())
+	}
+}
@@ -0,0 +1,200 @@
+package todo_service
+
+import (
+	"assignment-4/domain/tag_domain"
+	"assignment-4/domain/todo_domain"
+	"assignment-4/utils/error_utils"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBatchRepo(t *testing.T) *todo_domain.Todo {
+	todo_domain.TodoDomain = todo_domain.NewTodoMemoryRepo()
+
+	existing, err := todo_domain.TodoDomain.CreateTodo(context.Background(), &todo_domain.Todo{OwnerId: 1, Title: "Homework", Description: "Deadline"})
+	require.Nil(t, err)
+
+	return existing
+}
+
+func TestTodoService_ApplyBatch_AllOrNothing(t *testing.T) {
+	existing := newBatchRepo(t)
+
+	res, err := TodoService.ApplyBatch(context.Background(), 1, &todo_domain.Batch{
+		Operations: []todo_domain.BatchOperation{
+			{Op: todo_domain.BatchOpCreate, Todo: &todo_domain.Todo{Title: "Groceries", Description: "Eggs and milk"}},
+			{Op: todo_domain.BatchOpComplete, Id: existing.Id},
+			{Op: todo_domain.BatchOpUpdate, Id: existing.Id, Todo: &todo_domain.Todo{Title: "Homework", Description: "Submitted", Completed: true}},
+		},
+	})
+
+	require.Nil(t, err)
+	assert.True(t, res.Committed)
+	assert.EqualValues(t, todo_domain.BatchModeAllOrNothing, res.Mode)
+	assert.EqualValues(t, 3, res.Succeeded)
+	require.Len(t, res.Results, 3)
+	assert.EqualValues(t, http.StatusCreated, res.Results[0].Status)
+	assert.EqualValues(t, http.StatusOK, res.Results[1].Status)
+	assert.True(t, res.Results[1].Todo.Completed)
+	assert.EqualValues(t, "Submitted", res.Results[2].Todo.Description)
+	assert.EqualValues(t, 3, res.Results[2].Todo.Version)
+}
+
+func TestTodoService_ApplyBatch_AllOrNothing_RollsBack(t *testing.T) {
+	existing := newBatchRepo(t)
+
+	res, err := TodoService.ApplyBatch(context.Background(), 1, &todo_domain.Batch{
+		Mode: todo_domain.BatchModeAllOrNothing,
+		Operations: []todo_domain.BatchOperation{
+			{Op: todo_domain.BatchOpCreate, Todo: &todo_domain.Todo{Title: "Groceries", Description: "Eggs and milk"}},
+			{Op: todo_domain.BatchOpDelete, Id: existing.Id},
+			{Op: todo_domain.BatchOpComplete, Id: 999},
+			{Op: todo_domain.BatchOpCreate, Todo: &todo_domain.Todo{Title: "Laundry", Description: "Whites"}},
+		},
+	})
+
+	require.Nil(t, err)
+	assert.False(t, res.Committed)
+	assert.EqualValues(t, 0, res.Succeeded)
+	assert.EqualValues(t, 1, res.Failed)
+	require.Len(t, res.Results, 1)
+	assert.EqualValues(t, 2, res.Results[0].Index)
+	assert.EqualValues(t, http.StatusNotFound, res.Results[0].Status)
+	assert.EqualValues(t, error_utils.CodeNotFound, res.Results[0].Error.Error())
+
+	page, _ := todo_domain.TodoDomain.GetAllTodos(context.Background(), &todo_domain.TodoQuery{OwnerId: 1})
+	require.Len(t, page.Todos, 1)
+	assert.EqualValues(t, existing.Id, page.Todos[0].Id)
+}
+
+func TestTodoService_ApplyBatch_PerItem(t *testing.T) {
+	existing := newBatchRepo(t)
+
+	res, err := TodoService.ApplyBatch(context.Background(), 1, &todo_domain.Batch{
+		Mode: todo_domain.BatchModePerItem,
+		Operations: []todo_domain.BatchOperation{
+			{Op: todo_domain.BatchOpCreate, Todo: &todo_domain.Todo{Title: "Groceries", Description: "Eggs and milk"}},
+			{Op: todo_domain.BatchOpCreate, Todo: &todo_domain.Todo{Title: "", Description: "No title"}},
+			{Op: todo_domain.BatchOpComplete, Id: existing.Id, Version: 7},
+			{Op: todo_domain.BatchOpDelete, Id: existing.Id, Version: 1},
+		},
+	})
+
+	require.Nil(t, err)
+	assert.True(t, res.Committed)
+	assert.EqualValues(t, 2, res.Succeeded)
+	assert.EqualValues(t, 2, res.Failed)
+	require.Len(t, res.Results, 4)
+
+	assert.EqualValues(t, http.StatusCreated, res.Results[0].Status)
+	assert.EqualValues(t, http.StatusBadRequest, res.Results[1].Status)
+	assert.Nil(t, res.Results[1].Todo)
+	assert.EqualValues(t, http.StatusPreconditionFailed, res.Results[2].Status)
+	assert.EqualValues(t, http.StatusOK, res.Results[3].Status)
+	assert.False(t, res.Results[3].Deleted.Permanent)
+
+	page, _ := todo_domain.TodoDomain.GetAllTodos(context.Background(), &todo_domain.TodoQuery{OwnerId: 1})
+	require.Len(t, page.Todos, 1)
+	assert.EqualValues(t, "Groceries", page.Todos[0].Title)
+}
+
+func TestTodoService_ApplyBatch_Invalid(t *testing.T) {
+	newBatchRepo(t)
+
+	tooMany := make([]todo_domain.BatchOperation, todo_domain.MaxBatchOperations+1)
+	for i := range tooMany {
+		tooMany[i] = todo_domain.BatchOperation{Op: todo_domain.BatchOpComplete, Id: 1}
+	}
+
+	tests := []struct {
+		name   string
+		batch  todo_domain.Batch
+		errMsg string
+	}{
+		{
+			name:   "empty",
+			batch:  todo_domain.Batch{},
+			errMsg: "operations must not be empty",
+		},
+		{
+			name:   "too many operations",
+			batch:  todo_domain.Batch{Operations: tooMany},
+			errMsg: "a batch holds at most 100 operations",
+		},
+		{
+			name:   "unknown mode",
+			batch:  todo_domain.Batch{Mode: "best_effort", Operations: tooMany[:1]},
+			errMsg: "mode must be all_or_nothing or per_item",
+		},
+		{
+			name: "malformed operations",
+			batch: todo_domain.Batch{Operations: []todo_domain.BatchOperation{
+				{Op: todo_domain.BatchOpCreate},
+				{Op: "archive", Id: 1},
+				{Op: todo_domain.BatchOpDelete, Todo: &todo_domain.Todo{}},
+			}},
+			errMsg: "operations[0].todo is required for create;operations[1].op must be one of create, update, delete, complete;operations[2].todo is not allowed for delete;operations[2].id is required for delete",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := TodoService.ApplyBatch(context.Background(), 1, &tt.batch)
+
+			assert.Nil(t, res)
+			require.NotNil(t, err)
+			assert.EqualValues(t, http.StatusBadRequest, err.Status())
+			assert.EqualValues(t, tt.errMsg, err.Message())
+		})
+	}
+
+	page, _ := todo_domain.TodoDomain.GetAllTodos(context.Background(), &todo_domain.TodoQuery{OwnerId: 1})
+	assert.False(t, page.Todos[0].Completed)
+}
+
+func tagNames(t *testing.T) []string {
+	tags, err := tag_domain.TagDomain.GetAllTags(context.Background(), 1)
+	require.Nil(t, err)
+
+	names := []string{}
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+
+	return names
+}
+
+func TestTodoService_ApplyBatch_RollsBackNewTags(t *testing.T) {
+	existing := newBatchRepo(t)
+	tag_domain.TagDomain = tag_domain.NewTagMemoryRepo()
+
+	res, err := TodoService.ApplyBatch(context.Background(), 1, &todo_domain.Batch{
+		Mode: todo_domain.BatchModeAllOrNothing,
+		Operations: []todo_domain.BatchOperation{
+			{Op: todo_domain.BatchOpCreate, Todo: &todo_domain.Todo{Title: "Groceries", Description: "Eggs and milk", Tags: []string{"errands"}}},
+			{Op: todo_domain.BatchOpComplete, Id: 999},
+		},
+	})
+
+	require.Nil(t, err)
+	assert.False(t, res.Committed)
+	assert.Empty(t, tagNames(t))
+
+	res, err = TodoService.ApplyBatch(context.Background(), 1, &todo_domain.Batch{
+		Mode: todo_domain.BatchModePerItem,
+		Operations: []todo_domain.BatchOperation{
+			{Op: todo_domain.BatchOpCreate, Todo: &todo_domain.Todo{Title: "Groceries", Description: "Eggs and milk", Tags: []string{"errands"}}},
+			{Op: todo_domain.BatchOpUpdate, Id: 999, Todo: &todo_domain.Todo{Title: "Gone", Description: "Missing", Tags: []string{"phantom"}}},
+			{Op: todo_domain.BatchOpUpdate, Id: existing.Id, Todo: &todo_domain.Todo{Title: "Homework", Description: "Deadline", Tags: []string{"school"}}},
+		},
+	})
+
+	require.Nil(t, err)
+	assert.True(t, res.Committed)
+	assert.EqualValues(t, 1, res.Failed)
+	assert.EqualValues(t, []string{"errands", "school"}, tagNames(t))
+}
@@ -0,0 +1,150 @@
+package todo_service
+
+import (
+	"assignment-4/domain/todo_domain"
+	"assignment-4/utils/error_utils"
+	"context"
+)
+
+var checklistSync bool
+
+// SetChecklistSync makes checklists follow their todo: completing a todo
+// completes all of its items, and an item that is left open un-completes
+// its todo.
+func SetChecklistSync(enabled bool) {
+	checklistSync = enabled
+}
+
+// GetTodoWithChecklist returns the todo together with its checklist items.
+func (t *todoService) GetTodoWithChecklist(ctx context.Context, todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+	res, err := todo_domain.TodoDomain.GetTodoById(ctx, todoId, ownerId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := todo_domain.TodoDomain.GetChecklist(ctx, todoId, ownerId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.Checklist = items
+	res.Progress = todo_domain.Progress(items)
+
+	return res, nil
+}
+
+func (t *todoService) GetChecklist(ctx context.Context, todoId int64, ownerId int64) (*todo_domain.Checklist, error_utils.MessageErr) {
+	items, err := todo_domain.TodoDomain.GetChecklist(ctx, todoId, ownerId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &todo_domain.Checklist{Items: items, Progress: todo_domain.Progress(items)}, nil
+}
+
+func (t *todoService) AddChecklistItem(ctx context.Context, itemReq *todo_domain.ChecklistItem) (*todo_domain.ChecklistItem, error_utils.MessageErr) {
+	err := itemReq.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return writeItem(ctx, func(ctx context.Context) (*todo_domain.ChecklistItem, error_utils.MessageErr) {
+		return todo_domain.TodoDomain.AddChecklistItem(ctx, itemReq)
+	})
+}
+
+func (t *todoService) UpdateChecklistItem(ctx context.Context, todoId int64, ownerId int64, itemId int64, patch *todo_domain.ChecklistItemPatch) (*todo_domain.ChecklistItem, error_utils.MessageErr) {
+	err := patch.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return writeItem(ctx, func(ctx context.Context) (*todo_domain.ChecklistItem, error_utils.MessageErr) {
+		return todo_domain.TodoDomain.UpdateChecklistItem(ctx, todoId, ownerId, itemId, patch)
+	})
+}
+
+// ToggleChecklistItem flips the item between done and open.
+func (t *todoService) ToggleChecklistItem(ctx context.Context, todoId int64, ownerId int64, itemId int64) (*todo_domain.ChecklistItem, error_utils.MessageErr) {
+	return writeItem(ctx, func(ctx context.Context) (*todo_domain.ChecklistItem, error_utils.MessageErr) {
+		return todo_domain.TodoDomain.UpdateChecklistItem(ctx, todoId, ownerId, itemId, &todo_domain.ChecklistItemPatch{Toggle: true})
+	})
+}
+
+func (t *todoService) DeleteChecklistItem(ctx context.Context, todoId int64, ownerId int64, itemId int64) error_utils.MessageErr {
+	return todo_domain.TodoDomain.DeleteChecklistItem(ctx, todoId, ownerId, itemId)
+}
+
+func (t *todoService) ReorderChecklist(ctx context.Context, todoId int64, ownerId int64, order *todo_domain.ChecklistOrder) (*todo_domain.Checklist, error_utils.MessageErr) {
+	err := order.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := todo_domain.TodoDomain.ReorderChecklist(ctx, todoId, ownerId, order.ItemIds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &todo_domain.Checklist{Items: items, Progress: todo_domain.Progress(items)}, nil
+}
+
+// completeChecklist completes the checklist of a todo that was just
+// completed, with checklist sync.
+func completeChecklist(ctx context.Context, todo *todo_domain.Todo) error_utils.MessageErr {
+	if !checklistSync || !todo.Completed || todo.Progress.Done == todo.Progress.Total {
+		return nil
+	}
+
+	if err := todo_domain.TodoDomain.CompleteChecklist(ctx, todo.Id, todo.OwnerId); err != nil {
+		return err
+	}
+
+	todo.Progress.Done = todo.Progress.Total
+
+	return nil
+}
+
+// writeItem runs write and, with checklist sync, un-completes the todo of
+// the item when write left the item open, in one transaction.
+func writeItem(ctx context.Context, write func(context.Context) (*todo_domain.ChecklistItem, error_utils.MessageErr)) (*todo_domain.ChecklistItem, error_utils.MessageErr) {
+	if !checklistSync {
+		return write(ctx)
+	}
+
+	var item *todo_domain.ChecklistItem
+
+	err := todo_domain.TodoDomain.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+		var err error_utils.MessageErr
+
+		if item, err = write(ctx); err != nil || item.Done {
+			return err
+		}
+
+		todo, err := todo_domain.TodoDomain.GetTodoById(ctx, item.TodoId, item.OwnerId)
+
+		if err != nil || !todo.Completed {
+			return err
+		}
+
+		todo.Completed = false
+		todo.Version = 0
+
+		_, err = todo_domain.TodoDomain.PatchTodo(ctx, todo, []string{"completed"})
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
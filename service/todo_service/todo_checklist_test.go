@@ -0,0 +1,135 @@
+package todo_service
+
+import (
+	"assignment-4/domain/todo_domain"
+	"assignment-4/utils/error_utils"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newChecklistRepo(t *testing.T, texts ...string) (*todo_domain.Todo, []*todo_domain.ChecklistItem) {
+	todo_domain.TodoDomain = todo_domain.NewTodoMemoryRepo()
+	ctx := context.Background()
+
+	todo, err := todo_domain.TodoDomain.CreateTodo(ctx, &todo_domain.Todo{OwnerId: 1, Title: "Dinner", Description: "Fried rice"})
+	require.Nil(t, err)
+
+	var items []*todo_domain.ChecklistItem
+
+	for _, text := range texts {
+		item, err := TodoService.AddChecklistItem(ctx, &todo_domain.ChecklistItem{TodoId: todo.Id, OwnerId: 1, Text: text})
+		require.Nil(t, err)
+		items = append(items, item)
+	}
+
+	return todo, items
+}
+
+func TestTodoService_AddChecklistItem_Invalid(t *testing.T) {
+	todo, _ := newChecklistRepo(t)
+
+	item, err := TodoService.AddChecklistItem(context.Background(), &todo_domain.ChecklistItem{TodoId: todo.Id, OwnerId: 1, Text: "  "})
+
+	assert.Nil(t, item)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusBadRequest, err.Status())
+
+	validationErr, ok := err.(*error_utils.ValidationErrData)
+	require.True(t, ok)
+	assert.EqualValues(t, []error_utils.FieldError{{Field: "text", Rule: "required", Message: "text is required"}}, validationErr.Fields)
+
+	text := strings.Repeat("a", todo_domain.MaxChecklistItemLength+1)
+	item, err = TodoService.UpdateChecklistItem(context.Background(), todo.Id, 1, 1, &todo_domain.ChecklistItemPatch{Text: &text})
+
+	assert.Nil(t, item)
+	require.NotNil(t, err)
+	assert.EqualValues(t, "text must be at most 500 characters", err.(*error_utils.ValidationErrData).Fields[0].Message)
+
+	item, err = TodoService.UpdateChecklistItem(context.Background(), todo.Id, 1, 1, &todo_domain.ChecklistItemPatch{})
+
+	assert.Nil(t, item)
+	require.NotNil(t, err)
+	assert.EqualValues(t, "text or done is required", err.Message())
+}
+
+func TestTodoService_GetTodoWithChecklist(t *testing.T) {
+	todo, items := newChecklistRepo(t, "Buy eggs", "Cook rice")
+	ctx := context.Background()
+
+	_, err := TodoService.ToggleChecklistItem(ctx, todo.Id, 1, items[1].Id)
+	require.Nil(t, err)
+
+	res, err := TodoService.GetTodoWithChecklist(ctx, todo.Id, 1)
+
+	require.Nil(t, err)
+	require.Len(t, res.Checklist, 2)
+	assert.EqualValues(t, "Buy eggs", res.Checklist[0].Text)
+	assert.EqualValues(t, todo_domain.ChecklistProgress{Done: 1, Total: 2}, res.Progress)
+	assert.EqualValues(t, 4, res.Version)
+}
+
+func TestTodoService_ChecklistSync(t *testing.T) {
+	SetChecklistSync(true)
+	defer SetChecklistSync(false)
+
+	todo, items := newChecklistRepo(t, "Buy eggs", "Cook rice")
+	ctx := context.Background()
+
+	completed, err := TodoService.UpdateTodo(ctx, &todo_domain.Todo{Id: todo.Id, OwnerId: 1, Title: "Dinner", Description: "Fried rice", Completed: true})
+
+	require.Nil(t, err)
+	assert.EqualValues(t, todo_domain.ChecklistProgress{Done: 2, Total: 2}, completed.Progress)
+
+	checklist, _ := TodoService.GetChecklist(ctx, todo.Id, 1)
+	assert.True(t, checklist.Items[0].Done)
+	assert.True(t, checklist.Items[1].Done)
+
+	item, err := TodoService.ToggleChecklistItem(ctx, todo.Id, 1, items[0].Id)
+
+	require.Nil(t, err)
+	assert.False(t, item.Done)
+
+	reopened, _ := TodoService.GetTodoById(ctx, todo.Id, 1)
+	assert.False(t, reopened.Completed)
+	assert.Nil(t, reopened.CompletedAt)
+	assert.EqualValues(t, todo_domain.ChecklistProgress{Done: 1, Total: 2}, reopened.Progress)
+}
+
+func TestTodoService_ChecklistSync_Off(t *testing.T) {
+	todo, items := newChecklistRepo(t, "Buy eggs")
+	ctx := context.Background()
+
+	completed, err := TodoService.UpdateTodo(ctx, &todo_domain.Todo{Id: todo.Id, OwnerId: 1, Title: "Dinner", Description: "Fried rice", Completed: true})
+
+	require.Nil(t, err)
+	assert.EqualValues(t, todo_domain.ChecklistProgress{Done: 0, Total: 1}, completed.Progress)
+
+	done := false
+	_, err = TodoService.UpdateChecklistItem(ctx, todo.Id, 1, items[0].Id, &todo_domain.ChecklistItemPatch{Done: &done})
+	require.Nil(t, err)
+
+	current, _ := TodoService.GetTodoById(ctx, todo.Id, 1)
+	assert.True(t, current.Completed)
+}
+
+func TestTodoService_ReorderChecklist(t *testing.T) {
+	todo, items := newChecklistRepo(t, "Buy eggs", "Cook rice", "Fry")
+	ctx := context.Background()
+
+	res, err := TodoService.ReorderChecklist(ctx, todo.Id, 1, &todo_domain.ChecklistOrder{ItemIds: []int64{items[2].Id, items[0].Id}})
+
+	assert.Nil(t, res)
+	require.NotNil(t, err)
+	assert.EqualValues(t, "item_ids must list every checklist item exactly once", err.(*error_utils.ValidationErrData).Fields[0].Message)
+
+	res, err = TodoService.ReorderChecklist(ctx, todo.Id, 1, &todo_domain.ChecklistOrder{ItemIds: []int64{items[2].Id, items[0].Id, items[1].Id}})
+
+	require.Nil(t, err)
+	require.Len(t, res.Items, 3)
+	assert.EqualValues(t, []string{"Fry", "Buy eggs", "Cook rice"}, []string{res.Items[0].Text, res.Items[1].Text, res.Items[2].Text})
+}
@@ -0,0 +1,117 @@
+package todo_service
+
+import (
+	"assignment-4/domain/todo_domain"
+	"assignment-4/utils/error_utils"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOrderedTodos(t *testing.T, priorities ...string) []*todo_domain.Todo {
+	todo_domain.TodoDomain = todo_domain.NewTodoMemoryRepo()
+
+	var todos []*todo_domain.Todo
+
+	for _, priority := range priorities {
+		todo, err := TodoService.CreateTodo(context.Background(), &todo_domain.Todo{OwnerId: 1, Title: "Errand", Description: "Today", Priority: priority})
+		require.Nil(t, err)
+		todos = append(todos, todo)
+	}
+
+	return todos
+}
+
+func todoIds(page *todo_domain.TodoPage) []int64 {
+	ids := []int64{}
+
+	for _, todo := range page.Todos {
+		ids = append(ids, todo.Id)
+	}
+
+	return ids
+}
+
+func TestTodoService_CreateTodo_Priority(t *testing.T) {
+	todos := newOrderedTodos(t, "", "urgent")
+
+	assert.EqualValues(t, todo_domain.PriorityNone, todos[0].Priority)
+	assert.EqualValues(t, todo_domain.PriorityUrgent, todos[1].Priority)
+	assert.True(t, todos[0].Position < todos[1].Position)
+
+	res, err := TodoService.CreateTodo(context.Background(), &todo_domain.Todo{OwnerId: 1, Title: "Errand", Description: "Soon", Priority: "asap"})
+
+	assert.Nil(t, res)
+	require.NotNil(t, err)
+	assert.EqualValues(t, []error_utils.FieldError{{Field: "priority", Rule: "in", Message: "priority must be one of none, low, medium, high, urgent"}}, err.(*error_utils.ValidationErrData).Fields)
+}
+
+func TestTodoService_GetAllTodos_SortByPriority(t *testing.T) {
+	todos := newOrderedTodos(t, "low", "urgent", "none", "urgent", "medium")
+	ctx := context.Background()
+
+	page, err := TodoService.GetAllTodos(ctx, &todo_domain.TodoQuery{OwnerId: 1, Sort: todo_domain.SortByPriority, Limit: 2})
+
+	require.Nil(t, err)
+	assert.EqualValues(t, []int64{todos[1].Id, todos[3].Id}, todoIds(page))
+
+	page, err = TodoService.GetAllTodos(ctx, &todo_domain.TodoQuery{OwnerId: 1, Sort: todo_domain.SortByPriority, Limit: 2, Cursor: page.NextCursor})
+
+	require.Nil(t, err)
+	assert.EqualValues(t, []int64{todos[4].Id, todos[0].Id}, todoIds(page))
+
+	page, err = TodoService.GetAllTodos(ctx, &todo_domain.TodoQuery{OwnerId: 1, Priority: "urgent"})
+
+	require.Nil(t, err)
+	assert.EqualValues(t, []int64{todos[1].Id, todos[3].Id}, todoIds(page))
+}
+
+func TestTodoService_MoveTodo(t *testing.T) {
+	todos := newOrderedTodos(t, "none", "none", "none", "none")
+	ctx := context.Background()
+
+	moved, err := TodoService.MoveTodo(ctx, &todo_domain.TodoMove{TodoId: todos[3].Id, OwnerId: 1, Before: &todos[1].Id})
+	require.Nil(t, err)
+	assert.EqualValues(t, 2, moved.Version)
+
+	_, err = TodoService.MoveTodo(ctx, &todo_domain.TodoMove{TodoId: todos[0].Id, OwnerId: 1, After: &todos[2].Id})
+	require.Nil(t, err)
+
+	page, err := TodoService.GetAllTodos(ctx, &todo_domain.TodoQuery{OwnerId: 1, Sort: todo_domain.SortByPosition})
+
+	require.Nil(t, err)
+	assert.EqualValues(t, []int64{todos[3].Id, todos[1].Id, todos[2].Id, todos[0].Id}, todoIds(page))
+
+	// the default order stays by id
+	page, _ = TodoService.GetAllTodos(ctx, &todo_domain.TodoQuery{OwnerId: 1})
+	assert.EqualValues(t, []int64{todos[0].Id, todos[1].Id, todos[2].Id, todos[3].Id}, todoIds(page))
+}
+
+func TestTodoService_MoveTodo_Invalid(t *testing.T) {
+	todos := newOrderedTodos(t, "none", "none")
+	ctx := context.Background()
+
+	res, err := TodoService.MoveTodo(ctx, &todo_domain.TodoMove{TodoId: todos[0].Id, OwnerId: 1})
+
+	assert.Nil(t, res)
+	require.NotNil(t, err)
+	assert.EqualValues(t, "exactly one of before or after is required", err.Message())
+
+	_, err = TodoService.MoveTodo(ctx, &todo_domain.TodoMove{TodoId: todos[0].Id, OwnerId: 1, Before: &todos[1].Id, After: &todos[1].Id})
+
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusBadRequest, err.Status())
+
+	_, err = TodoService.MoveTodo(ctx, &todo_domain.TodoMove{TodoId: todos[0].Id, OwnerId: 1, After: &todos[0].Id})
+
+	require.NotNil(t, err)
+	assert.EqualValues(t, []error_utils.FieldError{{Field: "after", Rule: "not_self", Message: "after must be another todo"}}, err.(*error_utils.ValidationErrData).Fields)
+
+	_, err = TodoService.MoveTodo(ctx, &todo_domain.TodoMove{TodoId: todos[0].Id, OwnerId: 2, After: &todos[1].Id})
+
+	require.NotNil(t, err)
+	assert.EqualValues(t, []error_utils.FieldError{{Field: "after", Rule: "exists", Message: "after must refer to one of your todos"}}, err.(*error_utils.ValidationErrData).Fields)
+}
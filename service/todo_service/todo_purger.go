@@ -0,0 +1,58 @@
+package todo_service
+
+import (
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/logger_utils"
+	"context"
+	"time"
+)
+
+// StartTrashPurger permanently deletes todos that have been in the trash for
+// longer than retention, once right away and then every interval. The
+// returned func stops the purger and waits for a running purge to finish.
+func StartTrashPurger(retention time.Duration, interval time.Duration) func() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			purgeExpiredTrash(ctx, retention)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func() error {
+		cancel()
+		<-done
+		return nil
+	}
+}
+
+func purgeExpiredTrash(ctx context.Context, retention time.Duration) {
+	deletedBefore := time.Now().Add(-retention)
+	purged, err := TodoService.PurgeTrash(ctx, deletedBefore)
+
+	if err != nil {
+		if ctx.Err() == nil {
+			logger_utils.Ctx(ctx).Error().
+				Err(error_utils.Cause(err)).
+				Str("code", err.Error()).
+				Msg("purging trashed todos failed: " + err.Message())
+		}
+		return
+	}
+
+	if purged > 0 {
+		logger_utils.Ctx(ctx).Info().Int64("purged", purged).Time("deleted_before", deletedBefore).Msg("purged trashed todos")
+	}
+}
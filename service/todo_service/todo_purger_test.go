@@ -0,0 +1,42 @@
+package todo_service
+
+import (
+	"assignment-4/domain/todo_domain"
+	"assignment-4/utils/error_utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartTrashPurger(t *testing.T) {
+	todo_domain.TodoDomain = &todoDomainMock{}
+	TodoService = &todoService{}
+
+	cutoffs := make(chan time.Time, 10)
+
+	purgeTrash = func(deletedBefore time.Time) (int64, error_utils.MessageErr) {
+		cutoffs <- deletedBefore
+		return 1, nil
+	}
+
+	stop := StartTrashPurger(time.Hour, 10*time.Millisecond)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case cutoff := <-cutoffs:
+			assert.WithinDuration(t, time.Now().Add(-time.Hour), cutoff, time.Second)
+		case <-time.After(time.Second):
+			t.Fatal("purger did not run")
+		}
+	}
+
+	assert.Nil(t, stop())
+
+	for len(cutoffs) > 0 {
+		<-cutoffs
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	assert.Empty(t, cutoffs, "purger kept running after stop")
+}
@@ -0,0 +1,56 @@
+package todo_service
+
+import (
+	"assignment-4/domain/todo_domain"
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/logger_utils"
+	"context"
+)
+
+// GetOccurrences previews up to count occurrences that follow the recurring
+// todo.
+func (t *todoService) GetOccurrences(ctx context.Context, todoId int64, ownerId int64, count int) (*todo_domain.Occurrences, error_utils.MessageErr) {
+	todo, err := todo_domain.TodoDomain.GetTodoById(ctx, todoId, ownerId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return todo.Occurrences(count)
+}
+
+// recur creates the next occurrence of a recurring todo that was completed
+// and links it from the todo, once: completing the todo again after
+// reopening it creates no other.
+func recur(ctx context.Context, todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr) {
+	if !todo.Completed || todo.Recurrence == nil || todo.NextOccurrenceId != nil {
+		return todo, nil
+	}
+
+	next, err := todo.NextOccurrence()
+
+	if err != nil || next == nil {
+		return todo, err
+	}
+
+	created, err := todo_domain.TodoDomain.CreateTodo(ctx, next)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := todo_domain.TodoDomain.LinkNextOccurrence(ctx, todo.Id, todo.OwnerId, created.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	logger_utils.Ctx(ctx).Info().
+		Int64("todo_id", todo.Id).
+		Int64("next_todo_id", created.Id).
+		Int("occurrence", created.Occurrence).
+		Int64("owner_id", todo.OwnerId).
+		Msg("next occurrence created")
+
+	return res, nil
+}
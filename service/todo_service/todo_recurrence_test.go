@@ -0,0 +1,155 @@
+package todo_service
+
+import (
+	"assignment-4/domain/tag_domain"
+	"assignment-4/domain/todo_domain"
+	"assignment-4/utils/error_utils"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRecurringTodo(t *testing.T, rule string, dueAt time.Time) *todo_domain.Todo {
+	todo_domain.TodoDomain = todo_domain.NewTodoMemoryRepo()
+	tag_domain.TagDomain = tag_domain.NewTagMemoryRepo()
+
+	remindAt := dueAt.Add(-time.Hour)
+
+	todo, err := TodoService.CreateTodo(context.Background(), &todo_domain.Todo{
+		OwnerId:     1,
+		Title:       "Take out trash",
+		Description: "Bins go out before 8",
+		DueAt:       &dueAt,
+		RemindAt:    &remindAt,
+		Tags:        []string{"chores"},
+		Recurrence:  &rule,
+	})
+	require.Nil(t, err)
+
+	return todo
+}
+
+func TestTodoService_CreateTodo_InvalidRecurrence(t *testing.T) {
+	todo_domain.TodoDomain = todo_domain.NewTodoMemoryRepo()
+	dueAt := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+
+	for rule, message := range map[string]string{
+		"FREQ=YEARLY":                       "recurrence FREQ must be DAILY, WEEKLY or MONTHLY",
+		"INTERVAL=2":                        "recurrence FREQ is required",
+		"FREQ=DAILY;INTERVAL=0":             "recurrence INTERVAL must be a number from 1 to 1000",
+		"FREQ=WEEKLY;BYDAY=MO,XX":           `recurrence BYDAY "XX" is not a weekday`,
+		"FREQ=WEEKLY;BYDAY=1MO":             "recurrence BYDAY can only number weekdays, like 1MO, with FREQ=MONTHLY",
+		"FREQ=DAILY;COUNT=3;UNTIL=20261231": "recurrence cannot have both COUNT and UNTIL",
+		"FREQ=DAILY;UNTIL=tomorrow":         "recurrence UNTIL must be a date like 20261231 or a UTC time like 20261231T170000Z",
+		"FREQ=DAILY;BYHOUR=9":               "recurrence BYHOUR is not supported",
+		"FREQ=DAILY;FREQ=WEEKLY":            "recurrence has FREQ more than once",
+		"FREQ=DAILY;COUNT":                  `recurrence part "COUNT" must look like NAME=VALUE`,
+		"FREQ=MONTHLY;BYDAY=6FR":            `recurrence BYDAY "6FR" must number the weekday from 1 to 5 or -1 to -5`,
+	} {
+		rule := rule
+
+		res, err := TodoService.CreateTodo(context.Background(), &todo_domain.Todo{OwnerId: 1, Title: "Report", Description: "Monthly", DueAt: &dueAt, Recurrence: &rule})
+
+		assert.Nil(t, res, rule)
+		require.NotNil(t, err, rule)
+		assert.EqualValues(t, http.StatusBadRequest, err.Status())
+		assert.EqualValues(t, []error_utils.FieldError{{Field: "recurrence", Rule: "rrule", Message: message}}, err.(*error_utils.ValidationErrData).Fields, rule)
+	}
+
+	rule := "FREQ=DAILY"
+	_, err := TodoService.CreateTodo(context.Background(), &todo_domain.Todo{OwnerId: 1, Title: "Report", Description: "Monthly", Recurrence: &rule})
+
+	require.NotNil(t, err)
+	assert.EqualValues(t, []error_utils.FieldError{{Field: "due_at", Rule: "required_with", Message: "due_at is required for a recurring todo"}}, err.(*error_utils.ValidationErrData).Fields)
+}
+
+func TestTodoService_UpdateTodo_CompletingCreatesNextOccurrence(t *testing.T) {
+	// a Thursday
+	todo := newRecurringTodo(t, "rrule:freq=weekly;byday=th,mo", time.Date(2026, 10, 22, 8, 0, 0, 0, time.UTC))
+	ctx := context.Background()
+
+	assert.EqualValues(t, "FREQ=WEEKLY;BYDAY=TH,MO", *todo.Recurrence)
+	assert.EqualValues(t, 1, todo.Occurrence)
+
+	todo.Completed = true
+	completed, err := TodoService.UpdateTodo(ctx, todo)
+
+	require.Nil(t, err)
+	require.NotNil(t, completed.NextOccurrenceId)
+
+	next, err := TodoService.GetTodoById(ctx, *completed.NextOccurrenceId, 1)
+
+	require.Nil(t, err)
+	assert.False(t, next.Completed)
+	assert.EqualValues(t, 2, next.Occurrence)
+	assert.EqualValues(t, "Take out trash", next.Title)
+	assert.EqualValues(t, []string{"chores"}, next.Tags)
+	assert.EqualValues(t, time.Date(2026, 10, 26, 8, 0, 0, 0, time.UTC), *next.DueAt)
+	assert.EqualValues(t, time.Date(2026, 10, 26, 7, 0, 0, 0, time.UTC), *next.RemindAt)
+
+	completed.Completed = false
+	reopened, err := TodoService.UpdateTodo(ctx, completed)
+	require.Nil(t, err)
+
+	reopened.Completed = true
+	recompleted, err := TodoService.UpdateTodo(ctx, reopened)
+
+	require.Nil(t, err)
+	assert.EqualValues(t, next.Id, *recompleted.NextOccurrenceId)
+
+	page, _ := TodoService.GetAllTodos(ctx, &todo_domain.TodoQuery{OwnerId: 1})
+	assert.EqualValues(t, 2, page.Total)
+}
+
+func TestTodoService_UpdateTodo_SeriesEnds(t *testing.T) {
+	todo := newRecurringTodo(t, "FREQ=DAILY;COUNT=2", time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC))
+	ctx := context.Background()
+
+	todo.Completed = true
+	first, err := TodoService.UpdateTodo(ctx, todo)
+	require.Nil(t, err)
+	require.NotNil(t, first.NextOccurrenceId)
+
+	second, _ := TodoService.GetTodoById(ctx, *first.NextOccurrenceId, 1)
+	second.Completed = true
+	last, err := TodoService.UpdateTodo(ctx, second)
+
+	require.Nil(t, err)
+	assert.Nil(t, last.NextOccurrenceId)
+}
+
+func TestTodoService_GetOccurrences(t *testing.T) {
+	// the last Friday of October 2026
+	todo := newRecurringTodo(t, "FREQ=MONTHLY;INTERVAL=2;BYDAY=-1FR;UNTIL=20270331", time.Date(2026, 10, 30, 17, 0, 0, 0, time.UTC))
+
+	res, err := TodoService.GetOccurrences(context.Background(), todo.Id, 1, 5)
+
+	require.Nil(t, err)
+	assert.EqualValues(t, "FREQ=MONTHLY;INTERVAL=2;BYDAY=-1FR;UNTIL=20270331T235959Z", res.Recurrence)
+	assert.EqualValues(t, []todo_domain.Occurrence{
+		{Occurrence: 2, DueAt: time.Date(2026, 12, 25, 17, 0, 0, 0, time.UTC)},
+		{Occurrence: 3, DueAt: time.Date(2027, 2, 26, 17, 0, 0, 0, time.UTC)},
+	}, res.Items)
+
+	// skips the months without a 31st
+	todo = newRecurringTodo(t, "FREQ=MONTHLY", time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC))
+
+	res, err = TodoService.GetOccurrences(context.Background(), todo.Id, 1, 3)
+
+	require.Nil(t, err)
+	assert.EqualValues(t, []time.Time{
+		time.Date(2026, 3, 31, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, 5, 31, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, 7, 31, 9, 0, 0, 0, time.UTC),
+	}, []time.Time{res.Items[0].DueAt, res.Items[1].DueAt, res.Items[2].DueAt})
+
+	plain, _ := TodoService.CreateTodo(context.Background(), &todo_domain.Todo{OwnerId: 1, Title: "Report", Description: "Once"})
+	_, err = TodoService.GetOccurrences(context.Background(), plain.Id, 1, 3)
+
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusConflict, err.Status())
+}
@@ -0,0 +1,55 @@
+package todo_service
+
+import (
+	"assignment-4/domain/todo_domain"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoService_SearchTodos(t *testing.T) {
+	todo_domain.TodoDomain = todo_domain.NewTodoMemoryRepo()
+	ctx := context.Background()
+
+	for _, todo := range []*todo_domain.Todo{
+		{Title: "Make Dinner", Description: "Cook fried rice with egg and chicken"},
+		{Title: "Groceries", Description: "Rice, eggs, fried onions"},
+		{Title: "Fried rice", Description: "Try the recipe from grandma"},
+	} {
+		todo.OwnerId = 1
+		_, err := TodoService.CreateTodo(ctx, todo)
+		require.Nil(t, err)
+	}
+
+	res, err := TodoService.SearchTodos(ctx, &todo_domain.TodoSearch{OwnerId: 1, Query: `"fried rice"`})
+
+	require.Nil(t, err)
+	assert.EqualValues(t, 2, res.Total)
+	assert.EqualValues(t, todo_domain.DefaultTodoLimit, res.Limit)
+	require.Len(t, res.Results, 2)
+	assert.EqualValues(t, "Fried rice", res.Results[0].Title)
+	assert.EqualValues(t, "<mark>Fried</mark> <mark>rice</mark>", res.Results[0].Highlight.Title)
+	assert.EqualValues(t, "Make Dinner", res.Results[1].Title)
+	assert.EqualValues(t, "Cook <mark>fried</mark> <mark>rice</mark> with egg and chicken", res.Results[1].Highlight.Description)
+
+	res, err = TodoService.SearchTodos(ctx, &todo_domain.TodoSearch{OwnerId: 1, Query: "egg* ONIONS"})
+
+	require.Nil(t, err)
+	require.Len(t, res.Results, 1)
+	assert.EqualValues(t, "Groceries", res.Results[0].Title)
+
+	res, err = TodoService.SearchTodos(ctx, &todo_domain.TodoSearch{OwnerId: 1, Query: "eg"})
+
+	require.Nil(t, err)
+	assert.Empty(t, res.Results)
+
+	res, err = TodoService.SearchTodos(ctx, &todo_domain.TodoSearch{OwnerId: 1})
+
+	assert.Nil(t, res)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusBadRequest, err.Status())
+	assert.EqualValues(t, "q is required", err.Message())
+}
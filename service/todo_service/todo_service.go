@@ -1,44 +1,186 @@
 package todo_service
 
 import (
+	"assignment-4/domain/list_domain"
+	"assignment-4/domain/tag_domain"
 	"assignment-4/domain/todo_domain"
 	"assignment-4/utils/error_utils"
+	"assignment-4/utils/logger_utils"
+	"context"
+	"net/http"
+	"time"
 )
 
 var TodoService todoServiceInterface = &todoService{}
 
 type todoServiceInterface interface {
-	CreateTodo(*todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr)
-	UpdateTodo(*todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr)
-	GetTodoById(int64) (*todo_domain.Todo, error_utils.MessageErr)
-	GetAllTodos() (*[]todo_domain.Todo, error_utils.MessageErr)
-	DeleteTodoById(int64) (*map[string]interface{}, error_utils.MessageErr)
+	CreateTodo(context.Context, *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr)
+	UpdateTodo(context.Context, *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr)
+	PatchTodo(context.Context, int64, int64, int64, []byte, string) (*todo_domain.Todo, error_utils.MessageErr)
+	GetTodoById(context.Context, int64, int64) (*todo_domain.Todo, error_utils.MessageErr)
+	GetAllTodos(context.Context, *todo_domain.TodoQuery) (*todo_domain.TodoPage, error_utils.MessageErr)
+	SearchTodos(context.Context, *todo_domain.TodoSearch) (*todo_domain.SearchPage, error_utils.MessageErr)
+	DeleteTodoById(context.Context, int64, int64, int64, bool) (*todo_domain.DeleteResult, error_utils.MessageErr)
+	RestoreTodoById(context.Context, int64, int64) (*todo_domain.Todo, error_utils.MessageErr)
+	PurgeTrash(context.Context, time.Time) (int64, error_utils.MessageErr)
+	ApplyBatch(context.Context, int64, *todo_domain.Batch) (*todo_domain.BatchResult, error_utils.MessageErr)
+	GetTodoWithChecklist(context.Context, int64, int64) (*todo_domain.Todo, error_utils.MessageErr)
+	GetChecklist(context.Context, int64, int64) (*todo_domain.Checklist, error_utils.MessageErr)
+	AddChecklistItem(context.Context, *todo_domain.ChecklistItem) (*todo_domain.ChecklistItem, error_utils.MessageErr)
+	UpdateChecklistItem(context.Context, int64, int64, int64, *todo_domain.ChecklistItemPatch) (*todo_domain.ChecklistItem, error_utils.MessageErr)
+	ToggleChecklistItem(context.Context, int64, int64, int64) (*todo_domain.ChecklistItem, error_utils.MessageErr)
+	DeleteChecklistItem(context.Context, int64, int64, int64) error_utils.MessageErr
+	ReorderChecklist(context.Context, int64, int64, *todo_domain.ChecklistOrder) (*todo_domain.Checklist, error_utils.MessageErr)
+	GetOccurrences(context.Context, int64, int64, int) (*todo_domain.Occurrences, error_utils.MessageErr)
+	MoveTodo(context.Context, *todo_domain.TodoMove) (*todo_domain.Todo, error_utils.MessageErr)
 }
 
 type todoService struct{}
 
-func (t *todoService) CreateTodo(todoReq *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr) {
-	err := todoReq.Validate()
+func (t *todoService) CreateTodo(ctx context.Context, todoReq *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr) {
+	err := validate(ctx, todoReq)
 
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := todo_domain.TodoDomain.CreateTodo(todoReq)
+	err = checkList(ctx, todoReq, nil)
 
 	if err != nil {
 		return nil, err
 	}
+
+	todoReq.Occurrence = 1
+	todoReq.NextOccurrenceId = nil
+
+	var res *todo_domain.Todo
+
+	err = todo_domain.TodoDomain.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+		if err := ensureTags(ctx, todoReq); err != nil {
+			return err
+		}
+
+		var err error_utils.MessageErr
+		res, err = todo_domain.TodoDomain.CreateTodo(ctx, todoReq)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	logger_utils.Ctx(ctx).Info().Int64("todo_id", res.Id).Int64("owner_id", res.OwnerId).Msg("todo created")
+
+	return res, err
+}
+
+func (t *todoService) UpdateTodo(ctx context.Context, todoReq *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr) {
+	err := validate(ctx, todoReq)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if todoReq.ListId != nil {
+		current, err := todo_domain.TodoDomain.GetTodoById(ctx, todoReq.Id, todoReq.OwnerId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkList(ctx, todoReq, current)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := writeTodo(ctx, func(ctx context.Context) (*todo_domain.Todo, error_utils.MessageErr) {
+		if err := ensureTags(ctx, todoReq); err != nil {
+			return nil, err
+		}
+
+		return todo_domain.TodoDomain.UpdateTodo(ctx, todoReq)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, err
+}
+
+// PatchTodo applies patch to the current todo. The write is conditional on
+// the version that was read, so a concurrent update is never overwritten.
+func (t *todoService) PatchTodo(ctx context.Context, todoId int64, ownerId int64, version int64, patch []byte, contentType string) (*todo_domain.Todo, error_utils.MessageErr) {
+	current, err := todo_domain.TodoDomain.GetTodoById(ctx, todoId, ownerId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if version != 0 && version != current.Version {
+		return nil, todo_domain.NewVersionMismatchError()
+	}
+
+	todoReq, err := current.ApplyPatch(patch, contentType)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = validate(ctx, todoReq)
+
+	if err != nil {
+		return nil, err
+	}
+
+	columns := current.ChangedColumns(todoReq)
+
+	if len(columns) == 0 {
+		return current, nil
+	}
+
+	err = checkList(ctx, todoReq, current)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := writeTodo(ctx, func(ctx context.Context) (*todo_domain.Todo, error_utils.MessageErr) {
+		if err := ensureTags(ctx, todoReq); err != nil {
+			return nil, err
+		}
+
+		return todo_domain.TodoDomain.PatchTodo(ctx, todoReq, columns)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, err
+}
+
+func (t *todoService) GetTodoById(ctx context.Context, todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+	res, err := todo_domain.TodoDomain.GetTodoById(ctx, todoId, ownerId)
+
+	if err != nil {
+		return nil, err
+	}
+
 	return res, err
 }
 
-func (t *todoService) UpdateTodo(todoReq *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr) {
-	err := todoReq.Validate()
+func (t *todoService) GetAllTodos(ctx context.Context, query *todo_domain.TodoQuery) (*todo_domain.TodoPage, error_utils.MessageErr) {
+	err := query.Validate()
 
 	if err != nil {
 		return nil, err
 	}
-	res, err := todo_domain.TodoDomain.UpdateTodo(todoReq)
+
+	res, err := todo_domain.TodoDomain.GetAllTodos(ctx, query)
 
 	if err != nil {
 		return nil, err
@@ -47,8 +189,14 @@ func (t *todoService) UpdateTodo(todoReq *todo_domain.Todo) (*todo_domain.Todo,
 	return res, err
 }
 
-func (t *todoService) GetTodoById(todoId int64) (*todo_domain.Todo, error_utils.MessageErr) {
-	res, err := todo_domain.TodoDomain.GetTodoById(todoId)
+func (t *todoService) SearchTodos(ctx context.Context, search *todo_domain.TodoSearch) (*todo_domain.SearchPage, error_utils.MessageErr) {
+	err := search.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := todo_domain.TodoDomain.SearchTodos(ctx, search)
 
 	if err != nil {
 		return nil, err
@@ -57,22 +205,126 @@ func (t *todoService) GetTodoById(todoId int64) (*todo_domain.Todo, error_utils.
 	return res, err
 }
 
-func (t *todoService) GetAllTodos() (*[]todo_domain.Todo, error_utils.MessageErr) {
-	res, err := todo_domain.TodoDomain.GetAllTodos()
+// MoveTodo puts the todo right before or right after another todo in the
+// manual order, changing only the position of the moved todo.
+func (t *todoService) MoveTodo(ctx context.Context, move *todo_domain.TodoMove) (*todo_domain.Todo, error_utils.MessageErr) {
+	err := move.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := todo_domain.TodoDomain.MoveTodo(ctx, move)
+
+	if err != nil {
+		return nil, err
+	}
+
+	logger_utils.Ctx(ctx).Debug().Int64("todo_id", res.Id).Str("position", res.Position).Msg("todo moved")
+
+	return res, err
+}
+
+// DeleteTodoById moves the todo to the trash, or deletes it for good when
+// permanent is set.
+func (t *todoService) DeleteTodoById(ctx context.Context, todoId int64, ownerId int64, version int64, permanent bool) (*todo_domain.DeleteResult, error_utils.MessageErr) {
+	var res *todo_domain.DeleteResult
+	var err error_utils.MessageErr
+
+	if permanent {
+		res, err = todo_domain.TodoDomain.PurgeTodoById(ctx, todoId, ownerId, version)
+	} else {
+		res, err = todo_domain.TodoDomain.DeleteTodoById(ctx, todoId, ownerId, version)
+	}
 
 	if err != nil {
 		return nil, err
 	}
 
+	logger_utils.Ctx(ctx).Info().Int64("todo_id", todoId).Int64("owner_id", ownerId).Bool("permanent", permanent).Msg("todo deleted")
+
 	return res, err
 }
 
-func (t *todoService) DeleteTodoById(todoId int64) (*map[string]interface{}, error_utils.MessageErr) {
-	res, err := todo_domain.TodoDomain.DeleteTodoById(todoId)
+func (t *todoService) RestoreTodoById(ctx context.Context, todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+	res, err := todo_domain.TodoDomain.RestoreTodoById(ctx, todoId, ownerId)
 
 	if err != nil {
 		return nil, err
 	}
 
+	logger_utils.Ctx(ctx).Info().Int64("todo_id", todoId).Int64("owner_id", ownerId).Msg("todo restored")
+
 	return res, err
 }
+
+// PurgeTrash deletes every todo that was moved to the trash before
+// deletedBefore, for all owners.
+func (t *todoService) PurgeTrash(ctx context.Context, deletedBefore time.Time) (int64, error_utils.MessageErr) {
+	return todo_domain.TodoDomain.PurgeTrash(ctx, deletedBefore)
+}
+
+// writeTodo runs write in one transaction with what follows from completing
+// a todo: its checklist is completed with checklist sync, and the next
+// occurrence of a recurring todo is created.
+func writeTodo(ctx context.Context, write func(context.Context) (*todo_domain.Todo, error_utils.MessageErr)) (*todo_domain.Todo, error_utils.MessageErr) {
+	var todo *todo_domain.Todo
+
+	err := todo_domain.TodoDomain.RunInTx(ctx, func(ctx context.Context) error_utils.MessageErr {
+		var err error_utils.MessageErr
+
+		if todo, err = write(ctx); err != nil {
+			return err
+		}
+
+		if err = completeChecklist(ctx, todo); err != nil {
+			return err
+		}
+
+		todo, err = recur(ctx, todo)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+// checkList makes sure a todo only goes into a list of its owner that is not
+// archived. Todos already in an archived list may stay there.
+func checkList(ctx context.Context, todoReq *todo_domain.Todo, current *todo_domain.Todo) error_utils.MessageErr {
+	if todoReq.ListId == nil || (current != nil && current.ListId != nil && *current.ListId == *todoReq.ListId) {
+		return nil
+	}
+
+	list, err := list_domain.ListDomain.GetListById(ctx, *todoReq.ListId, todoReq.OwnerId)
+
+	if err != nil {
+		if err.Status() == http.StatusNotFound {
+			return error_utils.NewValidationError([]error_utils.FieldError{
+				{Field: "list_id", Rule: "exists", Message: "list_id must refer to one of your lists"},
+			})
+		}
+		return err
+	}
+
+	if list.IsArchived() {
+		return list_domain.NewListArchivedError()
+	}
+
+	return nil
+}
+
+// ensureTags creates the tags the todo names that its owner does not have
+// yet, so they show up in GET /tags. It runs in the transaction of the todo
+// write, so a failed write leaves no tags behind.
+func ensureTags(ctx context.Context, todoReq *todo_domain.Todo) error_utils.MessageErr {
+	if len(todoReq.Tags) == 0 {
+		return nil
+	}
+
+	return tag_domain.TagDomain.EnsureTags(ctx, todoReq.OwnerId, todoReq.Tags)
+}
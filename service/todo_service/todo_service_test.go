@@ -1,42 +1,147 @@
 package todo_service
 
 import (
+	"assignment-4/domain/list_domain"
+	"assignment-4/domain/tag_domain"
 	"assignment-4/domain/todo_domain"
 	"assignment-4/utils/error_utils"
+	"context"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
-	createTodo     func(todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr)
-	updateTodo     func(todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr)
-	getTodoById    func(todoId int64) (*todo_domain.Todo, error_utils.MessageErr)
-	getAllTodos    func() (*[]todo_domain.Todo, error_utils.MessageErr)
-	deleteTodoById func(todoId int64) (*map[string]interface{}, error_utils.MessageErr)
+	createTodo      func(todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr)
+	updateTodo      func(todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr)
+	patchTodo       func(todo *todo_domain.Todo, columns []string) (*todo_domain.Todo, error_utils.MessageErr)
+	linkNext        func(todoId int64, ownerId int64, nextId int64) (*todo_domain.Todo, error_utils.MessageErr)
+	moveTodo        func(move *todo_domain.TodoMove) (*todo_domain.Todo, error_utils.MessageErr)
+	getTodoById     func(todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr)
+	getAllTodos     func(query *todo_domain.TodoQuery) (*todo_domain.TodoPage, error_utils.MessageErr)
+	searchTodos     func(search *todo_domain.TodoSearch) (*todo_domain.SearchPage, error_utils.MessageErr)
+	deleteTodoById  func(todoId int64, ownerId int64, version int64) (*todo_domain.DeleteResult, error_utils.MessageErr)
+	restoreTodoById func(todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr)
+	purgeTodoById   func(todoId int64, ownerId int64, version int64) (*todo_domain.DeleteResult, error_utils.MessageErr)
+	purgeTrash      func(deletedBefore time.Time) (int64, error_utils.MessageErr)
+	moveTodos       func(ownerId int64, todoIds []int64, listId *int64) (int64, error_utils.MessageErr)
+	unassignList    func(ownerId int64, listId int64) error_utils.MessageErr
+	countTodos      func(ownerId int64) (map[int64]todo_domain.TodoCounts, error_utils.MessageErr)
+	countTags       func(ownerId int64) (todo_domain.TagCounts, error_utils.MessageErr)
+	renameTag       func(ownerId int64, from string, to string) error_utils.MessageErr
+	removeTag       func(ownerId int64, name string) error_utils.MessageErr
+	getChecklist    func(todoId int64, ownerId int64) ([]todo_domain.ChecklistItem, error_utils.MessageErr)
+	addItem         func(item *todo_domain.ChecklistItem) (*todo_domain.ChecklistItem, error_utils.MessageErr)
+	updateItem      func(todoId int64, ownerId int64, itemId int64, patch *todo_domain.ChecklistItemPatch) (*todo_domain.ChecklistItem, error_utils.MessageErr)
+	deleteItem      func(todoId int64, ownerId int64, itemId int64) error_utils.MessageErr
+	reorderItems    func(todoId int64, ownerId int64, itemIds []int64) ([]todo_domain.ChecklistItem, error_utils.MessageErr)
+	completeItems   func(todoId int64, ownerId int64) error_utils.MessageErr
 )
 
 type todoDomainMock struct{}
 
-func (t *todoDomainMock) CreateTodo(todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr) {
+func (t *todoDomainMock) CreateTodo(ctx context.Context, todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr) {
 	return createTodo(todo)
 }
 
-func (t *todoDomainMock) UpdateTodo(todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr) {
+func (t *todoDomainMock) UpdateTodo(ctx context.Context, todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr) {
 	return updateTodo(todo)
 }
 
-func (t *todoDomainMock) GetTodoById(todoId int64) (*todo_domain.Todo, error_utils.MessageErr) {
-	return getTodoById(todoId)
+func (t *todoDomainMock) PatchTodo(ctx context.Context, todo *todo_domain.Todo, columns []string) (*todo_domain.Todo, error_utils.MessageErr) {
+	return patchTodo(todo, columns)
 }
 
-func (t *todoDomainMock) GetAllTodos() (*[]todo_domain.Todo, error_utils.MessageErr) {
-	return getAllTodos()
+func (t *todoDomainMock) LinkNextOccurrence(ctx context.Context, todoId int64, ownerId int64, nextId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+	return linkNext(todoId, ownerId, nextId)
 }
 
-func (t *todoDomainMock) DeleteTodoById(todoId int64) (*map[string]interface{}, error_utils.MessageErr) {
-	return deleteTodoById(todoId)
+func (t *todoDomainMock) MoveTodo(ctx context.Context, move *todo_domain.TodoMove) (*todo_domain.Todo, error_utils.MessageErr) {
+	return moveTodo(move)
+}
+
+func (t *todoDomainMock) GetTodoById(ctx context.Context, todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+	return getTodoById(todoId, ownerId)
+}
+
+func (t *todoDomainMock) GetAllTodos(ctx context.Context, query *todo_domain.TodoQuery) (*todo_domain.TodoPage, error_utils.MessageErr) {
+	return getAllTodos(query)
+}
+
+func (t *todoDomainMock) SearchTodos(ctx context.Context, search *todo_domain.TodoSearch) (*todo_domain.SearchPage, error_utils.MessageErr) {
+	return searchTodos(search)
+}
+
+func (t *todoDomainMock) DeleteTodoById(ctx context.Context, todoId int64, ownerId int64, version int64) (*todo_domain.DeleteResult, error_utils.MessageErr) {
+	return deleteTodoById(todoId, ownerId, version)
+}
+
+func (t *todoDomainMock) RestoreTodoById(ctx context.Context, todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+	return restoreTodoById(todoId, ownerId)
+}
+
+func (t *todoDomainMock) PurgeTodoById(ctx context.Context, todoId int64, ownerId int64, version int64) (*todo_domain.DeleteResult, error_utils.MessageErr) {
+	return purgeTodoById(todoId, ownerId, version)
+}
+
+func (t *todoDomainMock) PurgeTrash(ctx context.Context, deletedBefore time.Time) (int64, error_utils.MessageErr) {
+	return purgeTrash(deletedBefore)
+}
+
+func (t *todoDomainMock) MoveTodos(ctx context.Context, ownerId int64, todoIds []int64, listId *int64) (int64, error_utils.MessageErr) {
+	return moveTodos(ownerId, todoIds, listId)
+}
+
+func (t *todoDomainMock) UnassignList(ctx context.Context, ownerId int64, listId int64) error_utils.MessageErr {
+	return unassignList(ownerId, listId)
+}
+
+func (t *todoDomainMock) CountTodosByList(ctx context.Context, ownerId int64) (map[int64]todo_domain.TodoCounts, error_utils.MessageErr) {
+	return countTodos(ownerId)
+}
+
+func (t *todoDomainMock) CountTodosByTag(ctx context.Context, ownerId int64) (todo_domain.TagCounts, error_utils.MessageErr) {
+	return countTags(ownerId)
+}
+
+func (t *todoDomainMock) RenameTag(ctx context.Context, ownerId int64, from string, to string) error_utils.MessageErr {
+	return renameTag(ownerId, from, to)
+}
+
+func (t *todoDomainMock) RemoveTag(ctx context.Context, ownerId int64, name string) error_utils.MessageErr {
+	return removeTag(ownerId, name)
+}
+
+func (t *todoDomainMock) GetChecklist(ctx context.Context, todoId int64, ownerId int64) ([]todo_domain.ChecklistItem, error_utils.MessageErr) {
+	return getChecklist(todoId, ownerId)
+}
+
+func (t *todoDomainMock) AddChecklistItem(ctx context.Context, item *todo_domain.ChecklistItem) (*todo_domain.ChecklistItem, error_utils.MessageErr) {
+	return addItem(item)
+}
+
+func (t *todoDomainMock) UpdateChecklistItem(ctx context.Context, todoId int64, ownerId int64, itemId int64, patch *todo_domain.ChecklistItemPatch) (*todo_domain.ChecklistItem, error_utils.MessageErr) {
+	return updateItem(todoId, ownerId, itemId, patch)
+}
+
+func (t *todoDomainMock) DeleteChecklistItem(ctx context.Context, todoId int64, ownerId int64, itemId int64) error_utils.MessageErr {
+	return deleteItem(todoId, ownerId, itemId)
+}
+
+func (t *todoDomainMock) ReorderChecklist(ctx context.Context, todoId int64, ownerId int64, itemIds []int64) ([]todo_domain.ChecklistItem, error_utils.MessageErr) {
+	return reorderItems(todoId, ownerId, itemIds)
+}
+
+func (t *todoDomainMock) CompleteChecklist(ctx context.Context, todoId int64, ownerId int64) error_utils.MessageErr {
+	return completeItems(todoId, ownerId)
+}
+
+func (t *todoDomainMock) RunInTx(ctx context.Context, fn todo_domain.TxFunc) error_utils.MessageErr {
+	return fn(ctx)
 }
 
 // ----------------
@@ -62,7 +167,7 @@ func TestTodoService_CreateTodo_Success(t *testing.T) {
 		return expectedVal, nil
 	}
 
-	todo, err := TodoService.CreateTodo(requestBody)
+	todo, err := TodoService.CreateTodo(context.Background(), requestBody)
 
 	assert.NotNil(t, todo)
 	assert.Nil(t, err)
@@ -81,7 +186,7 @@ func TestTodoService_CreateTodo_ServerError(t *testing.T) {
 		Completed:   false,
 	}
 
-	todo, err := TodoService.CreateTodo(requestBody)
+	todo, err := TodoService.CreateTodo(context.Background(), requestBody)
 
 	assert.NotNil(t, err)
 	assert.Nil(t, todo)
@@ -125,7 +230,7 @@ func TestTodoService_CreateTodo_BadRequest(t *testing.T) {
 	for _, tt := range tests {
 
 		t.Run(tt.name, func(t *testing.T) {
-			todo, err := TodoService.CreateTodo(tt.todoReq)
+			todo, err := TodoService.CreateTodo(context.Background(), tt.todoReq)
 
 			assert.NotNil(t, err)
 			assert.Nil(t, todo)
@@ -136,6 +241,153 @@ func TestTodoService_CreateTodo_BadRequest(t *testing.T) {
 	}
 }
 
+func TestTodoService_CreateTodo_FieldErrors(t *testing.T) {
+	todo_domain.TodoDomain = &todoDomainMock{}
+
+	todo, err := TodoService.CreateTodo(context.Background(), &todo_domain.Todo{
+		Title: strings.Repeat("a", 256),
+	})
+
+	assert.Nil(t, todo)
+	require.NotNil(t, err)
+	assert.EqualValues(t, "bad_request", err.Error())
+	assert.EqualValues(t, http.StatusBadRequest, err.Status())
+
+	validationErr, ok := err.(*error_utils.ValidationErrData)
+	require.True(t, ok)
+	assert.EqualValues(t, []error_utils.FieldError{
+		{Field: "title", Rule: "maxstringlength", Message: "title must be at most 255 characters"},
+		{Field: "description", Rule: "required", Message: "description is required"},
+	}, validationErr.Fields)
+}
+
+func TestTodoService_CreateTodo_RemindAfterDue(t *testing.T) {
+	todo_domain.TodoDomain = &todoDomainMock{}
+
+	dueAt := time.Date(2022, time.January, 19, 17, 0, 0, 0, time.UTC)
+	remindAt := dueAt.Add(time.Hour)
+
+	todo, err := TodoService.CreateTodo(context.Background(), &todo_domain.Todo{
+		Title:       "Homework",
+		Description: "Deadline: January 19, 2022",
+		DueAt:       &dueAt,
+		RemindAt:    &remindAt,
+	})
+
+	assert.Nil(t, todo)
+	assert.NotNil(t, err)
+	assert.EqualValues(t, "bad_request", err.Error())
+	assert.EqualValues(t, "remind_at must be before due_at", err.Message())
+	assert.EqualValues(t, http.StatusBadRequest, err.Status())
+}
+
+func TestTodoService_CreateTodo_List(t *testing.T) {
+	todo_domain.TodoDomain = &todoDomainMock{}
+	list_domain.ListDomain = list_domain.NewListMemoryRepo()
+
+	ctx := context.Background()
+	active, _ := list_domain.ListDomain.CreateList(ctx, &list_domain.List{Name: "School", OwnerId: 1})
+	archived, _ := list_domain.ListDomain.CreateList(ctx, &list_domain.List{Name: "Old", OwnerId: 1})
+	list_domain.ListDomain.ArchiveList(ctx, archived.Id, 1, true)
+	otherOwners, _ := list_domain.ListDomain.CreateList(ctx, &list_domain.List{Name: "School", OwnerId: 2})
+
+	createTodo = func(todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr) {
+		return todo, nil
+	}
+
+	tests := []struct {
+		name   string
+		listId int64
+		errMsg string
+		status int
+	}{
+		{name: "active list", listId: active.Id},
+		{name: "archived list", listId: archived.Id, errMsg: "list is archived, unarchive it before adding todos", status: http.StatusConflict},
+		{name: "list of another owner", listId: otherOwners.Id, errMsg: "list_id must refer to one of your lists", status: http.StatusBadRequest},
+		{name: "unknown list", listId: 99, errMsg: "list_id must refer to one of your lists", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listId := tt.listId
+
+			todo, err := TodoService.CreateTodo(ctx, &todo_domain.Todo{
+				Title:       "Homework",
+				Description: "Deadline: January 19, 2022",
+				ListId:      &listId,
+				OwnerId:     1,
+			})
+
+			if tt.status == 0 {
+				assert.Nil(t, err)
+				require.NotNil(t, todo)
+				assert.EqualValues(t, tt.listId, *todo.ListId)
+				return
+			}
+
+			assert.Nil(t, todo)
+			require.NotNil(t, err)
+			assert.EqualValues(t, tt.errMsg, err.Message())
+			assert.EqualValues(t, tt.status, err.Status())
+		})
+	}
+}
+
+func TestTodoService_CreateTodo_Tags(t *testing.T) {
+	todo_domain.TodoDomain = &todoDomainMock{}
+	tag_domain.TagDomain = tag_domain.NewTagMemoryRepo()
+
+	ctx := context.Background()
+	tag_domain.TagDomain.CreateTag(ctx, &tag_domain.Tag{Name: "school", Color: "#1e90ff", OwnerId: 1})
+
+	createTodo = func(todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr) {
+		return todo, nil
+	}
+
+	todo, err := TodoService.CreateTodo(ctx, &todo_domain.Todo{
+		Title:       "Homework",
+		Description: "Deadline: January 19, 2022",
+		Tags:        []string{" urgent", "school", "urgent"},
+		OwnerId:     1,
+	})
+
+	assert.Nil(t, err)
+	require.NotNil(t, todo)
+	assert.EqualValues(t, []string{"school", "urgent"}, todo.Tags)
+
+	tags, _ := tag_domain.TagDomain.GetAllTags(ctx, 1)
+	require.Len(t, tags, 2)
+	assert.EqualValues(t, "#1e90ff", tags[0].Color)
+	assert.EqualValues(t, "urgent", tags[1].Name)
+	assert.EqualValues(t, tag_domain.DefaultColor, tags[1].Color)
+}
+
+func TestTodoService_CreateTodo_InvalidTags(t *testing.T) {
+	todo_domain.TodoDomain = &todoDomainMock{}
+
+	tooMany := make([]string, todo_domain.MaxTodoTags+1)
+	for i := range tooMany {
+		tooMany[i] = strings.Repeat("a", i+1)
+	}
+
+	todo, err := TodoService.CreateTodo(context.Background(), &todo_domain.Todo{
+		Title:       "Homework",
+		Description: "Deadline: January 19, 2022",
+		Tags:        append(tooMany, " ", strings.Repeat("b", todo_domain.MaxTagLength+1)),
+	})
+
+	assert.Nil(t, todo)
+	require.NotNil(t, err)
+
+	validationErr, ok := err.(*error_utils.ValidationErrData)
+	require.True(t, ok)
+	assert.EqualValues(t, []error_utils.FieldError{
+		{Field: "tags[21]", Rule: "required", Message: "tags[21] must not be empty"},
+		{Field: "tags[22]", Rule: "maxstringlength", Message: "tags[22] must be at most 50 characters"},
+		{Field: "tags", Rule: "maxitems", Message: "a todo can have at most 20 tags"},
+	}, validationErr.Fields)
+}
+
 // ----------------
 // Test Update Todo
 
@@ -160,7 +412,7 @@ func TestTodoService_UpdateTodo_Success(t *testing.T) {
 		return expectedVal, nil
 	}
 
-	todo, err := TodoService.UpdateTodo(requestBody)
+	todo, err := TodoService.UpdateTodo(context.Background(), requestBody)
 
 	assert.NotNil(t, todo)
 	assert.Nil(t, err)
@@ -179,7 +431,7 @@ func TestTodoService_UpdateTodo_ServerError(t *testing.T) {
 		Completed:   false,
 	}
 
-	todo, err := TodoService.UpdateTodo(requestBody)
+	todo, err := TodoService.UpdateTodo(context.Background(), requestBody)
 
 	assert.NotNil(t, err)
 	assert.Nil(t, todo)
@@ -223,7 +475,7 @@ func TestTodoService_UpdateTodo_BadRequest(t *testing.T) {
 	for _, tt := range tests {
 
 		t.Run(tt.name, func(t *testing.T) {
-			todo, err := TodoService.UpdateTodo(tt.todoReq)
+			todo, err := TodoService.UpdateTodo(context.Background(), tt.todoReq)
 
 			assert.NotNil(t, err)
 			assert.Nil(t, todo)
@@ -234,6 +486,217 @@ func TestTodoService_UpdateTodo_BadRequest(t *testing.T) {
 	}
 }
 
+// ----------------
+// Test Patch Todo
+
+func TestTodoService_PatchTodo_Success(t *testing.T) {
+	todo_domain.TodoDomain = &todoDomainMock{}
+
+	storedVal := &todo_domain.Todo{
+		Id:          1,
+		Title:       "Homework",
+		Description: "Deadline: January 19, 2022",
+		Completed:   false,
+	}
+
+	getTodoById = func(todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+		stored := *storedVal
+		return &stored, nil
+	}
+
+	tests := []struct {
+		name        string
+		patch       string
+		contentType string
+	}{
+		{
+			name:        "merge patch",
+			patch:       `{"completed": true}`,
+			contentType: todo_domain.MergePatchContentType,
+		},
+		{
+			name:        "json patch",
+			patch:       `[{"op": "replace", "path": "/completed", "value": true}]`,
+			contentType: todo_domain.JSONPatchContentType,
+		},
+	}
+
+	for _, tt := range tests {
+
+		t.Run(tt.name, func(t *testing.T) {
+			var receivedColumns []string
+
+			patchTodo = func(todo *todo_domain.Todo, columns []string) (*todo_domain.Todo, error_utils.MessageErr) {
+				receivedColumns = columns
+				return todo, nil
+			}
+
+			todo, err := TodoService.PatchTodo(context.Background(), 1, 1, 0, []byte(tt.patch), tt.contentType)
+
+			assert.Nil(t, err)
+			assert.NotNil(t, todo)
+			assert.True(t, todo.Completed)
+			assert.EqualValues(t, storedVal.Title, todo.Title)
+			assert.EqualValues(t, []string{"completed"}, receivedColumns)
+		})
+	}
+}
+
+func TestTodoService_PatchTodo_NoChanges(t *testing.T) {
+	todo_domain.TodoDomain = &todoDomainMock{}
+
+	storedVal := &todo_domain.Todo{
+		Id:          1,
+		Title:       "Homework",
+		Description: "Deadline: January 19, 2022",
+		Completed:   true,
+	}
+
+	getTodoById = func(todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+		return storedVal, nil
+	}
+
+	patchCalled := false
+	patchTodo = func(todo *todo_domain.Todo, columns []string) (*todo_domain.Todo, error_utils.MessageErr) {
+		patchCalled = true
+		return todo, nil
+	}
+
+	todo, err := TodoService.PatchTodo(context.Background(), 1, 1, 0, []byte(`{"completed": true}`), todo_domain.MergePatchContentType)
+
+	assert.Nil(t, err)
+	assert.EqualValues(t, storedVal, todo)
+	assert.False(t, patchCalled)
+}
+
+func TestTodoService_PatchTodo_Error(t *testing.T) {
+	todo_domain.TodoDomain = &todoDomainMock{}
+
+	getTodoById = func(todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+		return &todo_domain.Todo{
+			Id:          1,
+			Title:       "Homework",
+			Description: "Deadline: January 19, 2022",
+		}, nil
+	}
+
+	tests := []struct {
+		name        string
+		patch       string
+		contentType string
+		errMsg      string
+		status      int
+		err         string
+	}{
+		{
+			name:        "removed title",
+			patch:       `{"title": null}`,
+			contentType: todo_domain.MergePatchContentType,
+			errMsg:      "title is required",
+			status:      http.StatusBadRequest,
+			err:         "bad_request",
+		},
+		{
+			name:        "malformed merge patch",
+			patch:       `[1, 2]`,
+			contentType: todo_domain.MergePatchContentType,
+			errMsg:      "invalid merge patch body",
+			status:      http.StatusBadRequest,
+			err:         "bad_request",
+		},
+		{
+			name:        "malformed json patch",
+			patch:       `{"op": "replace"}`,
+			contentType: todo_domain.JSONPatchContentType,
+			errMsg:      "invalid json patch body",
+			status:      http.StatusBadRequest,
+			err:         "bad_request",
+		},
+		{
+			name:        "failed json patch test",
+			patch:       `[{"op": "test", "path": "/completed", "value": true}]`,
+			contentType: todo_domain.JSONPatchContentType,
+			status:      http.StatusUnprocessableEntity,
+			err:         "invalid_request",
+		},
+		{
+			name:        "changed id",
+			patch:       `{"id": 2}`,
+			contentType: todo_domain.MergePatchContentType,
+			errMsg:      "id is read only",
+			status:      http.StatusBadRequest,
+			err:         "bad_request",
+		},
+		{
+			name:        "added version",
+			patch:       `[{"op": "add", "path": "/version", "value": 9}]`,
+			contentType: todo_domain.JSONPatchContentType,
+			errMsg:      "version is read only",
+			status:      http.StatusBadRequest,
+			err:         "bad_request",
+		},
+		{
+			name:        "unsupported content type",
+			patch:       `{"completed": true}`,
+			contentType: "text/plain",
+			errMsg:      "content type must be application/merge-patch+json or application/json-patch+json",
+			status:      http.StatusUnsupportedMediaType,
+			err:         "unsupported_media_type",
+		},
+	}
+
+	for _, tt := range tests {
+
+		t.Run(tt.name, func(t *testing.T) {
+			todo, err := TodoService.PatchTodo(context.Background(), 1, 1, 0, []byte(tt.patch), tt.contentType)
+
+			assert.NotNil(t, err)
+			assert.Nil(t, todo)
+			assert.EqualValues(t, tt.err, err.Error())
+			assert.EqualValues(t, tt.status, err.Status())
+
+			if tt.errMsg != "" {
+				assert.EqualValues(t, tt.errMsg, err.Message())
+			}
+		})
+	}
+}
+
+func TestTodoService_PatchTodo_NotFoundError(t *testing.T) {
+	todo_domain.TodoDomain = &todoDomainMock{}
+
+	getTodoById = func(todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+		return nil, error_utils.NewNotFoundError("data not found")
+	}
+
+	todo, err := TodoService.PatchTodo(context.Background(), 1, 1, 0, []byte(`{"completed": true}`), todo_domain.MergePatchContentType)
+
+	assert.NotNil(t, err)
+	assert.Nil(t, todo)
+
+	assert.EqualValues(t, error_utils.NewNotFoundError("data not found"), err)
+}
+
+func TestTodoService_PatchTodo_VersionMismatch(t *testing.T) {
+	todo_domain.TodoDomain = &todoDomainMock{}
+
+	getTodoById = func(todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+		return &todo_domain.Todo{Id: 1, Title: "Homework", Description: "Deadline", Version: 3}, nil
+	}
+
+	patchTodo = func(todo *todo_domain.Todo, columns []string) (*todo_domain.Todo, error_utils.MessageErr) {
+		t.Fatal("patchTodo must not be called for a stale version")
+		return nil, nil
+	}
+
+	todo, err := TodoService.PatchTodo(context.Background(), 1, 1, 2, []byte(`{"completed": true}`), todo_domain.MergePatchContentType)
+
+	assert.Nil(t, todo)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusPreconditionFailed, err.Status())
+	assert.EqualValues(t, error_utils.CodePreconditionFailed, err.Error())
+}
+
 // ----------------
 // Test Get Todo By ID
 
@@ -247,11 +710,11 @@ func TestTodoService_GetTodoById_Success(t *testing.T) {
 		Completed:   false,
 	}
 
-	getTodoById = func(todoId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+	getTodoById = func(todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
 		return expectedVal, nil
 	}
 
-	todo, err := TodoService.GetTodoById(1)
+	todo, err := TodoService.GetTodoById(context.Background(), 1, 1)
 
 	assert.Nil(t, err)
 	assert.NotNil(t, todo)
@@ -262,11 +725,11 @@ func TestTodoService_GetTodoById_Success(t *testing.T) {
 func TestTodoService_GetTodoById_NotFoundError(t *testing.T) {
 	todo_domain.TodoDomain = &todoDomainMock{}
 
-	getTodoById = func(todoId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+	getTodoById = func(todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
 		return nil, error_utils.NewNotFoundError("data not found")
 	}
 
-	todo, err := TodoService.GetTodoById(1)
+	todo, err := TodoService.GetTodoById(context.Background(), 1, 1)
 
 	assert.NotNil(t, err)
 	assert.Nil(t, todo)
@@ -280,31 +743,89 @@ func TestTodoService_GetTodoById_NotFoundError(t *testing.T) {
 func TestTodoService_GetAllTodos_Success(t *testing.T) {
 	todo_domain.TodoDomain = &todoDomainMock{}
 
-	expectedVal := &[]todo_domain.Todo{
-		{
-			Id:          1,
-			Title:       "Homework",
-			Description: "Deadline: January 19, 2022",
-			Completed:   false,
-		},
-		{
-			Id:          2,
-			Title:       "Hacktiv8 Course Last Session",
-			Description: "Using Gmeet at Wednesday, January 12 2022",
-			Completed:   true,
+	expectedVal := &todo_domain.TodoPage{
+		Todos: []todo_domain.Todo{
+			{
+				Id:          1,
+				Title:       "Homework",
+				Description: "Deadline: January 19, 2022",
+				Completed:   false,
+			},
+			{
+				Id:          2,
+				Title:       "Hacktiv8 Course Last Session",
+				Description: "Using Gmeet at Wednesday, January 12 2022",
+				Completed:   true,
+			},
 		},
+		Total: 2,
+		Limit: todo_domain.DefaultTodoLimit,
 	}
 
-	getAllTodos = func() (*[]todo_domain.Todo, error_utils.MessageErr) {
+	var receivedQuery *todo_domain.TodoQuery
+
+	getAllTodos = func(query *todo_domain.TodoQuery) (*todo_domain.TodoPage, error_utils.MessageErr) {
+		receivedQuery = query
 		return expectedVal, nil
 	}
 
-	todo, err := TodoService.GetAllTodos()
+	todo, err := TodoService.GetAllTodos(context.Background(), &todo_domain.TodoQuery{})
 
 	assert.Nil(t, err)
 	assert.NotNil(t, todo)
 
 	assert.EqualValues(t, expectedVal, todo)
+	assert.EqualValues(t, todo_domain.DefaultTodoLimit, receivedQuery.Limit)
+	assert.EqualValues(t, todo_domain.SortById, receivedQuery.Sort)
+}
+
+func TestTodoService_GetAllTodos_BadRequest(t *testing.T) {
+	todo_domain.TodoDomain = &todoDomainMock{}
+
+	tests := []struct {
+		name   string
+		query  *todo_domain.TodoQuery
+		errMsg string
+	}{
+		{
+			name:   "limit too large",
+			query:  &todo_domain.TodoQuery{Limit: 1000},
+			errMsg: "limit must be between 1 and 100",
+		},
+		{
+			name:   "negative offset",
+			query:  &todo_domain.TodoQuery{Offset: -1},
+			errMsg: "offset must not be negative",
+		},
+		{
+			name:   "unknown sort",
+			query:  &todo_domain.TodoQuery{Sort: "description"},
+			errMsg: "sort must be one of id, -id, title, position, -priority",
+		},
+		{
+			name:   "cursor with offset",
+			query:  &todo_domain.TodoQuery{Cursor: "eyJzIjoiaWQiLCJpIjoyfQ", Offset: 10},
+			errMsg: "cursor and offset cannot be used together",
+		},
+		{
+			name:   "malformed cursor",
+			query:  &todo_domain.TodoQuery{Cursor: "not-a-cursor"},
+			errMsg: "invalid cursor",
+		},
+	}
+
+	for _, tt := range tests {
+
+		t.Run(tt.name, func(t *testing.T) {
+			todo, err := TodoService.GetAllTodos(context.Background(), tt.query)
+
+			assert.NotNil(t, err)
+			assert.Nil(t, todo)
+			assert.EqualValues(t, "bad_request", err.Error())
+			assert.EqualValues(t, tt.errMsg, err.Message())
+			assert.EqualValues(t, http.StatusBadRequest, err.Status())
+		})
+	}
 }
 
 // ----------------
@@ -313,16 +834,19 @@ func TestTodoService_GetAllTodos_Success(t *testing.T) {
 func TestTodoService_DeleteTodoById_Success(t *testing.T) {
 	todo_domain.TodoDomain = &todoDomainMock{}
 
-	expectedVal := &map[string]interface{}{
-		"StatusDelete": "Success",
-		"AffectedRow":  1,
-	}
+	deletedAt := time.Now()
+	expectedVal := &todo_domain.DeleteResult{Id: 1, DeletedAt: &deletedAt}
 
-	deleteTodoById = func(todoId int64) (*map[string]interface{}, error_utils.MessageErr) {
+	deleteTodoById = func(todoId int64, ownerId int64, version int64) (*todo_domain.DeleteResult, error_utils.MessageErr) {
 		return expectedVal, nil
 	}
 
-	todo, err := TodoService.DeleteTodoById(1)
+	purgeTodoById = func(todoId int64, ownerId int64, version int64) (*todo_domain.DeleteResult, error_utils.MessageErr) {
+		t.Fatal("purgeTodoById must not be called without permanent")
+		return nil, nil
+	}
+
+	todo, err := TodoService.DeleteTodoById(context.Background(), 1, 1, 0, false)
 
 	assert.Nil(t, err)
 	assert.NotNil(t, todo)
@@ -330,13 +854,34 @@ func TestTodoService_DeleteTodoById_Success(t *testing.T) {
 	assert.EqualValues(t, expectedVal, todo)
 }
 
+func TestTodoService_DeleteTodoById_Permanent(t *testing.T) {
+	todo_domain.TodoDomain = &todoDomainMock{}
+
+	expectedVal := &todo_domain.DeleteResult{Id: 1, Permanent: true}
+
+	deleteTodoById = func(todoId int64, ownerId int64, version int64) (*todo_domain.DeleteResult, error_utils.MessageErr) {
+		t.Fatal("deleteTodoById must not be called with permanent")
+		return nil, nil
+	}
+
+	purgeTodoById = func(todoId int64, ownerId int64, version int64) (*todo_domain.DeleteResult, error_utils.MessageErr) {
+		return expectedVal, nil
+	}
+
+	todo, err := TodoService.DeleteTodoById(context.Background(), 1, 1, 0, true)
+
+	assert.Nil(t, err)
+	assert.EqualValues(t, expectedVal, todo)
+}
+
 func TestTodoService_DeleteTodoById_NotFoundError(t *testing.T) {
+	todo_domain.TodoDomain = &todoDomainMock{}
 
-	deleteTodoById = func(todoId int64) (*map[string]interface{}, error_utils.MessageErr) {
+	deleteTodoById = func(todoId int64, ownerId int64, version int64) (*todo_domain.DeleteResult, error_utils.MessageErr) {
 		return nil, error_utils.NewNotFoundError("data not found")
 	}
 
-	todo, err := TodoService.DeleteTodoById(1)
+	todo, err := TodoService.DeleteTodoById(context.Background(), 1, 1, 0, false)
 
 	assert.NotNil(t, err)
 	assert.Nil(t, todo)
@@ -0,0 +1,205 @@
+package todo_service
+
+import (
+	"assignment-4/domain/todo_domain"
+	"assignment-4/utils/error_utils"
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("assignment-4/service/todo_service")
+
+// todoServiceTracing wraps every todoServiceInterface method in a span.
+type todoServiceTracing struct {
+	next todoServiceInterface
+}
+
+func WithTracing(next todoServiceInterface) todoServiceInterface {
+	return &todoServiceTracing{next: next}
+}
+
+func startSpan(ctx context.Context, method string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "TodoService."+method, trace.WithAttributes(attributes...))
+}
+
+func endSpan(span trace.Span, err error_utils.MessageErr) {
+	if err != nil {
+		span.SetAttributes(attribute.String("error.code", err.Error()))
+		span.SetStatus(codes.Error, err.Message())
+	}
+
+	span.End()
+}
+
+// validate runs todo.Validate in its own span, so validation time shows up
+// next to the repository calls.
+func validate(ctx context.Context, todo *todo_domain.Todo) error_utils.MessageErr {
+	_, span := tracer.Start(ctx, "Todo.Validate")
+	err := todo.Validate()
+	endSpan(span, err)
+
+	return err
+}
+
+func (t *todoServiceTracing) CreateTodo(ctx context.Context, todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr) {
+	ctx, span := startSpan(ctx, "CreateTodo")
+	res, err := t.next.CreateTodo(ctx, todo)
+	endSpan(span, err)
+
+	return res, err
+}
+
+func (t *todoServiceTracing) UpdateTodo(ctx context.Context, todo *todo_domain.Todo) (*todo_domain.Todo, error_utils.MessageErr) {
+	ctx, span := startSpan(ctx, "UpdateTodo", attribute.Int64("todo.id", todo.Id), attribute.Int64("todo.version", todo.Version))
+	res, err := t.next.UpdateTodo(ctx, todo)
+	endSpan(span, err)
+
+	return res, err
+}
+
+func (t *todoServiceTracing) PatchTodo(ctx context.Context, todoId int64, ownerId int64, version int64, patch []byte, contentType string) (*todo_domain.Todo, error_utils.MessageErr) {
+	ctx, span := startSpan(ctx, "PatchTodo", attribute.Int64("todo.id", todoId), attribute.Int64("todo.version", version), attribute.String("patch.content_type", contentType))
+	res, err := t.next.PatchTodo(ctx, todoId, ownerId, version, patch, contentType)
+	endSpan(span, err)
+
+	return res, err
+}
+
+func (t *todoServiceTracing) GetTodoById(ctx context.Context, todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+	ctx, span := startSpan(ctx, "GetTodoById", attribute.Int64("todo.id", todoId))
+	res, err := t.next.GetTodoById(ctx, todoId, ownerId)
+	endSpan(span, err)
+
+	return res, err
+}
+
+func (t *todoServiceTracing) GetAllTodos(ctx context.Context, query *todo_domain.TodoQuery) (*todo_domain.TodoPage, error_utils.MessageErr) {
+	ctx, span := startSpan(ctx, "GetAllTodos", attribute.String("query.sort", query.Sort), attribute.Int("query.limit", query.Limit))
+	res, err := t.next.GetAllTodos(ctx, query)
+	endSpan(span, err)
+
+	return res, err
+}
+
+func (t *todoServiceTracing) SearchTodos(ctx context.Context, search *todo_domain.TodoSearch) (*todo_domain.SearchPage, error_utils.MessageErr) {
+	ctx, span := startSpan(ctx, "SearchTodos", attribute.Int("query.limit", search.Limit), attribute.Int("query.offset", search.Offset))
+	res, err := t.next.SearchTodos(ctx, search)
+	endSpan(span, err)
+
+	return res, err
+}
+
+func (t *todoServiceTracing) DeleteTodoById(ctx context.Context, todoId int64, ownerId int64, version int64, permanent bool) (*todo_domain.DeleteResult, error_utils.MessageErr) {
+	ctx, span := startSpan(ctx, "DeleteTodoById", attribute.Int64("todo.id", todoId), attribute.Int64("todo.version", version), attribute.Bool("todo.permanent", permanent))
+	res, err := t.next.DeleteTodoById(ctx, todoId, ownerId, version, permanent)
+	endSpan(span, err)
+
+	return res, err
+}
+
+func (t *todoServiceTracing) RestoreTodoById(ctx context.Context, todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+	ctx, span := startSpan(ctx, "RestoreTodoById", attribute.Int64("todo.id", todoId))
+	res, err := t.next.RestoreTodoById(ctx, todoId, ownerId)
+	endSpan(span, err)
+
+	return res, err
+}
+
+func (t *todoServiceTracing) PurgeTrash(ctx context.Context, deletedBefore time.Time) (int64, error_utils.MessageErr) {
+	ctx, span := startSpan(ctx, "PurgeTrash", attribute.String("trash.deleted_before", deletedBefore.UTC().Format(time.RFC3339)))
+	res, err := t.next.PurgeTrash(ctx, deletedBefore)
+	if err == nil {
+		span.SetAttributes(attribute.Int64("trash.purged", res))
+	}
+	endSpan(span, err)
+
+	return res, err
+}
+
+func (t *todoServiceTracing) ApplyBatch(ctx context.Context, ownerId int64, batch *todo_domain.Batch) (*todo_domain.BatchResult, error_utils.MessageErr) {
+	ctx, span := startSpan(ctx, "ApplyBatch", attribute.String("batch.mode", batch.Mode), attribute.Int("batch.size", len(batch.Operations)))
+	res, err := t.next.ApplyBatch(ctx, ownerId, batch)
+	if res != nil {
+		span.SetAttributes(attribute.Bool("batch.committed", res.Committed), attribute.Int("batch.failed", res.Failed))
+	}
+	endSpan(span, err)
+
+	return res, err
+}
+
+func (t *todoServiceTracing) GetTodoWithChecklist(ctx context.Context, todoId int64, ownerId int64) (*todo_domain.Todo, error_utils.MessageErr) {
+	ctx, span := startSpan(ctx, "GetTodoWithChecklist", attribute.Int64("todo.id", todoId))
+	res, err := t.next.GetTodoWithChecklist(ctx, todoId, ownerId)
+	endSpan(span, err)
+
+	return res, err
+}
+
+func (t *todoServiceTracing) GetChecklist(ctx context.Context, todoId int64, ownerId int64) (*todo_domain.Checklist, error_utils.MessageErr) {
+	ctx, span := startSpan(ctx, "GetChecklist", attribute.Int64("todo.id", todoId))
+	res, err := t.next.GetChecklist(ctx, todoId, ownerId)
+	endSpan(span, err)
+
+	return res, err
+}
+
+func (t *todoServiceTracing) AddChecklistItem(ctx context.Context, item *todo_domain.ChecklistItem) (*todo_domain.ChecklistItem, error_utils.MessageErr) {
+	ctx, span := startSpan(ctx, "AddChecklistItem", attribute.Int64("todo.id", item.TodoId))
+	res, err := t.next.AddChecklistItem(ctx, item)
+	endSpan(span, err)
+
+	return res, err
+}
+
+func (t *todoServiceTracing) UpdateChecklistItem(ctx context.Context, todoId int64, ownerId int64, itemId int64, patch *todo_domain.ChecklistItemPatch) (*todo_domain.ChecklistItem, error_utils.MessageErr) {
+	ctx, span := startSpan(ctx, "UpdateChecklistItem", attribute.Int64("todo.id", todoId), attribute.Int64("checklist_item.id", itemId))
+	res, err := t.next.UpdateChecklistItem(ctx, todoId, ownerId, itemId, patch)
+	endSpan(span, err)
+
+	return res, err
+}
+
+func (t *todoServiceTracing) ToggleChecklistItem(ctx context.Context, todoId int64, ownerId int64, itemId int64) (*todo_domain.ChecklistItem, error_utils.MessageErr) {
+	ctx, span := startSpan(ctx, "ToggleChecklistItem", attribute.Int64("todo.id", todoId), attribute.Int64("checklist_item.id", itemId))
+	res, err := t.next.ToggleChecklistItem(ctx, todoId, ownerId, itemId)
+	endSpan(span, err)
+
+	return res, err
+}
+
+func (t *todoServiceTracing) DeleteChecklistItem(ctx context.Context, todoId int64, ownerId int64, itemId int64) error_utils.MessageErr {
+	ctx, span := startSpan(ctx, "DeleteChecklistItem", attribute.Int64("todo.id", todoId), attribute.Int64("checklist_item.id", itemId))
+	err := t.next.DeleteChecklistItem(ctx, todoId, ownerId, itemId)
+	endSpan(span, err)
+
+	return err
+}
+
+func (t *todoServiceTracing) ReorderChecklist(ctx context.Context, todoId int64, ownerId int64, order *todo_domain.ChecklistOrder) (*todo_domain.Checklist, error_utils.MessageErr) {
+	ctx, span := startSpan(ctx, "ReorderChecklist", attribute.Int64("todo.id", todoId), attribute.Int("checklist.size", len(order.ItemIds)))
+	res, err := t.next.ReorderChecklist(ctx, todoId, ownerId, order)
+	endSpan(span, err)
+
+	return res, err
+}
+
+func (t *todoServiceTracing) GetOccurrences(ctx context.Context, todoId int64, ownerId int64, count int) (*todo_domain.Occurrences, error_utils.MessageErr) {
+	ctx, span := startSpan(ctx, "GetOccurrences", attribute.Int64("todo.id", todoId), attribute.Int("occurrences.count", count))
+	res, err := t.next.GetOccurrences(ctx, todoId, ownerId, count)
+	endSpan(span, err)
+
+	return res, err
+}
+
+func (t *todoServiceTracing) MoveTodo(ctx context.Context, move *todo_domain.TodoMove) (*todo_domain.Todo, error_utils.MessageErr) {
+	ctx, span := startSpan(ctx, "MoveTodo", attribute.Int64("todo.id", move.TodoId))
+	res, err := t.next.MoveTodo(ctx, move)
+	endSpan(span, err)
+
+	return res, err
+}
@@ -0,0 +1,106 @@
+package user_service
+
+import (
+	"assignment-4/domain/user_domain"
+	"assignment-4/utils/crypto_utils"
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/logger_utils"
+	"assignment-4/utils/token_utils"
+	"context"
+	"net/http"
+)
+
+var UserService userServiceInterface = &userService{}
+
+// dummyPasswordHash is compared against on logins with an unknown email, so
+// they take as long as a wrong password and do not reveal which emails are
+// registered. It has the cost of crypto_utils.HashPassword.
+const dummyPasswordHash = "$2a$10$/LMc./kDCJggH4OyXw0by.nzOoLUtjsSXt5.cC7BI1qkJ0C.E2gJ."
+
+type userServiceInterface interface {
+	Register(context.Context, *user_domain.User) (*user_domain.User, error_utils.MessageErr)
+	Login(context.Context, *user_domain.LoginRequest) (*token_utils.Tokens, error_utils.MessageErr)
+	RefreshToken(context.Context, *user_domain.RefreshTokenRequest) (*token_utils.Tokens, error_utils.MessageErr)
+}
+
+type userService struct{}
+
+func (u *userService) Register(ctx context.Context, userReq *user_domain.User) (*user_domain.User, error_utils.MessageErr) {
+	err := userReq.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	hashed, hashErr := crypto_utils.HashPassword(userReq.Password)
+
+	if hashErr != nil {
+		logger_utils.Ctx(ctx).Error().Err(hashErr).Msg("hashing password")
+		return nil, error_utils.NewInternalServerError("something went wrong")
+	}
+
+	res, err := user_domain.UserDomain.CreateUser(ctx, &user_domain.User{
+		Email:    userReq.Email,
+		Password: hashed,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.Password = ""
+
+	logger_utils.Ctx(ctx).Info().Int64("user_id", res.Id).Msg("user registered")
+
+	return res, nil
+}
+
+func (u *userService) Login(ctx context.Context, loginReq *user_domain.LoginRequest) (*token_utils.Tokens, error_utils.MessageErr) {
+	err := loginReq.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := user_domain.UserDomain.GetUserByEmail(ctx, loginReq.Email)
+
+	if err != nil {
+		if err.Status() == http.StatusNotFound {
+			crypto_utils.ComparePassword(dummyPasswordHash, loginReq.Password)
+			return nil, error_utils.NewNotAuthenticated("invalid email or password")
+		}
+		return nil, err
+	}
+
+	if !crypto_utils.ComparePassword(user.Password, loginReq.Password) {
+		logger_utils.Ctx(ctx).Warn().Int64("user_id", user.Id).Msg("login with wrong password")
+		return nil, error_utils.NewNotAuthenticated("invalid email or password")
+	}
+
+	return token_utils.GenerateTokens(user.Id, user.Email)
+}
+
+func (u *userService) RefreshToken(ctx context.Context, refreshReq *user_domain.RefreshTokenRequest) (*token_utils.Tokens, error_utils.MessageErr) {
+	err := refreshReq.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := token_utils.ValidateToken(refreshReq.RefreshToken, token_utils.RefreshToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := user_domain.UserDomain.GetUserById(ctx, claims.UserId())
+
+	if err != nil {
+		if err.Status() == http.StatusNotFound {
+			return nil, error_utils.NewNotAuthenticated("invalid or expired token")
+		}
+		return nil, err
+	}
+
+	return token_utils.GenerateTokens(user.Id, user.Email)
+}
@@ -0,0 +1,215 @@
+package user_service
+
+import (
+	"assignment-4/domain/user_domain"
+	"assignment-4/utils/crypto_utils"
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/token_utils"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	createUser     func(user *user_domain.User) (*user_domain.User, error_utils.MessageErr)
+	getUserByEmail func(email string) (*user_domain.User, error_utils.MessageErr)
+	getUserById    func(userId int64) (*user_domain.User, error_utils.MessageErr)
+)
+
+type userDomainMock struct{}
+
+func (u *userDomainMock) CreateUser(ctx context.Context, user *user_domain.User) (*user_domain.User, error_utils.MessageErr) {
+	return createUser(user)
+}
+
+func (u *userDomainMock) GetUserByEmail(ctx context.Context, email string) (*user_domain.User, error_utils.MessageErr) {
+	return getUserByEmail(email)
+}
+
+func (u *userDomainMock) GetUserById(ctx context.Context, userId int64) (*user_domain.User, error_utils.MessageErr) {
+	return getUserById(userId)
+}
+
+// ----------------
+// Test Register
+
+func TestUserService_Register_Success(t *testing.T) {
+	user_domain.UserDomain = &userDomainMock{}
+
+	var storedPassword string
+
+	createUser = func(user *user_domain.User) (*user_domain.User, error_utils.MessageErr) {
+		storedPassword = user.Password
+		return &user_domain.User{Id: 1, Email: user.Email, Password: user.Password}, nil
+	}
+
+	user, err := UserService.Register(context.Background(), &user_domain.User{
+		Email:    " John@Mail.com ",
+		Password: "secret123",
+	})
+
+	require.Nil(t, err)
+	assert.EqualValues(t, 1, user.Id)
+	assert.EqualValues(t, "john@mail.com", user.Email)
+	assert.Empty(t, user.Password)
+	assert.NotEqual(t, "secret123", storedPassword)
+	assert.True(t, crypto_utils.ComparePassword(storedPassword, "secret123"))
+}
+
+func TestUserService_Register_BadRequest(t *testing.T) {
+	user_domain.UserDomain = &userDomainMock{}
+
+	tests := []struct {
+		name    string
+		userReq *user_domain.User
+		errMsg  string
+	}{
+		{
+			name:    "empty email",
+			userReq: &user_domain.User{Password: "secret123"},
+			errMsg:  "email is required",
+		},
+		{
+			name:    "invalid email",
+			userReq: &user_domain.User{Email: "john", Password: "secret123"},
+			errMsg:  "invalid email format",
+		},
+		{
+			name:    "short password",
+			userReq: &user_domain.User{Email: "john@mail.com", Password: "123"},
+			errMsg:  "password has to have a minimum length of 6 characters",
+		},
+		{
+			name:    "password longer than bcrypt allows",
+			userReq: &user_domain.User{Email: "john@mail.com", Password: strings.Repeat("é", 37)},
+			errMsg:  "password has to have a maximum length of 72 bytes",
+		},
+	}
+
+	for _, tt := range tests {
+
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := UserService.Register(context.Background(), tt.userReq)
+
+			assert.Nil(t, user)
+			require.NotNil(t, err)
+			assert.EqualValues(t, "bad_request", err.Error())
+			assert.EqualValues(t, tt.errMsg, err.Message())
+			assert.EqualValues(t, http.StatusBadRequest, err.Status())
+		})
+	}
+}
+
+// ----------------
+// Test Login
+
+func TestUserService_DummyPasswordHash(t *testing.T) {
+	cost, err := bcrypt.Cost([]byte(dummyPasswordHash))
+
+	require.Nil(t, err)
+	assert.EqualValues(t, bcrypt.DefaultCost, cost)
+	assert.False(t, crypto_utils.ComparePassword(dummyPasswordHash, "secret123"))
+}
+
+func TestUserService_Login_Success(t *testing.T) {
+	token_utils.SetSecret("test-secret")
+	user_domain.UserDomain = &userDomainMock{}
+
+	hashed, _ := crypto_utils.HashPassword("secret123")
+
+	getUserByEmail = func(email string) (*user_domain.User, error_utils.MessageErr) {
+		return &user_domain.User{Id: 1, Email: email, Password: hashed}, nil
+	}
+
+	tokens, err := UserService.Login(context.Background(), &user_domain.LoginRequest{
+		Email:    "john@mail.com",
+		Password: "secret123",
+	})
+
+	require.Nil(t, err)
+	require.NotNil(t, tokens)
+
+	claims, err := token_utils.ValidateToken(tokens.AccessToken, token_utils.AccessToken)
+
+	require.Nil(t, err)
+	assert.EqualValues(t, 1, claims.UserId())
+}
+
+func TestUserService_Login_NotAuthenticated(t *testing.T) {
+	token_utils.SetSecret("test-secret")
+	user_domain.UserDomain = &userDomainMock{}
+
+	hashed, _ := crypto_utils.HashPassword("secret123")
+
+	tests := []struct {
+		name           string
+		getUserByEmail func(email string) (*user_domain.User, error_utils.MessageErr)
+	}{
+		{
+			name: "wrong password",
+			getUserByEmail: func(email string) (*user_domain.User, error_utils.MessageErr) {
+				return &user_domain.User{Id: 1, Email: email, Password: hashed}, nil
+			},
+		},
+		{
+			name: "unknown email",
+			getUserByEmail: func(email string) (*user_domain.User, error_utils.MessageErr) {
+				return nil, error_utils.NewNotFoundError("no record found")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+
+		t.Run(tt.name, func(t *testing.T) {
+			getUserByEmail = tt.getUserByEmail
+
+			tokens, err := UserService.Login(context.Background(), &user_domain.LoginRequest{
+				Email:    "john@mail.com",
+				Password: "wrong-password",
+			})
+
+			assert.Nil(t, tokens)
+			require.NotNil(t, err)
+			assert.EqualValues(t, http.StatusUnauthorized, err.Status())
+			assert.EqualValues(t, "invalid email or password", err.Message())
+		})
+	}
+}
+
+// ----------------
+// Test Refresh Token
+
+func TestUserService_RefreshToken_Success(t *testing.T) {
+	token_utils.SetSecret("test-secret")
+	user_domain.UserDomain = &userDomainMock{}
+
+	getUserById = func(userId int64) (*user_domain.User, error_utils.MessageErr) {
+		return &user_domain.User{Id: userId, Email: "john@mail.com"}, nil
+	}
+
+	issued, _ := token_utils.GenerateTokens(1, "john@mail.com")
+
+	tokens, err := UserService.RefreshToken(context.Background(), &user_domain.RefreshTokenRequest{RefreshToken: issued.RefreshToken})
+
+	require.Nil(t, err)
+	assert.NotEmpty(t, tokens.AccessToken)
+}
+
+func TestUserService_RefreshToken_AccessTokenRejected(t *testing.T) {
+	token_utils.SetSecret("test-secret")
+	user_domain.UserDomain = &userDomainMock{}
+
+	issued, _ := token_utils.GenerateTokens(1, "john@mail.com")
+
+	tokens, err := UserService.RefreshToken(context.Background(), &user_domain.RefreshTokenRequest{RefreshToken: issued.AccessToken})
+
+	assert.Nil(t, tokens)
+	require.NotNil(t, err)
+	assert.EqualValues(t, http.StatusUnauthorized, err.Status())
+}
@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// stdoutExporter writes finished spans as JSON lines, for local debugging
+// without a collector.
+type stdoutExporter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+type stdoutSpan struct {
+	Name       string                 `json:"name"`
+	TraceId    string                 `json:"trace_id"`
+	SpanId     string                 `json:"span_id"`
+	ParentId   string                 `json:"parent_id,omitempty"`
+	Kind       string                 `json:"kind"`
+	Start      time.Time              `json:"start"`
+	Duration   time.Duration          `json:"duration_ns"`
+	Status     string                 `json:"status"`
+	Error      string                 `json:"error,omitempty"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+func newStdoutExporter(w io.Writer) *stdoutExporter {
+	return &stdoutExporter{encoder: json.NewEncoder(w)}
+}
+
+func (e *stdoutExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, span := range spans {
+		out := stdoutSpan{
+			Name:     span.Name(),
+			TraceId:  span.SpanContext().TraceID().String(),
+			SpanId:   span.SpanContext().SpanID().String(),
+			Kind:     span.SpanKind().String(),
+			Start:    span.StartTime(),
+			Duration: span.EndTime().Sub(span.StartTime()),
+			Status:   span.Status().Code.String(),
+			Error:    span.Status().Description,
+		}
+
+		if span.Parent().IsValid() {
+			out.ParentId = span.Parent().SpanID().String()
+		}
+
+		if attributes := span.Attributes(); len(attributes) > 0 {
+			out.Attributes = make(map[string]interface{}, len(attributes))
+			for _, attribute := range attributes {
+				out.Attributes[string(attribute.Key)] = attribute.Value.AsInterface()
+			}
+		}
+
+		if err := e.encoder.Encode(out); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *stdoutExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
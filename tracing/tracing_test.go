@@ -0,0 +1,47 @@
+package tracing
+
+import (
+	"assignment-4/config"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestSetup_StdoutExporter(t *testing.T) {
+	var out bytes.Buffer
+
+	shutdown, err := Setup(config.TracingConfig{Exporter: ExporterStdout, ServiceName: "todo-api", SampleRatio: 1}, &out)
+	require.Nil(t, err)
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	_, child := otel.Tracer("test").Start(ctx, "child")
+	child.SetAttributes(attribute.String("db.statement.name", "queryGetTodoById"))
+	child.End()
+	parent.End()
+
+	require.Nil(t, shutdown(context.Background()))
+
+	decoder := json.NewDecoder(&out)
+
+	var first, second stdoutSpan
+	require.Nil(t, decoder.Decode(&first))
+	require.Nil(t, decoder.Decode(&second))
+
+	assert.EqualValues(t, "child", first.Name)
+	assert.EqualValues(t, "queryGetTodoById", first.Attributes["db.statement.name"])
+	assert.EqualValues(t, "parent", second.Name)
+	assert.EqualValues(t, second.TraceId, first.TraceId)
+	assert.EqualValues(t, second.SpanId, first.ParentId)
+}
+
+func TestSetup_UnknownExporter(t *testing.T) {
+	_, err := Setup(config.TracingConfig{Exporter: "zipkin"}, nil)
+
+	assert.NotNil(t, err)
+}
@@ -2,16 +2,89 @@ package error_formats
 
 import (
 	"assignment-4/utils/error_utils"
-	"strings"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"syscall"
+
+	"github.com/lib/pq"
 )
 
+// constraintMessages gives client facing messages for constraints whose
+// violation is an expected user error rather than a bug.
+var constraintMessages = map[string]string{
+	"users_email_key":               "email has been taken, try another one",
+	"todos_remind_before_due_check": "remind_at must be before due_at",
+	"lists_owner_id_name_key":       "a list with this name already exists",
+	"todos_list_id_fkey":            "list does not exist",
+	"tags_owner_id_name_key":        "a tag with this name already exists",
+}
+
+// ParseError turns a database error into a MessageErr. The original error is
+// kept as the cause so it can be logged without being sent to the client.
 func ParseError(err error) error_utils.MessageErr {
+	return error_utils.Wrap(classifyError(err), err)
+}
 
-	if strings.Contains(err.Error(), "no rows in result set") {
+func classifyError(err error) error_utils.MessageErr {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return error_utils.NewGatewayTimeoutError("request timed out")
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
 		return error_utils.NewNotFoundError("no record found")
-	} else if strings.Contains(err.Error(), "violates unique constraint") {
-		return error_utils.NewBadRequest("email has been taken, try another one")
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return classifyPqError(pqErr)
+	}
+
+	if isConnectionError(err) {
+		return error_utils.NewServiceUnavailableError("database is unavailable, try again later")
 	}
 
 	return error_utils.NewInternalServerError("something went wrong")
 }
+
+func classifyPqError(err *pq.Error) error_utils.MessageErr {
+	switch err.Code.Name() {
+	case "unique_violation":
+		return error_utils.NewConflictError(constraintMessage(err, "record already exists"))
+	case "foreign_key_violation":
+		return error_utils.NewConflictError(constraintMessage(err, "referenced record does not exist"))
+	case "not_null_violation":
+		return error_utils.NewUnprocessibleEntityError(err.Column + " must not be null")
+	case "check_violation":
+		return error_utils.NewUnprocessibleEntityError(constraintMessage(err, "record violates a data constraint"))
+	case "serialization_failure", "deadlock_detected":
+		return error_utils.NewSerializationFailureError("request conflicted with a concurrent update, try again")
+	case "too_many_connections", "admin_shutdown", "crash_shutdown", "cannot_connect_now":
+		return error_utils.NewServiceUnavailableError("database is unavailable, try again later")
+	}
+
+	if err.Code.Class() == "08" {
+		return error_utils.NewServiceUnavailableError("database is unavailable, try again later")
+	}
+
+	return error_utils.NewInternalServerError("something went wrong")
+}
+
+func constraintMessage(err *pq.Error, fallback string) string {
+	if message, ok := constraintMessages[err.Constraint]; ok {
+		return message
+	}
+
+	return fallback
+}
+
+func isConnectionError(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
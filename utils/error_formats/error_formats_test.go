@@ -0,0 +1,120 @@
+package error_formats
+
+import (
+	"assignment-4/utils/error_utils"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		code    string
+		message string
+	}{
+		{
+			name:    "no rows",
+			err:     sql.ErrNoRows,
+			status:  http.StatusNotFound,
+			code:    "not_found",
+			message: "no record found",
+		},
+		{
+			name:    "known unique constraint",
+			err:     &pq.Error{Code: "23505", Constraint: "users_email_key"},
+			status:  http.StatusConflict,
+			code:    "conflict",
+			message: "email has been taken, try another one",
+		},
+		{
+			name:    "duplicate tag name",
+			err:     &pq.Error{Code: "23505", Constraint: "tags_owner_id_name_key"},
+			status:  http.StatusConflict,
+			code:    "conflict",
+			message: "a tag with this name already exists",
+		},
+		{
+			name:    "unknown unique constraint",
+			err:     &pq.Error{Code: "23505", Constraint: "todos_pkey"},
+			status:  http.StatusConflict,
+			code:    "conflict",
+			message: "record already exists",
+		},
+		{
+			name:    "foreign key violation",
+			err:     &pq.Error{Code: "23503", Constraint: "todos_owner_id_fkey"},
+			status:  http.StatusConflict,
+			code:    "conflict",
+			message: "referenced record does not exist",
+		},
+		{
+			name:    "not null violation",
+			err:     &pq.Error{Code: "23502", Column: "title"},
+			status:  http.StatusUnprocessableEntity,
+			code:    "invalid_request",
+			message: "title must not be null",
+		},
+		{
+			name:    "check violation",
+			err:     &pq.Error{Code: "23514", Constraint: "todos_remind_before_due_check"},
+			status:  http.StatusUnprocessableEntity,
+			code:    "invalid_request",
+			message: "remind_at must be before due_at",
+		},
+		{
+			name:   "serialization failure",
+			err:    &pq.Error{Code: "40001"},
+			status: http.StatusServiceUnavailable,
+			code:   "serialization_failure",
+		},
+		{
+			name:   "connection exception",
+			err:    &pq.Error{Code: "08006"},
+			status: http.StatusServiceUnavailable,
+			code:   "service_unavailable",
+		},
+		{
+			name:   "bad connection",
+			err:    fmt.Errorf("query: %w", driver.ErrBadConn),
+			status: http.StatusServiceUnavailable,
+			code:   "service_unavailable",
+		},
+		{
+			name:   "deadline exceeded",
+			err:    context.DeadlineExceeded,
+			status: http.StatusGatewayTimeout,
+			code:   "gateway_timeout",
+		},
+		{
+			name:    "unknown error",
+			err:     &pq.Error{Code: "42P01", Message: `relation "todos" does not exist`},
+			status:  http.StatusInternalServerError,
+			code:    "server-error",
+			message: "something went wrong",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ParseError(tt.err)
+
+			assert.EqualValues(t, tt.status, err.Status())
+			assert.EqualValues(t, tt.code, err.Error())
+			assert.True(t, errors.Is(error_utils.Cause(err), tt.err))
+
+			if tt.message != "" {
+				assert.EqualValues(t, tt.message, err.Message())
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package error_utils
+
+import (
+	"net/http"
+	"sort"
+)
+
+// Stable machine-readable error codes. Clients match on these, so existing
+// values must never change; add a new code instead.
+const (
+	CodeBadRequest           = "bad_request"
+	CodeNotAuthenticated     = "not_authenticated"
+	CodeNotAuthorized        = "not_authorized"
+	CodeNotFound             = "not_found"
+	CodeConflict             = "conflict"
+	CodePreconditionFailed   = "precondition_failed"
+	CodePreconditionRequired = "precondition_required"
+	CodeUnsupportedMediaType = "unsupported_media_type"
+	CodeInvalidRequest       = "invalid_request"
+	CodeServerError          = "server-error"
+	CodeServiceUnavailable   = "service_unavailable"
+	CodeSerializationFailure = "serialization_failure"
+	CodeGatewayTimeout       = "gateway_timeout"
+)
+
+// ProblemTypeBase prefixes every code to form its problem type URI.
+const ProblemTypeBase = "/problems/"
+
+type ErrorCode struct {
+	Code        string `json:"code" example:"not_found"`
+	Type        string `json:"type" example:"/problems/not_found"`
+	Title       string `json:"title" example:"Resource not found"`
+	Status      int    `json:"status" example:"404"`
+	Description string `json:"description" example:"The requested resource does not exist or is not visible to the caller."`
+}
+
+var errorCodes = map[string]ErrorCode{}
+
+func registerCode(code string, status int, title, description string) {
+	errorCodes[code] = ErrorCode{
+		Code:        code,
+		Type:        ProblemTypeBase + code,
+		Title:       title,
+		Status:      status,
+		Description: description,
+	}
+}
+
+func init() {
+	registerCode(CodeBadRequest, http.StatusBadRequest, "Bad request",
+		"The request is malformed or failed validation. Validation failures list the offending inputs in fields.")
+	registerCode(CodeNotAuthenticated, http.StatusUnauthorized, "Not authenticated",
+		"The bearer token or credentials are missing, invalid or expired.")
+	registerCode(CodeNotAuthorized, http.StatusForbidden, "Not authorized",
+		"The caller is authenticated but may not perform this action.")
+	registerCode(CodeNotFound, http.StatusNotFound, "Resource not found",
+		"The requested resource does not exist or is not visible to the caller.")
+	registerCode(CodeConflict, http.StatusConflict, "Conflict",
+		"The request conflicts with an existing record, e.g. a duplicate unique value or a broken reference.")
+	registerCode(CodePreconditionFailed, http.StatusPreconditionFailed, "Precondition failed",
+		"The If-Match header does not match the current ETag, the resource was changed by someone else. Fetch it again and reapply the change.")
+	registerCode(CodePreconditionRequired, http.StatusPreconditionRequired, "Precondition required",
+		"The request must be conditional. Send the ETag from the last GET in an If-Match header.")
+	registerCode(CodeUnsupportedMediaType, http.StatusUnsupportedMediaType, "Unsupported media type",
+		"The request body is sent with a Content-Type this endpoint does not accept.")
+	registerCode(CodeInvalidRequest, http.StatusUnprocessableEntity, "Unprocessable request",
+		"The request is well formed but cannot be applied to the current state of the resource.")
+	registerCode(CodeServerError, http.StatusInternalServerError, "Internal server error",
+		"The server failed to handle the request. Retrying may succeed.")
+	registerCode(CodeServiceUnavailable, http.StatusServiceUnavailable, "Service unavailable",
+		"The database is unreachable or refusing connections. Retry after the Retry-After delay.")
+	registerCode(CodeSerializationFailure, http.StatusServiceUnavailable, "Concurrent update",
+		"The transaction lost a race with a concurrent one and was rolled back. Retrying the same request is safe.")
+	registerCode(CodeGatewayTimeout, http.StatusGatewayTimeout, "Request timed out",
+		"The request did not finish within the server's deadline and was cancelled.")
+}
+
+// LookupCode returns the registry entry for code.
+func LookupCode(code string) (ErrorCode, bool) {
+	errorCode, ok := errorCodes[code]
+	return errorCode, ok
+}
+
+// Codes returns every registered error code, ordered by status and code.
+func Codes() []ErrorCode {
+	codes := make([]ErrorCode, 0, len(errorCodes))
+	for _, errorCode := range errorCodes {
+		codes = append(codes, errorCode)
+	}
+
+	sort.Slice(codes, func(i, j int) bool {
+		if codes[i].Status != codes[j].Status {
+			return codes[i].Status < codes[j].Status
+		}
+		return codes[i].Code < codes[j].Code
+	})
+
+	return codes
+}
+
+func newMessageErr(code string, message string) *MessageErrData {
+	errorCode, ok := LookupCode(code)
+	if !ok {
+		errorCode, _ = LookupCode(CodeServerError)
+	}
+
+	return &MessageErrData{
+		ErrMessage: message,
+		ErrStatus:  errorCode.Status,
+		ErrError:   errorCode.Code,
+	}
+}
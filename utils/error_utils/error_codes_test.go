@@ -0,0 +1,57 @@
+package error_utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstructors_UseRegisteredCodes(t *testing.T) {
+	errs := []MessageErr{
+		NewNotFoundError("message"),
+		NewNotAuthenticated("message"),
+		NewUnAuthorized("message"),
+		NewBadRequest("message"),
+		NewInternalServerError("message"),
+		NewUnprocessibleEntityError("message"),
+		NewUnsupportedMediaTypeError("message"),
+		NewGatewayTimeoutError("message"),
+		NewConflictError("message"),
+		NewServiceUnavailableError("message"),
+		NewSerializationFailureError("message"),
+		NewPreconditionFailedError("message"),
+		NewPreconditionRequiredError("message"),
+		NewValidationError(nil),
+	}
+
+	for _, err := range errs {
+		errorCode, ok := LookupCode(err.Error())
+
+		assert.True(t, ok, err.Error())
+		assert.EqualValues(t, errorCode.Status, err.Status())
+		assert.EqualValues(t, ProblemTypeBase+err.Error(), errorCode.Type)
+	}
+}
+
+func TestCodes_Stable(t *testing.T) {
+	var codes []string
+	for _, errorCode := range Codes() {
+		codes = append(codes, errorCode.Code)
+	}
+
+	assert.EqualValues(t, []string{
+		"bad_request",
+		"not_authenticated",
+		"not_authorized",
+		"not_found",
+		"conflict",
+		"precondition_failed",
+		"unsupported_media_type",
+		"invalid_request",
+		"precondition_required",
+		"server-error",
+		"serialization_failure",
+		"service_unavailable",
+		"gateway_timeout",
+	}, codes)
+}
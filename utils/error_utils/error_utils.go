@@ -1,6 +1,6 @@
 package error_utils
 
-import "net/http"
+import "strings"
 
 type MessageErr interface {
 	Message() string
@@ -12,6 +12,8 @@ type MessageErrData struct {
 	ErrMessage string `json:"message"`
 	ErrStatus  int    `json:"status"`
 	ErrError   string `json:"error"`
+	RequestId  string `json:"request_id,omitempty"`
+	cause      error
 }
 
 func (e *MessageErrData) Message() string {
@@ -26,50 +28,123 @@ func (e *MessageErrData) Error() string {
 	return e.ErrError
 }
 
-func NewNotFoundError(message string) MessageErr {
-	return &MessageErrData{
-		ErrMessage: message,
-		ErrStatus:  http.StatusNotFound,
-		ErrError:   "not_found",
+// Cause is the internal error behind e. It is meant for logs and is never
+// serialized to the client.
+func (e *MessageErrData) Cause() error {
+	return e.cause
+}
+
+func (e *MessageErrData) setCause(cause error) {
+	e.cause = cause
+}
+
+func (e *MessageErrData) requestId() string {
+	return e.RequestId
+}
+
+func (e *MessageErrData) setRequestId(id string) {
+	e.RequestId = id
+}
+
+// Wrap attaches an internal cause to err.
+func Wrap(err MessageErr, cause error) MessageErr {
+	if setter, ok := err.(interface{ setCause(error) }); ok {
+		setter.setCause(cause)
 	}
+
+	return err
 }
 
-func NewNotAuthenticated(message string) MessageErr {
-	return &MessageErrData{
-		ErrMessage: message,
-		ErrStatus:  http.StatusUnauthorized,
-		ErrError:   "not_authenticated",
+// Cause returns the internal cause attached to err with Wrap, if any.
+func Cause(err MessageErr) error {
+	if causer, ok := err.(interface{ Cause() error }); ok {
+		return causer.Cause()
+	}
+
+	return nil
+}
+
+// WithRequestId stamps err with the id of the request it is answering, so
+// users can quote it in bug reports.
+func WithRequestId(err MessageErr, id string) MessageErr {
+	if setter, ok := err.(interface{ setRequestId(string) }); ok {
+		setter.setRequestId(id)
 	}
+
+	return err
+}
+
+func NewNotFoundError(message string) MessageErr {
+	return newMessageErr(CodeNotFound, message)
+}
+
+func NewNotAuthenticated(message string) MessageErr {
+	return newMessageErr(CodeNotAuthenticated, message)
 }
 
 func NewUnAuthorized(message string) MessageErr {
-	return &MessageErrData{
-		ErrMessage: message,
-		ErrStatus:  http.StatusForbidden,
-		ErrError:   "not_authorized",
-	}
+	return newMessageErr(CodeNotAuthorized, message)
 }
 
 func NewBadRequest(message string) MessageErr {
-	return &MessageErrData{
-		ErrMessage: message,
-		ErrStatus:  http.StatusBadRequest,
-		ErrError:   "bad_request",
-	}
+	return newMessageErr(CodeBadRequest, message)
 }
 
 func NewInternalServerError(message string) MessageErr {
-	return &MessageErrData{
-		ErrMessage: message,
-		ErrStatus:  http.StatusInternalServerError,
-		ErrError:   "server-error",
-	}
+	return newMessageErr(CodeServerError, message)
 }
 
 func NewUnprocessibleEntityError(message string) MessageErr {
-	return &MessageErrData{
-		ErrMessage: message,
-		ErrStatus:  http.StatusUnprocessableEntity,
-		ErrError:   "invalid_request",
+	return newMessageErr(CodeInvalidRequest, message)
+}
+
+func NewUnsupportedMediaTypeError(message string) MessageErr {
+	return newMessageErr(CodeUnsupportedMediaType, message)
+}
+
+func NewConflictError(message string) MessageErr {
+	return newMessageErr(CodeConflict, message)
+}
+
+func NewPreconditionFailedError(message string) MessageErr {
+	return newMessageErr(CodePreconditionFailed, message)
+}
+
+func NewPreconditionRequiredError(message string) MessageErr {
+	return newMessageErr(CodePreconditionRequired, message)
+}
+
+func NewServiceUnavailableError(message string) MessageErr {
+	return newMessageErr(CodeServiceUnavailable, message)
+}
+
+func NewSerializationFailureError(message string) MessageErr {
+	return newMessageErr(CodeSerializationFailure, message)
+}
+
+func NewGatewayTimeoutError(message string) MessageErr {
+	return newMessageErr(CodeGatewayTimeout, message)
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+type ValidationErrData struct {
+	MessageErrData
+	Fields []FieldError `json:"fields"`
+}
+
+func NewValidationError(fields []FieldError) MessageErr {
+	messages := make([]string, len(fields))
+	for i, field := range fields {
+		messages[i] = field.Message
+	}
+
+	return &ValidationErrData{
+		MessageErrData: *newMessageErr(CodeBadRequest, strings.Join(messages, ";")),
+		Fields:         fields,
 	}
 }
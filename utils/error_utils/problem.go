@@ -0,0 +1,44 @@
+package error_utils
+
+import "net/http"
+
+const ProblemContentType = "application/problem+json"
+
+// Problem is the RFC 7807 representation of a MessageErr. Code carries the
+// same value as MessageErrData.error so clients can switch between the two.
+type Problem struct {
+	Type      string       `json:"type" example:"/problems/not_found"`
+	Title     string       `json:"title" example:"Resource not found"`
+	Status    int          `json:"status" example:"404"`
+	Detail    string       `json:"detail" example:"no record found"`
+	Instance  string       `json:"instance,omitempty" example:"/todo/42"`
+	Code      string       `json:"code" example:"not_found"`
+	RequestId string       `json:"request_id,omitempty" example:"3f2a9c1e8b7d4a6f9e0c1b2a3d4e5f60"`
+	Fields    []FieldError `json:"fields,omitempty"`
+}
+
+func NewProblem(err MessageErr, instance string) *Problem {
+	problem := &Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(err.Status()),
+		Status:   err.Status(),
+		Detail:   err.Message(),
+		Instance: instance,
+		Code:     err.Error(),
+	}
+
+	if errorCode, ok := LookupCode(err.Error()); ok {
+		problem.Type = errorCode.Type
+		problem.Title = errorCode.Title
+	}
+
+	if data, ok := err.(interface{ requestId() string }); ok {
+		problem.RequestId = data.requestId()
+	}
+
+	if validationErr, ok := err.(*ValidationErrData); ok {
+		problem.Fields = validationErr.Fields
+	}
+
+	return problem
+}
@@ -0,0 +1,72 @@
+package etag_utils
+
+import (
+	"assignment-4/utils/error_utils"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var requireIfMatch bool
+
+// SetRequireIfMatch makes If-Match mandatory on conditional writes, missing
+// headers are then answered with 428.
+func SetRequireIfMatch(required bool) {
+	requireIfMatch = required
+}
+
+// ETag is the strong entity tag of a resource at version.
+func ETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// VariantETag is the strong entity tag of another representation of a
+// resource at version, such as a todo with its checklist. Bodies that differ
+// must not share a tag, or a cache could serve one for the other.
+func VariantETag(version int64, variant string) string {
+	return `"` + strconv.FormatInt(version, 10) + "-" + variant + `"`
+}
+
+// IfMatch returns the version the client expects from the If-Match header,
+// or 0 when any version will do.
+func IfMatch(c *gin.Context) (int64, error_utils.MessageErr) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+
+	if header == "" {
+		if requireIfMatch {
+			return 0, error_utils.NewPreconditionRequiredError("If-Match header is required, send the ETag of the last GET")
+		}
+		return 0, nil
+	}
+
+	if header == "*" {
+		return 0, nil
+	}
+
+	// If-Match uses the strong comparison, so a weak or foreign tag can
+	// never match one of ours. Writes only care about the version, so the
+	// tag of any variant will do.
+	value, _, _ := strings.Cut(strings.Trim(header, `"`), "-")
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || version <= 0 || !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) {
+		return 0, error_utils.NewPreconditionFailedError("If-Match does not match the current ETag")
+	}
+
+	return version, nil
+}
+
+// NoneMatch reports whether the If-None-Match header matches etag, in which
+// case a GET should be answered with 304 Not Modified.
+func NoneMatch(c *gin.Context, etag string) bool {
+	header := c.GetHeader("If-None-Match")
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,49 @@
+package logger_utils
+
+import (
+	"context"
+	"io"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const RequestIdHeader = "X-Request-ID"
+
+type requestIdKey struct{}
+
+// Setup makes the global logger write JSON lines to w at the given level,
+// one of debug, info, warn or error.
+func Setup(level string, w io.Writer) error {
+	parsed, err := zerolog.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	zerolog.SetGlobalLevel(parsed)
+
+	log.Logger = zerolog.New(w).With().Timestamp().Logger()
+	zerolog.DefaultContextLogger = &log.Logger
+
+	return nil
+}
+
+// WithRequestId returns a context carrying id and a logger that adds it to
+// every line.
+func WithRequestId(ctx context.Context, id string) context.Context {
+	logger := log.Logger.With().Str("request_id", id).Logger()
+
+	return logger.WithContext(context.WithValue(ctx, requestIdKey{}, id))
+}
+
+// RequestId is the id stored by WithRequestId, or "" outside a request.
+func RequestId(ctx context.Context) string {
+	id, _ := ctx.Value(requestIdKey{}).(string)
+
+	return id
+}
+
+// Ctx is the request scoped logger, falling back to the global logger.
+func Ctx(ctx context.Context) *zerolog.Logger {
+	return zerolog.Ctx(ctx)
+}
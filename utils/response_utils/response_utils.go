@@ -0,0 +1,56 @@
+package response_utils
+
+import (
+	"assignment-4/metrics"
+	"assignment-4/utils/error_utils"
+	"assignment-4/utils/logger_utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const retryAfterSeconds = "1"
+
+// Error writes err as problem details when the client asks for
+// application/problem+json, and as MessageErrData otherwise.
+func Error(c *gin.Context, err error_utils.MessageErr) {
+	metrics.Errors.WithLabelValues(err.Error(), strconv.Itoa(err.Status())).Inc()
+
+	ctx := c.Request.Context()
+
+	if cause := error_utils.Cause(err); cause != nil {
+		logger_utils.Ctx(ctx).Error().
+			Err(cause).
+			Str("code", err.Error()).
+			Int("status", err.Status()).
+			Str("path", c.Request.URL.Path).
+			Msg(err.Message())
+	}
+
+	if id := logger_utils.RequestId(ctx); id != "" {
+		error_utils.WithRequestId(err, id)
+	}
+
+	if err.Status() == http.StatusServiceUnavailable {
+		c.Header("Retry-After", retryAfterSeconds)
+	}
+
+	if wantsProblem(c) {
+		c.Header("Content-Type", error_utils.ProblemContentType)
+		c.JSON(err.Status(), error_utils.NewProblem(err, c.Request.URL.Path))
+		return
+	}
+
+	c.JSON(err.Status(), err)
+}
+
+// AbortWithError is Error for middlewares that stop the handler chain.
+func AbortWithError(c *gin.Context, err error_utils.MessageErr) {
+	c.Abort()
+	Error(c, err)
+}
+
+func wantsProblem(c *gin.Context) bool {
+	return c.NegotiateFormat(gin.MIMEJSON, error_utils.ProblemContentType) == error_utils.ProblemContentType
+}
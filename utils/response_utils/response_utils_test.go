@@ -0,0 +1,88 @@
+package response_utils
+
+import (
+	"assignment-4/utils/error_utils"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveError(accept string, err error_utils.MessageErr) *httptest.ResponseRecorder {
+	r := gin.Default()
+
+	r.GET("/todo/:todoId", func(c *gin.Context) {
+		Error(c, err)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/todo/42", nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func TestError_MessageErrByDefault(t *testing.T) {
+	for _, accept := range []string{"", "application/json", "*/*"} {
+		rr := serveError(accept, error_utils.NewNotFoundError("no record found"))
+
+		var errData error_utils.MessageErrData
+		require.Nil(t, json.Unmarshal(rr.Body.Bytes(), &errData))
+
+		assert.EqualValues(t, http.StatusNotFound, rr.Code)
+		assert.Contains(t, rr.Header().Get("Content-Type"), "application/json")
+		assert.EqualValues(t, "not_found", errData.Error())
+		assert.EqualValues(t, "no record found", errData.Message())
+	}
+}
+
+func TestError_ProblemDetails(t *testing.T) {
+	rr := serveError("application/problem+json", error_utils.NewNotFoundError("no record found"))
+
+	var problem error_utils.Problem
+	require.Nil(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+
+	assert.EqualValues(t, http.StatusNotFound, rr.Code)
+	assert.EqualValues(t, error_utils.ProblemContentType, rr.Header().Get("Content-Type"))
+	assert.EqualValues(t, error_utils.Problem{
+		Type:     "/problems/not_found",
+		Title:    "Resource not found",
+		Status:   http.StatusNotFound,
+		Detail:   "no record found",
+		Instance: "/todo/42",
+		Code:     "not_found",
+	}, problem)
+}
+
+func TestError_ProblemDetailsWithFields(t *testing.T) {
+	fields := []error_utils.FieldError{{Field: "title", Rule: "required", Message: "title is required"}}
+
+	rr := serveError("application/problem+json, application/json", error_utils.NewValidationError(fields))
+
+	var problem error_utils.Problem
+	require.Nil(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+
+	assert.EqualValues(t, http.StatusBadRequest, rr.Code)
+	assert.EqualValues(t, "bad_request", problem.Code)
+	assert.EqualValues(t, "/problems/bad_request", problem.Type)
+	assert.EqualValues(t, fields, problem.Fields)
+}
+
+func TestError_CauseNotLeaked(t *testing.T) {
+	cause := errors.New(`pq: password authentication failed for user "postgres"`)
+
+	rr := serveError("", error_utils.Wrap(error_utils.NewServiceUnavailableError("database is unavailable, try again later"), cause))
+
+	assert.EqualValues(t, http.StatusServiceUnavailable, rr.Code)
+	assert.EqualValues(t, "1", rr.Header().Get("Retry-After"))
+	assert.NotContains(t, rr.Body.String(), "password")
+}
@@ -0,0 +1,122 @@
+package token_utils
+
+import (
+	"assignment-4/utils/error_utils"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	AccessToken  = "access"
+	RefreshToken = "refresh"
+
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 7 * 24 * time.Hour
+)
+
+type Claims struct {
+	jwt.RegisteredClaims
+	Email     string `json:"email"`
+	TokenType string `json:"token_type"`
+}
+
+type Tokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+func (c *Claims) UserId() int64 {
+	userId, _ := strconv.ParseInt(c.Subject, 10, 64)
+	return userId
+}
+
+func GenerateTokens(userId int64, email string) (*Tokens, error_utils.MessageErr) {
+	accessToken, err := signToken(userId, email, AccessToken, AccessTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := signToken(userId, email, RefreshToken, RefreshTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+func ValidateToken(tokenString string, tokenType string) (*Claims, error_utils.MessageErr) {
+	secret, messageErr := jwtSecret()
+	if messageErr != nil {
+		return nil, messageErr
+	}
+
+	var claims Claims
+
+	token, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return secret, nil
+	})
+
+	if err != nil || !token.Valid {
+		return nil, error_utils.NewNotAuthenticated("invalid or expired token")
+	}
+
+	if claims.TokenType != tokenType || claims.UserId() <= 0 {
+		return nil, error_utils.NewNotAuthenticated("invalid or expired token")
+	}
+
+	return &claims, nil
+}
+
+func signToken(userId int64, email string, tokenType string, ttl time.Duration) (string, error_utils.MessageErr) {
+	secret, messageErr := jwtSecret()
+	if messageErr != nil {
+		return "", messageErr
+	}
+
+	now := time.Now()
+
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatInt(userId, 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+		Email:     email,
+		TokenType: tokenType,
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	if err != nil {
+		return "", error_utils.NewInternalServerError("something went wrong")
+	}
+
+	return signed, nil
+}
+
+var secret string
+
+// SetSecret sets the HMAC key tokens are signed and verified with.
+func SetSecret(value string) {
+	secret = value
+}
+
+func jwtSecret() ([]byte, error_utils.MessageErr) {
+	if secret == "" {
+		return nil, error_utils.NewInternalServerError("something went wrong")
+	}
+
+	return []byte(secret), nil
+}
@@ -0,0 +1,74 @@
+package token_utils
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateTokens_RoundTrip(t *testing.T) {
+	SetSecret("test-secret")
+
+	tokens, err := GenerateTokens(7, "john@mail.com")
+
+	require.Nil(t, err)
+	assert.EqualValues(t, "Bearer", tokens.TokenType)
+
+	claims, err := ValidateToken(tokens.AccessToken, AccessToken)
+
+	require.Nil(t, err)
+	assert.EqualValues(t, 7, claims.UserId())
+	assert.EqualValues(t, "john@mail.com", claims.Email)
+
+	claims, err = ValidateToken(tokens.RefreshToken, RefreshToken)
+
+	require.Nil(t, err)
+	assert.EqualValues(t, 7, claims.UserId())
+}
+
+func TestValidateToken_Rejected(t *testing.T) {
+	SetSecret("test-secret")
+
+	tokens, _ := GenerateTokens(7, "john@mail.com")
+
+	tests := []struct {
+		name      string
+		token     string
+		tokenType string
+		secret    string
+	}{
+		{
+			name:      "refresh token used as access token",
+			token:     tokens.RefreshToken,
+			tokenType: AccessToken,
+			secret:    "test-secret",
+		},
+		{
+			name:      "signed with another secret",
+			token:     tokens.AccessToken,
+			tokenType: AccessToken,
+			secret:    "another-secret",
+		},
+		{
+			name:      "malformed token",
+			token:     "not.a.token",
+			tokenType: AccessToken,
+			secret:    "test-secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetSecret(tt.secret)
+
+			claims, err := ValidateToken(tt.token, tt.tokenType)
+
+			assert.Nil(t, claims)
+			require.NotNil(t, err)
+			assert.EqualValues(t, http.StatusUnauthorized, err.Status())
+			assert.EqualValues(t, "not_authenticated", err.Error())
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package validation_utils
+
+import (
+	"assignment-4/utils/error_utils"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	tracer   = otel.Tracer("assignment-4/utils/validation_utils")
+)
+
+// ValidateStruct runs the govalidator tags of s and reports every failing
+// field by its json name, in declaration order.
+func ValidateStruct(s interface{}) []error_utils.FieldError {
+	_, err := govalidator.ValidateStruct(s)
+	if err == nil {
+		return nil
+	}
+
+	var fields []error_utils.FieldError
+
+	for _, err := range flattenErrors(err) {
+		var validatorErr govalidator.Error
+		if !errors.As(err, &validatorErr) {
+			fields = append(fields, error_utils.FieldError{Rule: "invalid", Message: err.Error()})
+			continue
+		}
+
+		fields = append(fields, error_utils.FieldError{
+			Field:   jsonFieldName(s, validatorErr.Name),
+			Rule:    validatorErr.Validator,
+			Message: validatorErr.Error(),
+		})
+	}
+
+	order := fieldOrder(s)
+	sort.SliceStable(fields, func(i, j int) bool {
+		return order[fields[i].Field] < order[fields[j].Field]
+	})
+
+	return fields
+}
+
+// BindJSON decodes the request body into obj, rejecting unknown fields.
+func BindJSON(c *gin.Context, obj interface{}) error_utils.MessageErr {
+	_, span := tracer.Start(c.Request.Context(), "BindJSON")
+	defer span.End()
+
+	err := bindJSON(c, obj)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Message())
+	}
+
+	return err
+}
+
+func bindJSON(c *gin.Context, obj interface{}) error_utils.MessageErr {
+	if c.Request.Body == nil {
+		return error_utils.NewBadRequest("invalid json body")
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return error_utils.NewBadRequest("invalid json body")
+	}
+
+	return DecodeJSON(body, obj)
+}
+
+// DecodeJSON decodes data into obj, rejecting unknown fields, fields tagged
+// readonly:"true" and anything after the JSON value. Type mismatches, unknown
+// and read only fields are reported per field, anything else as "invalid json body".
+func DecodeJSON(data []byte, obj interface{}) error_utils.MessageErr {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(obj)
+	if err == nil {
+		var trailing json.RawMessage
+		if decoder.Decode(&trailing) != io.EOF {
+			return error_utils.NewBadRequest("invalid json body")
+		}
+
+		if fields := readOnlyFields(data, reflect.ValueOf(obj), ""); len(fields) > 0 {
+			return error_utils.NewValidationError(fields)
+		}
+
+		return nil
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	var timeErr *time.ParseError
+
+	switch {
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return error_utils.NewValidationError([]error_utils.FieldError{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Message: typeErr.Field + " must be " + jsonTypeName(typeErr.Type),
+		}})
+	case errors.As(err, &timeErr):
+		if field := invalidTimeField(data, obj); field != "" {
+			return error_utils.NewValidationError([]error_utils.FieldError{{
+				Field:   field,
+				Rule:    "type",
+				Message: field + " must be an RFC 3339 timestamp",
+			}})
+		}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+
+		return error_utils.NewValidationError([]error_utils.FieldError{{
+			Field:   field,
+			Rule:    "unknown",
+			Message: field + " is not allowed",
+		}})
+	}
+
+	return error_utils.NewBadRequest("invalid json body")
+}
+
+// readOnlyFields reports the fields tagged readonly:"true" that data sets to
+// something other than their zero value, including those of nested objects
+// such as the todos of a batch. The server owns them and would otherwise
+// drop them silently.
+func readOnlyFields(data []byte, v reflect.Value, prefix string) []error_utils.FieldError {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	var fields []error_utils.FieldError
+
+	switch {
+	case v.Kind() == reflect.Struct && v.Type() != timeType:
+		var raw map[string]json.RawMessage
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil
+		}
+
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			name := jsonName(field)
+
+			value, ok := raw[name]
+			if !ok || field.PkgPath != "" || name == "-" {
+				continue
+			}
+
+			if field.Tag.Get("readonly") != "true" {
+				fields = append(fields, readOnlyFields(value, v.Field(i), prefix+name+".")...)
+				continue
+			}
+
+			if !isEmpty(v.Field(i)) {
+				fields = append(fields, error_utils.FieldError{
+					Field:   prefix + name,
+					Rule:    "readonly",
+					Message: prefix + name + " is read only",
+				})
+			}
+		}
+	case v.Kind() == reflect.Slice:
+		var raw []json.RawMessage
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil
+		}
+
+		for i := 0; i < len(raw) && i < v.Len(); i++ {
+			fields = append(fields, readOnlyFields(raw[i], v.Index(i), strings.TrimSuffix(prefix, ".")+"["+strconv.Itoa(i)+"].")...)
+		}
+	}
+
+	return fields
+}
+
+// ReadOnlyFields returns the json names of the fields of obj tagged
+// readonly:"true".
+func ReadOnlyFields(obj interface{}) []string {
+	t := structType(obj)
+
+	var names []string
+
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("readonly") == "true" {
+			names = append(names, jsonName(t.Field(i)))
+		}
+	}
+
+	return names
+}
+
+func isEmpty(v reflect.Value) bool {
+	if v.Kind() == reflect.Slice {
+		return v.Len() == 0
+	}
+
+	return v.IsZero()
+}
+
+func flattenErrors(err error) []error {
+	var validatorErrs govalidator.Errors
+	if !errors.As(err, &validatorErrs) {
+		return []error{err}
+	}
+
+	var flattened []error
+	for _, err := range validatorErrs {
+		flattened = append(flattened, flattenErrors(err)...)
+	}
+
+	return flattened
+}
+
+func structType(s interface{}) reflect.Type {
+	t := reflect.TypeOf(s)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+func jsonName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return field.Name
+	}
+
+	return name
+}
+
+func jsonFieldName(s interface{}, goName string) string {
+	field, ok := structType(s).FieldByName(goName)
+	if !ok {
+		return goName
+	}
+
+	return jsonName(field)
+}
+
+func fieldOrder(s interface{}) map[string]int {
+	t := structType(s)
+	order := map[string]int{}
+
+	for i := 0; i < t.NumField(); i++ {
+		order[jsonName(t.Field(i))] = i
+	}
+
+	return order
+}
+
+// invalidTimeField finds the timestamp field that failed to parse, since
+// encoding/json does not say which one it was.
+func invalidTimeField(data []byte, obj interface{}) string {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return ""
+	}
+
+	t := structType(obj)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if fieldType != timeType {
+			continue
+		}
+
+		value, ok := raw[jsonName(field)]
+		if !ok {
+			continue
+		}
+
+		var parsed *time.Time
+		if err := json.Unmarshal(value, &parsed); err != nil {
+			return jsonName(field)
+		}
+	}
+
+	return ""
+}
+
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
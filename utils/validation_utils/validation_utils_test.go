@@ -0,0 +1,124 @@
+package validation_utils
+
+import (
+	"assignment-4/utils/error_utils"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sample struct {
+	Id      int64      `json:"id" readonly:"true"`
+	Name    string     `json:"name" valid:"required~name is required,maxstringlength(5)~name must be at most 5 characters"`
+	Email   string     `json:"email" valid:"required~email is required,email~invalid email format"`
+	Count   int        `json:"count"`
+	Enabled bool       `json:"enabled"`
+	DueAt   *time.Time `json:"due_at"`
+}
+
+type sampleBatch struct {
+	Items []struct {
+		Id     int64   `json:"id"`
+		Sample *sample `json:"sample"`
+	} `json:"items"`
+}
+
+func TestValidateStruct_FieldErrors(t *testing.T) {
+	fields := ValidateStruct(&sample{Name: "too long", Email: "john"})
+
+	assert.EqualValues(t, []error_utils.FieldError{
+		{Field: "name", Rule: "maxstringlength", Message: "name must be at most 5 characters"},
+		{Field: "email", Rule: "email", Message: "invalid email format"},
+	}, fields)
+}
+
+func TestValidateStruct_Valid(t *testing.T) {
+	fields := ValidateStruct(&sample{Name: "john", Email: "john@mail.com"})
+
+	assert.Empty(t, fields)
+}
+
+func TestDecodeJSON_FieldErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		field error_utils.FieldError
+	}{
+		{
+			name:  "type mismatch",
+			body:  `{"name": "john", "count": "three"}`,
+			field: error_utils.FieldError{Field: "count", Rule: "type", Message: "count must be an integer"},
+		},
+		{
+			name:  "boolean mismatch",
+			body:  `{"enabled": "yes"}`,
+			field: error_utils.FieldError{Field: "enabled", Rule: "type", Message: "enabled must be a boolean"},
+		},
+		{
+			name:  "invalid timestamp",
+			body:  `{"name": "john", "due_at": "tomorrow"}`,
+			field: error_utils.FieldError{Field: "due_at", Rule: "type", Message: "due_at must be an RFC 3339 timestamp"},
+		},
+		{
+			name:  "unknown field",
+			body:  `{"name": "john", "owner": 2}`,
+			field: error_utils.FieldError{Field: "owner", Rule: "unknown", Message: "owner is not allowed"},
+		},
+		{
+			name:  "read only field",
+			body:  `{"id": 3, "name": "john"}`,
+			field: error_utils.FieldError{Field: "id", Rule: "readonly", Message: "id is read only"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s sample
+
+			err := DecodeJSON([]byte(tt.body), &s)
+
+			require.NotNil(t, err)
+			assert.EqualValues(t, http.StatusBadRequest, err.Status())
+			assert.EqualValues(t, "bad_request", err.Error())
+
+			validationErr, ok := err.(*error_utils.ValidationErrData)
+			require.True(t, ok)
+			assert.EqualValues(t, []error_utils.FieldError{tt.field}, validationErr.Fields)
+			assert.EqualValues(t, tt.field.Message, err.Message())
+		})
+	}
+}
+
+func TestDecodeJSON_InvalidBody(t *testing.T) {
+	for _, body := range []string{``, `{"name": `, `[1, 2]`, `{"name": "john"}garbage`, `{"name": "john"} {"name": "jane"}`, `{"name": "john"}}`} {
+		var s sample
+
+		err := DecodeJSON([]byte(body), &s)
+
+		require.NotNil(t, err)
+		assert.EqualValues(t, http.StatusBadRequest, err.Status())
+		assert.EqualValues(t, "invalid json body", err.Message())
+	}
+}
+
+func TestDecodeJSON_ReadOnlyFields(t *testing.T) {
+	var s sample
+
+	// zero values are what clients sending back a whole object have
+	require.Nil(t, DecodeJSON([]byte(`{"id": 0, "name": "john"}`), &s))
+
+	var batch sampleBatch
+
+	err := DecodeJSON([]byte(`{"items": [{"id": 1, "sample": {"name": "john"}}, {"id": 2, "sample": {"id": 5, "name": "jane"}}]}`), &batch)
+
+	require.NotNil(t, err)
+
+	validationErr, ok := err.(*error_utils.ValidationErrData)
+	require.True(t, ok)
+	assert.EqualValues(t, []error_utils.FieldError{
+		{Field: "items[1].sample.id", Rule: "readonly", Message: "items[1].sample.id is read only"},
+	}, validationErr.Fields)
+}